@@ -1,24 +1,41 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/reflection"
 
 	grpcapi "ai-speech-ingress-service/internal/api/grpc"
+	"ai-speech-ingress-service/internal/audit"
 	"ai-speech-ingress-service/internal/config"
 	"ai-speech-ingress-service/internal/events"
+	"ai-speech-ingress-service/internal/observability"
+	"ai-speech-ingress-service/internal/observability/metrics"
+	pb "ai-speech-ingress-service/proto"
 )
 
+// streamServiceName is the health check service name of AudioStreamService.
+var streamServiceName = pb.AudioStreamService_ServiceDesc.ServiceName
+
 func main() {
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
+	metrics.Default.SetTenantAllowlist(cfg.Metrics.TenantAllowlist)
 
 	// Create Kafka publisher with separate topics for partial and final transcripts
 	publisher := events.New(&events.Config{
@@ -27,27 +44,127 @@ func main() {
 		TopicPartial: cfg.Kafka.TopicPartial,
 		TopicFinal:   cfg.Kafka.TopicFinal,
 		Principal:    cfg.Kafka.Principal,
+
+		SerializationFormat: cfg.Kafka.SerializationFormat,
+		SchemaRegistryURL:   cfg.Kafka.SchemaRegistryURL,
+
+		EventFormat: cfg.Kafka.EventFormat,
+		EventSource: cfg.Kafka.EventSource,
+
+		Compression: cfg.Kafka.Compression,
+
+		PartitionStrategy: cfg.Kafka.PartitionStrategy,
+
+		StaticHeaders: cfg.Kafka.StaticHeaders,
+		TenantTopics:  cfg.Kafka.TenantTopics,
+
+		DisablePartials: !cfg.Kafka.PublishPartials,
+		MaxMessageBytes: cfg.Kafka.MaxMessageBytes,
+		BufferSize:      cfg.Kafka.BufferSize,
+		Workers:         cfg.Kafka.PublishWorkers,
+
+		PartialLogSampleRate: cfg.Log.PartialSampleRate,
 	})
 	defer publisher.Close()
 
+	// Register Avro schemas up front so a misconfigured registry fails fast
+	// instead of failing every publish.
+	if err := publisher.RegisterSchemas(context.Background()); err != nil {
+		log.Fatalf("failed to register event schemas: %v", err)
+	}
+
 	lis, err := net.Listen("tcp", ":"+cfg.Port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	server := grpc.NewServer()
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(cfg.Stream.MaxRecvMsgBytes)}
+	creds, err := grpcapi.ServerCredentials(cfg.GRPC)
+	if err != nil {
+		log.Fatalf("failed to configure TLS: %v", err)
+	}
+	switch {
+	case creds == nil:
+		log.Println("WARNING: serving gRPC without TLS (GRPC_ALLOW_INSECURE)")
+	case cfg.GRPC.TLSClientCAFile != "":
+		log.Printf("Serving gRPC with mutual TLS: cert=%s clientCA=%s", cfg.GRPC.TLSCertFile, cfg.GRPC.TLSClientCAFile)
+		opts = append(opts, grpc.Creds(creds))
+	default:
+		log.Printf("Serving gRPC with TLS: cert=%s", cfg.GRPC.TLSCertFile)
+		opts = append(opts, grpc.Creds(creds))
+	}
+	if keys := cfg.Stream.RequiredMetadata; len(keys) > 0 {
+		log.Printf("Requiring stream metadata: %v", keys)
+		opts = append(opts, grpc.ChainStreamInterceptor(grpcapi.RequiredMetadataInterceptor(keys)))
+	}
+	server := grpc.NewServer(opts...)
 
 	// Register gRPC health check service
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(server, healthServer)
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
-	healthServer.SetServingStatus("ai.speech.ingress.AudioStreamService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(streamServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
 
 	// Register application services
-	grpcapi.Register(server, publisher, cfg.STTProvider)
+	grpcServer, err := grpcapi.RegisterWithConfig(server, publisher, cfg)
+	if err != nil {
+		log.Fatalf("failed to configure gRPC service: %v", err)
+	}
 
-	// Enable gRPC reflection for debugging tools like grpcurl
-	reflection.Register(server)
+	// Dropped segments and STT errors go to a separate audit trail
+	switch cfg.Audit.Sink {
+	case "file":
+		auditLogger, err := audit.NewFileLogger(cfg.Audit.File)
+		if err != nil {
+			log.Fatalf("failed to open audit log: %v", err)
+		}
+		defer auditLogger.Close()
+		grpcServer.SetAuditLogger(auditLogger)
+		log.Printf("Audit trail: file %s", cfg.Audit.File)
+	case "kafka":
+		auditLogger := audit.NewKafkaLogger(cfg.Kafka.Brokers, cfg.Audit.Topic)
+		defer auditLogger.Close()
+		grpcServer.SetAuditLogger(auditLogger)
+		log.Printf("Audit trail: kafka topic %s", cfg.Audit.Topic)
+	}
+
+	// Observability HTTP server for operational endpoints
+	httpServer := observability.NewServer(cfg.HTTP.Port)
+	httpServer.Handle("/metrics", promhttp.Handler())
+	if cfg.HTTP.DebugEndpointsEnabled {
+		log.Println("Debug endpoints enabled: /debug/streams")
+		httpServer.Handle("/debug/streams", grpcServer.DebugStreamsHandler())
+	}
+	httpServer.Start()
+
+	// The synthetic STT probe gates stream readiness on the provider working
+	stopProbe := func() {}
+	if cfg.Probe.Interval > 0 {
+		probe, err := grpcServer.NewSTTProbe(cfg.Probe, func(ready bool) {
+			status := grpc_health_v1.HealthCheckResponse_SERVING
+			if !ready {
+				status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			}
+			healthServer.SetServingStatus(streamServiceName, status)
+		})
+		if err != nil {
+			log.Fatalf("failed to configure STT probe: %v", err)
+		}
+		log.Printf("STT probe enabled: interval=%s timeout=%s", cfg.Probe.Interval, cfg.Probe.Timeout)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			probe.Run(ctx)
+		}()
+		stopProbe = func() {
+			cancel()
+			<-done
+		}
+	}
+
+	// Reflection is for debugging tools like grpcurl; keep it off in production
+	grpcapi.RegisterReflection(server, cfg.GRPC.ReflectionEnabled)
 
 	go func() {
 		log.Printf("Speech Ingress Service started on :%s", cfg.Port)
@@ -56,11 +173,52 @@ func main() {
 		}
 	}()
 
+	// SIGHUP reloads STT tuning and partial/segment settings for new streams
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Println("SIGHUP received, reloading configuration")
+			newCfg, err := config.Load()
+			if err != nil {
+				log.Printf("reload failed, keeping current configuration: %v", err)
+				continue
+			}
+			if err := newCfg.Validate(); err != nil {
+				log.Printf("reload rejected, keeping current configuration:\n%v", err)
+				continue
+			}
+			if err := grpcServer.Reload(newCfg); err != nil {
+				log.Printf("reload failed, keeping current configuration: %v", err)
+			}
+		}
+	}()
+
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 	<-sig
 
+	// The probe must not mark the service ready again while draining
+	stopProbe()
+
+	// Stop advertising the stream service first; the server keeps accepting
+	// streams during the drain delay so load balancers can catch up
+	healthServer.SetServingStatus(streamServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	if d := cfg.Shutdown.DrainDelay; d > 0 {
+		log.Printf("draining for %s before shutdown", d)
+		time.Sleep(d)
+	}
+
 	log.Println("shutting down gRPC server")
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 	server.GracefulStop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := publisher.Flush(ctx); err != nil {
+		log.Printf("kafka flush: %v", err)
+	}
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Printf("observability server shutdown: %v", err)
+	}
 }
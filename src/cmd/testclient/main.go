@@ -2,17 +2,41 @@ package main
 
 import (
 	"context"
+	"flag"
+	"io"
 	"log"
+	"os"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 
+	"ai-speech-ingress-service/pkg/client"
 	pb "ai-speech-ingress-service/proto"
 )
 
 func main() {
-	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	addr := flag.String("addr", "localhost:50051", "server address")
+	timeout := flag.Duration("timeout", 30*time.Second, "overall deadline for the stream")
+	// Servers reject pings more frequent than their enforcement policy allows
+	// (5m by default), so keep this at or above the server's minimum.
+	keepaliveTime := flag.Duration("keepalive-time", 5*time.Minute, "interval between keepalive pings")
+	keepaliveTimeout := flag.Duration("keepalive-timeout", 20*time.Second, "wait for a keepalive ack before closing the connection")
+	audioPath := flag.String("audio", "", `raw PCM file to stream (no WAV header), or "-" for stdin; empty sends synthetic mock frames`)
+	sampleRate := flag.Int("sample-rate", 8000, "sample rate of -audio in Hz")
+	channels := flag.Int("channels", 1, "interleaved channels of -audio")
+	bits := flag.Int("bits", 16, "bits per sample of -audio: 16 (LINEAR16) or 8 (MULAW)")
+	frameDuration := flag.Duration("frame", 100*time.Millisecond, "audio per frame for -audio")
+	flag.Parse()
+
+	conn, err := grpc.NewClient(*addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    *keepaliveTime,
+			Timeout: *keepaliveTimeout,
+		}),
+	)
 	if err != nil {
 		log.Fatalf("failed to connect: %v", err)
 	}
@@ -20,12 +44,37 @@ func main() {
 
 	log.Println("Connected to server")
 
-	client := pb.NewAudioStreamServiceClient(conn)
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
 	defer cancel()
 
-	stream, err := client.StreamAudio(ctx)
+	if *audioPath != "" {
+		var r io.Reader = os.Stdin
+		if *audioPath != "-" {
+			f, err := os.Open(*audioPath)
+			if err != nil {
+				log.Fatalf("failed to open audio: %v", err)
+			}
+			defer f.Close()
+			r = f
+		}
+		encoding := map[int]string{16: "LINEAR16", 8: "MULAW"}[*bits]
+		if encoding == "" {
+			log.Fatalf("unsupported -bits %d (use 16 for LINEAR16 or 8 for MULAW)", *bits)
+		}
+		ack, err := client.New(conn).StreamAudio(ctx, r, client.Options{
+			InteractionID: "int-123",
+			TenantID:      "tenant-456",
+			Format:        client.Format{SampleRateHz: *sampleRate, Channels: *channels, Encoding: encoding},
+			FrameDuration: *frameDuration,
+		})
+		if err != nil {
+			log.Fatalf("failed to stream audio: %v", err)
+		}
+		logAck(ack)
+		return
+	}
+
+	stream, err := pb.NewAudioStreamServiceClient(conn).StreamAudio(ctx)
 	if err != nil {
 		log.Fatalf("failed to create stream: %v", err)
 	}
@@ -39,17 +88,22 @@ func main() {
 		frame := &pb.AudioFrame{
 			InteractionId: "int-123",
 			TenantId:      "tenant-456",
-			Audio:         []byte("audio-chunk-" + string(rune('0'+i))),
+			Audio:         make([]byte, 320), // 20ms of 8kHz LINEAR16 silence
 			AudioOffsetMs: int64(i * 100),
+			Seq:           uint64(i),
 		}
-		log.Printf("Sending frame %d/%d: interactionId=%s", i, numFrames, frame.InteractionId)
+		log.Printf("Sending frame %d/%d: interactionId=%s seq=%d", i, numFrames, frame.InteractionId, frame.Seq)
 		if err := stream.Send(frame); err != nil {
 			log.Fatalf("failed to send frame: %v", err)
 		}
 		time.Sleep(100 * time.Millisecond)
 	}
 
-	// Signal end of stream
+	closeStream(stream)
+}
+
+// closeStream signals the end of the stream and logs the server's ack.
+func closeStream(stream pb.AudioStreamService_StreamAudioClient) {
 	log.Println("Closing stream...")
 
 	// Close and receive response
@@ -58,5 +112,12 @@ func main() {
 		log.Fatalf("failed to receive ack: %v", err)
 	}
 
-	log.Printf("Received ack: interactionId=%s", ack.InteractionId)
+	logAck(ack)
+}
+
+func logAck(ack *pb.StreamAck) {
+	log.Printf("Received ack: interactionId=%s segmentState=%s", ack.InteractionId, ack.SegmentState)
+	if ack.SegmentDropped {
+		log.Printf("WARNING: last segment was dropped without a final: reason=%s", ack.DropReason)
+	}
 }
@@ -0,0 +1,23 @@
+package grpcapi
+
+import (
+	"context"
+
+	pb "ai-speech-ingress-service/proto"
+)
+
+// GetCapabilities reports the active STT provider and recognition settings so
+// clients can check compatibility before opening a stream.
+func (s *Server) GetCapabilities(ctx context.Context, _ *pb.GetCapabilitiesRequest) (*pb.Capabilities, error) {
+	base, _ := s.streamSettings()
+	sttCfg := languageSettings(base, "")
+	return &pb.Capabilities{
+		SttProvider:        s.sttProvider,
+		SupportedEncodings: s.supportedEncodings(),
+		LanguageCode:       sttCfg.LanguageCode,
+		SampleRateHz:       int32(sttCfg.SampleRateHz),
+		Encoding:           sttCfg.Encoding,
+		// All adapters end a segment at each detected utterance boundary
+		SingleUtterance: true,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package grpcapi
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"ai-speech-ingress-service/internal/config"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	s := &Server{
+		sttProvider: "google",
+		sttConfig: config.STTConfig{
+			SampleRateHz: 16000,
+			Encoding:     "MULAW",
+			LanguageCode: "en-GB",
+		},
+	}
+
+	caps, err := s.GetCapabilities(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetCapabilities: %v", err)
+	}
+	if caps.SttProvider != "google" || caps.LanguageCode != "en-GB" || caps.SampleRateHz != 16000 || caps.Encoding != "MULAW" {
+		t.Errorf("unexpected capabilities: %v", caps)
+	}
+	for _, enc := range []string{"LINEAR16", "MULAW", "OGG_OPUS"} {
+		if !slices.Contains(caps.SupportedEncodings, enc) {
+			t.Errorf("supported encodings %v missing %s", caps.SupportedEncodings, enc)
+		}
+	}
+	if slices.Contains(caps.SupportedEncodings, "ENCODING_UNSPECIFIED") {
+		t.Error("supported encodings should not include ENCODING_UNSPECIFIED")
+	}
+	if !caps.SingleUtterance {
+		t.Error("expected single utterance mode")
+	}
+}
@@ -0,0 +1,56 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "ai-speech-ingress-service/proto"
+)
+
+// DebugStreamsHandler returns an HTTP handler that reports the state of all
+// active streams as JSON. It exposes call metadata and should only be mounted
+// when debug endpoints are explicitly enabled.
+func (s *Server) DebugStreamsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"streams": s.streams.Snapshot(),
+		})
+	})
+}
+
+// GetInteractionStatus reports the current segment and live metrics of an
+// active interaction. Like /debug/streams it exposes call metadata, so it is
+// only served when debug endpoints are enabled.
+func (s *Server) GetInteractionStatus(ctx context.Context, req *pb.GetInteractionStatusRequest) (*pb.InteractionStatus, error) {
+	if !s.debugEnabled {
+		return nil, status.Error(codes.PermissionDenied, "interaction status requires DEBUG_ENDPOINTS_ENABLED")
+	}
+	if req.GetInteractionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "interactionId is required")
+	}
+
+	st, streams, ok := s.streams.Lookup(req.GetInteractionId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no active stream for interaction %s", req.GetInteractionId())
+	}
+	return &pb.InteractionStatus{
+		InteractionId:   st.InteractionID,
+		TenantId:        st.TenantID,
+		SegmentId:       st.SegmentID,
+		State:           st.State,
+		AudioBytes:      st.AudioBytes,
+		AudioDurationMs: st.AudioDurationMs,
+		PartialCount:    int32(st.PartialCount),
+		DurationMs:      st.DurationMs,
+		ActiveStreams:   int32(streams),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ai-speech-ingress-service/internal/service/audio"
+	"ai-speech-ingress-service/internal/service/stt/mock"
+	pb "ai-speech-ingress-service/proto"
+)
+
+func TestGetInteractionStatus(t *testing.T) {
+	s, _ := newTestServer(t)
+	h := audio.NewHandler(mock.New(), nil, nil, "int-1", "tenant-1", "int-1-seg-1")
+	s.streams.Register(h)
+	ctx := context.Background()
+
+	_, err := s.GetInteractionStatus(ctx, &pb.GetInteractionStatusRequest{InteractionId: "int-1"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("debug disabled: expected PermissionDenied, got %v", err)
+	}
+
+	s.debugEnabled = true
+	got, err := s.GetInteractionStatus(ctx, &pb.GetInteractionStatusRequest{InteractionId: "int-1"})
+	if err != nil {
+		t.Fatalf("GetInteractionStatus: %v", err)
+	}
+	if got.SegmentId != "int-1-seg-1" || got.State != "OPEN" || got.TenantId != "tenant-1" || got.ActiveStreams != 1 {
+		t.Errorf("unexpected status: %+v", got)
+	}
+
+	_, err = s.GetInteractionStatus(ctx, &pb.GetInteractionStatusRequest{InteractionId: "int-2"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unknown interaction: expected NotFound, got %v", err)
+	}
+	_, err = s.GetInteractionStatus(ctx, &pb.GetInteractionStatusRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty interactionId: expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package grpcapi
+
+import (
+	"cmp"
+	"log"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/service/stt/google"
+)
+
+// Sample rates accepted by the STT providers.
+const (
+	minSampleRateHz = 8000
+	maxSampleRateHz = 48000
+)
+
+// mulawSampleRateHz is the only rate MULAW (G.711 telephony audio) is sent at.
+const mulawSampleRateHz = 8000
+
+// supportedEncodings lists the encoding names the active provider accepts.
+func (s *Server) supportedEncodings() []string {
+	if s.sttProvider == "whisper" {
+		// Uploaded as WAV, which only wraps uncompressed audio
+		return []string{"LINEAR16", "MULAW"}
+	}
+	return google.SupportedEncodings()
+}
+
+// declaredFormat is the audio format a client declared for a request. Zero
+// fields are unset.
+type declaredFormat struct {
+	sampleRateHz int32
+	encoding     string
+	channels     int32
+}
+
+// maxChannels returns the most channels the active provider accepts.
+func (s *Server) maxChannels() int {
+	if s.sttProvider == "whisper" {
+		// The WAV upload is written as mono
+		return 1
+	}
+	return config.MaxChannels
+}
+
+// negotiateFormat resolves the audio format for a request from the format the
+// client declared, e.g. in its first frame. Unset fields keep the values from
+// the configured base. A declared format that differs from the config is used if the
+// provider supports it; otherwise the stream is rejected with InvalidArgument
+// rather than recognized with the wrong settings.
+func (s *Server) negotiateFormat(base config.STTConfig, interactionId string, declared declaredFormat) (config.STTConfig, error) {
+	cfg := base
+	rate := int(declared.sampleRateHz)
+	encoding := strings.ToUpper(declared.encoding)
+	channels := int(declared.channels)
+	if encoding == "MULAW" && rate == 0 {
+		// Telephony clients often declare only the codec
+		rate = mulawSampleRateHz
+	}
+	if (rate == 0 || rate == cfg.SampleRateHz) &&
+		(encoding == "" || strings.EqualFold(encoding, cfg.Encoding)) &&
+		(channels == 0 || channels == max(cfg.Channels, 1)) {
+		return cfg, nil
+	}
+
+	if rate != 0 && (rate < minSampleRateHz || rate > maxSampleRateHz) {
+		return s.rejectFormat(base, interactionId, "sample rate %d Hz is outside %d-%d Hz", rate, minSampleRateHz, maxSampleRateHz)
+	}
+	if encoding != "" && !slices.Contains(s.supportedEncodings(), encoding) {
+		return s.rejectFormat(base, interactionId, "encoding %q is not supported by provider %s", declared.encoding, s.sttProvider)
+	}
+	if strings.EqualFold(cmp.Or(encoding, cfg.Encoding), "MULAW") && cmp.Or(rate, cfg.SampleRateHz) != mulawSampleRateHz {
+		return s.rejectFormat(base, interactionId, "MULAW audio must be %d Hz, got %d Hz", mulawSampleRateHz, cmp.Or(rate, cfg.SampleRateHz))
+	}
+	if channels < 0 || channels > s.maxChannels() {
+		return s.rejectFormat(base, interactionId, "%d channels are not supported by provider %s (max %d)", channels, s.sttProvider, s.maxChannels())
+	}
+
+	if rate != 0 {
+		cfg.SampleRateHz = rate
+	}
+	if encoding != "" {
+		cfg.Encoding = encoding
+	}
+	if channels != 0 {
+		cfg.Channels = channels
+	}
+	s.metrics.FormatMismatches.WithLabelValues("reconfigured").Inc()
+	log.Printf("Audio format mismatch: interactionId=%s configured=%s/%dHz/%dch declared=%s/%dHz/%dch, reconfiguring stream",
+		interactionId, base.Encoding, base.SampleRateHz, base.Channels, cfg.Encoding, cfg.SampleRateHz, cfg.Channels)
+	return cfg, nil
+}
+
+func (s *Server) rejectFormat(base config.STTConfig, interactionId string, format string, args ...any) (config.STTConfig, error) {
+	err := status.Errorf(codes.InvalidArgument, format, args...)
+	s.metrics.FormatMismatches.WithLabelValues("rejected").Inc()
+	log.Printf("Audio format rejected: interactionId=%s err=%v", interactionId, err)
+	return base, err
+}
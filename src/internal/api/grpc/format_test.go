@@ -0,0 +1,106 @@
+package grpcapi
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		name         string
+		declared     declaredFormat
+		wantRate     int
+		wantEncoding string
+		wantChannels int
+		wantOutcome  string
+	}{
+		{"unset", declaredFormat{}, 8000, "LINEAR16", 1, ""},
+		{"matches config", declaredFormat{8000, "linear16", 1}, 8000, "LINEAR16", 1, ""},
+		{"different rate", declaredFormat{sampleRateHz: 16000}, 16000, "LINEAR16", 1, "reconfigured"},
+		{"different encoding", declaredFormat{encoding: "mulaw"}, 8000, "MULAW", 1, "reconfigured"},
+		{"stereo", declaredFormat{channels: 2}, 8000, "LINEAR16", 2, "reconfigured"},
+		{"rate out of range", declaredFormat{sampleRateHz: 96000}, 0, "", 0, "rejected"},
+		{"unknown encoding", declaredFormat{encoding: "MP3_FANCY"}, 0, "", 0, "rejected"},
+		{"too many channels", declaredFormat{channels: 9}, 0, "", 0, "rejected"},
+		{"mulaw off 8kHz", declaredFormat{16000, "MULAW", 1}, 0, "", 0, "rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestServer(t)
+
+			cfg, err := s.negotiateFormat(s.sttConfig, "int-1", tt.declared)
+
+			if tt.wantOutcome == "rejected" {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("err = %v, want InvalidArgument", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("negotiateFormat: %v", err)
+				}
+				if cfg.SampleRateHz != tt.wantRate || cfg.Encoding != tt.wantEncoding || cfg.Channels != tt.wantChannels {
+					t.Errorf("format = %s/%d/%dch, want %s/%d/%dch",
+						cfg.Encoding, cfg.SampleRateHz, cfg.Channels, tt.wantEncoding, tt.wantRate, tt.wantChannels)
+				}
+			}
+			for _, outcome := range []string{"reconfigured", "rejected"} {
+				want := 0.0
+				if outcome == tt.wantOutcome {
+					want = 1
+				}
+				if got := testutil.ToFloat64(m.FormatMismatches.WithLabelValues(outcome)); got != want {
+					t.Errorf("audio_format_mismatches_total{%s} = %v, want %v", outcome, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNegotiateFormat_MulawDefaultsTo8kHz(t *testing.T) {
+	s, _ := newTestServer(t)
+	base := s.sttConfig
+	base.SampleRateHz = 16000
+
+	cfg, err := s.negotiateFormat(base, "int-1", declaredFormat{encoding: "MULAW"})
+	if err != nil {
+		t.Fatalf("negotiateFormat: %v", err)
+	}
+	if cfg.Encoding != "MULAW" || cfg.SampleRateHz != 8000 {
+		t.Errorf("format = %s/%d, want MULAW/8000", cfg.Encoding, cfg.SampleRateHz)
+	}
+
+	// A rate change alone can't move a MULAW stream off 8kHz either
+	base.Encoding, base.SampleRateHz = "MULAW", 8000
+	if _, err := s.negotiateFormat(base, "int-1", declaredFormat{sampleRateHz: 16000}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestStreamAudio_RejectsUnsupportedFormat(t *testing.T) {
+	s, _ := newTestServer(t)
+	first := frames(1)
+	first[0].SampleRateHz = 4000
+	stream := &fakeAudioStream{frames: first}
+
+	if err := s.StreamAudio(stream); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("StreamAudio err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestNegotiateFormat_WhisperEncodings(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.sttProvider = "whisper"
+
+	if _, err := s.negotiateFormat(s.sttConfig, "int-1", declaredFormat{encoding: "OGG_OPUS"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("OGG_OPUS on whisper: err = %v, want InvalidArgument", err)
+	}
+	if _, err := s.negotiateFormat(s.sttConfig, "int-1", declaredFormat{encoding: "MULAW"}); err != nil {
+		t.Errorf("MULAW on whisper: %v", err)
+	}
+	if _, err := s.negotiateFormat(s.sttConfig, "int-1", declaredFormat{channels: 2}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("stereo on whisper: err = %v, want InvalidArgument", err)
+	}
+}
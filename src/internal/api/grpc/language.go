@@ -0,0 +1,35 @@
+package grpcapi
+
+import (
+	"strings"
+
+	"ai-speech-ingress-service/internal/config"
+)
+
+// languageSettings returns base for a stream in the given language: the
+// language's profile, if any, replaces the global sample rate, encoding and
+// model. An empty language selects the configured one. Language codes match
+// case-insensitively.
+func languageSettings(base config.STTConfig, language string) config.STTConfig {
+	cfg := base
+	if language != "" {
+		cfg.LanguageCode = language
+	}
+
+	for lang, p := range cfg.LanguageProfiles {
+		if !strings.EqualFold(lang, cfg.LanguageCode) {
+			continue
+		}
+		if p.SampleRateHz != 0 {
+			cfg.SampleRateHz = p.SampleRateHz
+		}
+		if p.Encoding != "" {
+			cfg.Encoding = p.Encoding
+		}
+		if p.Model != "" {
+			cfg.Model = p.Model
+		}
+		break
+	}
+	return cfg
+}
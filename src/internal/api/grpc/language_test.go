@@ -0,0 +1,44 @@
+package grpcapi
+
+import (
+	"testing"
+
+	"ai-speech-ingress-service/internal/config"
+)
+
+func TestLanguageSettings(t *testing.T) {
+	base := config.STTConfig{
+		SampleRateHz: 8000,
+		Encoding:     "MULAW",
+		LanguageCode: "en-US",
+		Model:        "phone_call",
+		LanguageProfiles: map[string]config.LanguageProfile{
+			"es-ES": {SampleRateHz: 16000, Encoding: "LINEAR16", Model: "latest_long"},
+			"fr-FR": {Model: "latest_short"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		language string
+		want     config.LanguageProfile
+		wantLang string
+	}{
+		{"profile applied", "es-ES", config.LanguageProfile{SampleRateHz: 16000, Encoding: "LINEAR16", Model: "latest_long"}, "es-ES"},
+		{"case-insensitive", "ES-es", config.LanguageProfile{SampleRateHz: 16000, Encoding: "LINEAR16", Model: "latest_long"}, "ES-es"},
+		{"partial profile keeps global settings", "fr-FR", config.LanguageProfile{SampleRateHz: 8000, Encoding: "MULAW", Model: "latest_short"}, "fr-FR"},
+		{"no profile falls back to global", "de-DE", config.LanguageProfile{SampleRateHz: 8000, Encoding: "MULAW", Model: "phone_call"}, "de-DE"},
+		{"empty selects configured language", "", config.LanguageProfile{SampleRateHz: 8000, Encoding: "MULAW", Model: "phone_call"}, "en-US"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := languageSettings(base, tt.language)
+			if got.LanguageCode != tt.wantLang {
+				t.Errorf("LanguageCode = %q, want %q", got.LanguageCode, tt.wantLang)
+			}
+			if p := (config.LanguageProfile{SampleRateHz: got.SampleRateHz, Encoding: got.Encoding, Model: got.Model}); p != tt.want {
+				t.Errorf("settings = %+v, want %+v", p, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package grpcapi
+
+import (
+	"time"
+
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/service/audio"
+)
+
+// tenantLimits holds per-tenant overrides of the global stream and segment
+// limits, keyed by tenantId.
+type tenantLimits map[string]config.SegmentLimits
+
+// apply returns the handler config and interaction duration cap for a stream
+// of tenantId: the global values, with the tenant's overrides in their place.
+// A fixed-interval override only applies under the fixed-interval policy.
+func (l tenantLimits) apply(tenantId string, hc audio.Config, maxDuration time.Duration) (audio.Config, time.Duration) {
+	o, ok := l[tenantId]
+	if !ok {
+		return hc, maxDuration
+	}
+	if o.MaxInteractionDuration > 0 {
+		maxDuration = o.MaxInteractionDuration
+	}
+	if o.MaxFrameBytes > 0 {
+		hc.MaxFrameBytes = o.MaxFrameBytes
+	}
+	if _, fixed := hc.Boundary.(audio.FixedIntervalBoundary); fixed && o.FixedInterval > 0 {
+		hc.Boundary = audio.FixedIntervalBoundary{Every: o.FixedInterval}
+	}
+	return hc, maxDuration
+}
@@ -0,0 +1,51 @@
+package grpcapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/service/audio"
+)
+
+func TestTenantLimits_Apply(t *testing.T) {
+	limits := tenantLimits{
+		"acme": {MaxInteractionDuration: 2 * time.Hour, MaxFrameBytes: 640, FixedInterval: 10 * time.Second},
+	}
+	base := audio.DefaultConfig()
+	base.MaxFrameBytes = 1 << 20
+	base.Boundary = audio.FixedIntervalBoundary{Every: 5 * time.Second}
+
+	hc, maxDuration := limits.apply("acme", base, time.Hour)
+	if maxDuration != 2*time.Hour || hc.MaxFrameBytes != 640 {
+		t.Errorf("acme: maxDuration=%s maxFrameBytes=%d, want the overrides", maxDuration, hc.MaxFrameBytes)
+	}
+	if b, _ := hc.Boundary.(audio.FixedIntervalBoundary); b.Every != 10*time.Second {
+		t.Errorf("acme: boundary = %v, want a 10s interval", hc.Boundary)
+	}
+
+	hc, maxDuration = limits.apply("globex", base, time.Hour)
+	if maxDuration != time.Hour || hc.MaxFrameBytes != 1<<20 || hc.Boundary != base.Boundary {
+		t.Errorf("globex: maxDuration=%s maxFrameBytes=%d boundary=%v, want the global limits", maxDuration, hc.MaxFrameBytes, hc.Boundary)
+	}
+
+	// The interval only replaces an existing fixed-interval policy
+	if hc, _ := limits.apply("acme", audio.DefaultConfig(), 0); hc.Boundary != (audio.SingleUtteranceBoundary{}) {
+		t.Errorf("acme: boundary = %v, want the global single-utterance policy", hc.Boundary)
+	}
+}
+
+func TestStreamAudio_TenantFrameLimit(t *testing.T) {
+	s, m := newTestServerWithConfig(t, config.StreamConfig{MaxFrameBytes: 1 << 20})
+	s.limits = tenantLimits{"tenant-1": {MaxFrameBytes: 160}}
+	stream := &fakeAudioStream{frames: frames(1, 2)}
+
+	if err := s.StreamAudio(stream); err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+	if got := testutil.ToFloat64(m.FramesRejected.WithLabelValues("oversized")); got != 2 {
+		t.Errorf("frames_rejected_total{oversized} = %v, want 2 (320-byte frames over the tenant's limit)", got)
+	}
+}
@@ -0,0 +1,224 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/models"
+	"ai-speech-ingress-service/internal/observability/metrics"
+	"ai-speech-ingress-service/internal/service/stt/mock"
+	pb "ai-speech-ingress-service/proto"
+)
+
+// memorySink captures published events in place of Kafka.
+type memorySink struct {
+	mu       sync.Mutex
+	partials []models.TranscriptPartial
+	finals   []models.TranscriptFinal
+	closed   []models.SegmentClosed
+}
+
+func (s *memorySink) PublishPartial(_ context.Context, _, _ string, event any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partials = append(s.partials, event.(models.TranscriptPartial))
+	return nil
+}
+
+func (s *memorySink) PublishFinal(_ context.Context, _, _ string, event any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finals = append(s.finals, event.(models.TranscriptFinal))
+	return nil
+}
+
+func (s *memorySink) PublishSegmentEvent(_ context.Context, _, _ string, event any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = append(s.closed, event.(models.SegmentClosed))
+	return nil
+}
+
+// loopback is a real gRPC server and client connected over an in-memory
+// listener, with the mock STT provider and a memorySink behind the server.
+type loopback struct {
+	server *Server
+	sink   *memorySink
+	client pb.AudioStreamServiceClient
+}
+
+// loopbackScript is played by every mock adapter the loopback server creates.
+var loopbackScript = []mock.SimulatedUtterance{
+	{Partials: []string{"I want", "I want to"}, Final: "I want to cancel", Confidence: 0.94},
+	{Partials: []string{"Yes"}, Final: "Yes please", Confidence: 0.97},
+}
+
+func newLoopback(t *testing.T) *loopback {
+	t.Helper()
+	cfg := config.Defaults()
+	cfg.STTProvider = "mock"
+	cfg.Kafka.PublishSegmentClosed = true
+
+	sink := &memorySink{}
+	// The message size limit main sets, so tests see the transport's limits
+	g := grpc.NewServer(grpc.MaxRecvMsgSize(cfg.Stream.MaxRecvMsgBytes))
+	s, err := RegisterWithConfig(g, sink, cfg)
+	if err != nil {
+		t.Fatalf("RegisterWithConfig: %v", err)
+	}
+	s.metrics = metrics.New(prometheus.NewRegistry())
+	s.newMock = func() *mock.Adapter {
+		a := mock.NewWithScript(loopbackScript)
+		a.SetSynchronous(true)
+		return a
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		if err := g.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("Serve: %v", err)
+		}
+	}()
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &loopback{server: s, sink: sink, client: pb.NewAudioStreamServiceClient(conn)}
+}
+
+func loopbackContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestLoopback_StreamAudio(t *testing.T) {
+	lb := newLoopback(t)
+
+	stream, err := lb.client.StreamAudio(loopbackContext(t))
+	if err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+	// Three frames play the first utterance, three more the second
+	for _, frame := range frames(1, 2, 3, 4, 5, 6) {
+		if err := stream.Send(frame); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	ack, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("CloseAndRecv: %v", err)
+	}
+	if ack.InteractionId != "int-1" || ack.SegmentDropped || ack.InteractionCapped {
+		t.Errorf("unexpected ack: %v", ack)
+	}
+
+	lb.sink.mu.Lock()
+	defer lb.sink.mu.Unlock()
+
+	var partials []string
+	for _, p := range lb.sink.partials {
+		partials = append(partials, p.Text)
+	}
+	if want := []string{"I want", "I want to", "Yes"}; !slices.Equal(partials, want) {
+		t.Errorf("partials = %q, want %q", partials, want)
+	}
+	if len(lb.sink.finals) != 2 {
+		t.Fatalf("published %d finals, want 2", len(lb.sink.finals))
+	}
+	first, second := lb.sink.finals[0], lb.sink.finals[1]
+	if first.Text != "I want to cancel" || second.Text != "Yes please" {
+		t.Errorf("finals = %q, %q", first.Text, second.Text)
+	}
+	if first.SegmentID == second.SegmentID {
+		t.Errorf("both finals in segment %s, want a new segment per utterance", first.SegmentID)
+	}
+	if first.InteractionID != "int-1" || first.TenantID != "tenant-1" {
+		t.Errorf("final published for %s/%s", first.TenantID, first.InteractionID)
+	}
+	if lb.sink.partials[2].SegmentID != second.SegmentID {
+		t.Errorf("partial in segment %s, want %s", lb.sink.partials[2].SegmentID, second.SegmentID)
+	}
+	if len(lb.sink.closed) < 2 || lb.sink.closed[0].SegmentID != first.SegmentID || lb.sink.closed[1].SegmentID != second.SegmentID {
+		t.Errorf("closed segments = %v, want one per final", lb.sink.closed)
+	}
+}
+
+func TestLoopback_StreamTranscribe(t *testing.T) {
+	lb := newLoopback(t)
+
+	stream, err := lb.client.StreamTranscribe(loopbackContext(t))
+	if err != nil {
+		t.Fatalf("StreamTranscribe: %v", err)
+	}
+	for _, frame := range frames(1, 2, 3) {
+		if err := stream.Send(frame); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+
+	var got []string
+	for {
+		tr, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		got = append(got, tr.Text)
+		if tr.IsFinal && tr.Confidence != 0.94 {
+			t.Errorf("final confidence = %v, want 0.94", tr.Confidence)
+		}
+	}
+	if want := []string{"I want", "I want to", "I want to cancel"}; !slices.Equal(got, want) {
+		t.Errorf("transcripts = %q, want %q", got, want)
+	}
+}
+
+func TestLoopback_RejectsMissingIdentity(t *testing.T) {
+	lb := newLoopback(t)
+
+	stream, err := lb.client.StreamAudio(loopbackContext(t))
+	if err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+	frame := frames(1)[0]
+	frame.TenantId = ""
+	if err := stream.Send(frame); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("err = %v, want InvalidArgument", err)
+	}
+	if n := len(lb.sink.finals); n != 0 {
+		t.Errorf("published %d finals for a rejected stream", n)
+	}
+}
@@ -0,0 +1,77 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "ai-speech-ingress-service/proto"
+)
+
+// RequiredMetadataInterceptor rejects AudioStreamService streams that lack
+// any of the given metadata keys with codes.InvalidArgument, before the
+// handler runs. Other services (e.g. reflection) are not checked.
+func RequiredMetadataInterceptor(keys []string) grpc.StreamServerInterceptor {
+	required := make([]string, 0, len(keys))
+	for _, k := range keys {
+		// gRPC metadata keys are lowercase on the wire
+		required = append(required, strings.ToLower(k))
+	}
+	prefix := "/" + pb.AudioStreamService_ServiceDesc.ServiceName + "/"
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, prefix) {
+			if missing := missingMetadata(ss.Context(), required); len(missing) > 0 {
+				return status.Errorf(codes.InvalidArgument, "missing required metadata: %s", strings.Join(missing, ", "))
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// missingMetadata returns the keys without a non-empty value in the
+// incoming metadata of ctx.
+func missingMetadata(ctx context.Context, keys []string) []string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var missing []string
+	for _, k := range keys {
+		if !hasValue(md.Get(k)) {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
+// validateFirstFrame checks that the first frame of a stream identifies the
+// interaction and tenant. Audio may ride along on the first frame, but never
+// replaces the identifiers.
+func validateFirstFrame(frame *pb.AudioFrame) error {
+	var missing []string
+	if strings.TrimSpace(frame.InteractionId) == "" {
+		missing = append(missing, "interactionId")
+	}
+	if strings.TrimSpace(frame.TenantId) == "" {
+		missing = append(missing, "tenantId")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if len(frame.Audio) > 0 {
+		return status.Errorf(codes.InvalidArgument,
+			"first frame has audio but no %s; the first frame must identify the stream", strings.Join(missing, " or "))
+	}
+	return status.Errorf(codes.InvalidArgument, "first frame is missing %s", strings.Join(missing, " and "))
+}
+
+func hasValue(values []string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return true
+		}
+	}
+	return false
+}
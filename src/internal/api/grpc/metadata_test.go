@@ -0,0 +1,58 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataStream is a ServerStream carrying only a context.
+type metadataStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *metadataStream) Context() context.Context { return s.ctx }
+
+func TestRequiredMetadataInterceptor(t *testing.T) {
+	intercept := RequiredMetadataInterceptor([]string{"Tenant-Id", "interaction-id"})
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/ai.speech.ingress.AudioStreamService/StreamAudio"}
+
+	tests := []struct {
+		name     string
+		info     *grpc.StreamServerInfo
+		md       metadata.MD
+		wantCode codes.Code
+	}{
+		{"all present", streamInfo, metadata.Pairs("tenant-id", "t1", "interaction-id", "i1"), codes.OK},
+		{"one missing", streamInfo, metadata.Pairs("tenant-id", "t1"), codes.InvalidArgument},
+		{"blank value", streamInfo, metadata.Pairs("tenant-id", " ", "interaction-id", "i1"), codes.InvalidArgument},
+		{"no metadata", streamInfo, nil, codes.InvalidArgument},
+		{"other service", &grpc.StreamServerInfo{FullMethod: "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"}, nil, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			called := false
+			err := intercept(nil, &metadataStream{ctx: ctx}, tt.info, func(any, grpc.ServerStream) error {
+				called = true
+				return nil
+			})
+
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err=%v)", got, tt.wantCode, err)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Errorf("handler called = %v", called)
+			}
+		})
+	}
+}
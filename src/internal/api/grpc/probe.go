@@ -0,0 +1,170 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/service/audio"
+	"ai-speech-ingress-service/internal/service/stt/google"
+)
+
+// Synthetic probe limits. The clip is capped so that each probe costs at most
+// a few seconds of recognition.
+const (
+	maxProbeClip    = 5 * time.Second
+	probeFrame      = 100 * time.Millisecond
+	probeFailures   = 2 // Consecutive failures before the probe reports not ready
+	mockProbeFrames = 10
+)
+
+var errProbeNoFinal = errors.New("no final before the probe timeout")
+
+// STTProbe periodically streams a short known clip through a new STT adapter
+// and checks that a final comes back.
+type STTProbe struct {
+	server   *Server
+	clip     []byte
+	interval time.Duration
+	timeout  time.Duration
+	report   func(ready bool)
+}
+
+// NewSTTProbe loads the probe clip from cfg. report is called after every
+// probe with whether STT is considered working; it only turns false after
+// consecutive failures, so a single blip doesn't fail readiness.
+func (s *Server) NewSTTProbe(cfg config.ProbeConfig, report func(ready bool)) (*STTProbe, error) {
+	base, _ := s.streamSettings()
+	sttCfg := languageSettings(base, "")
+	clip, err := loadProbeClip(cfg.AudioFile, sttCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &STTProbe{server: s, clip: clip, interval: cfg.Interval, timeout: cfg.Timeout, report: report}, nil
+}
+
+// loadProbeClip reads the clip, or makes one of silence when path is empty,
+// which only the mock provider transcribes.
+func loadProbeClip(path string, sttCfg config.STTConfig) ([]byte, error) {
+	if path == "" {
+		return make([]byte, mockProbeFrames*probeFrameBytes(sttCfg)), nil
+	}
+
+	clip, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading probe clip: %w", err)
+	}
+	if len(clip) == 0 {
+		return nil, fmt.Errorf("probe clip %s is empty", path)
+	}
+	durationMs := audio.Config{SampleRateHz: sttCfg.SampleRateHz, Encoding: sttCfg.Encoding, Channels: sttCfg.Channels}.AudioDurationMs(int64(len(clip)))
+	if durationMs > maxProbeClip.Milliseconds() {
+		return nil, fmt.Errorf("probe clip %s is %dms long, limit is %s", path, durationMs, maxProbeClip)
+	}
+	return clip, nil
+}
+
+// probeFrameBytes returns the size of a probeFrame of audio in the stream
+// format, or a fixed size for encodings without a fixed sample width.
+func probeFrameBytes(sttCfg config.STTConfig) int {
+	n := audio.Config{SampleRateHz: sttCfg.SampleRateHz, Encoding: sttCfg.Encoding, Channels: sttCfg.Channels}.AudioBytes(probeFrame)
+	if n <= 0 {
+		return 3200
+	}
+	return int(n)
+}
+
+// Run probes immediately and then every interval until ctx is done.
+func (p *STTProbe) Run(ctx context.Context) {
+	failures := 0
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		err := p.probe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt := p.server.metrics
+		if err != nil {
+			failures++
+			mt.STTProbeSuccess.Set(0)
+			log.Printf("STT probe failed: provider=%s failures=%d err=%v", p.server.sttProvider, failures, err)
+		} else {
+			failures = 0
+			mt.STTProbeSuccess.Set(1)
+			mt.STTProbeLatency.Observe(time.Since(start).Seconds())
+		}
+		p.report(failures < probeFailures)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe streams the clip through a new adapter and waits for a final.
+func (p *STTProbe) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	base, _ := p.server.streamSettings()
+	sttCfg := languageSettings(base, "")
+	adapter, err := p.server.createSTTAdapter(ctx, sttCfg)
+	if err != nil {
+		return err
+	}
+	defer adapter.Close()
+
+	cb := &probeCallback{final: make(chan struct{}, 1), err: make(chan error, 1)}
+	if err := adapter.Start(ctx, cb); err != nil {
+		return err
+	}
+	if ga, ok := adapter.(*google.Adapter); ok {
+		go ga.Listen()
+	}
+
+	frame := probeFrameBytes(sttCfg)
+	for off := 0; off < len(p.clip); off += frame {
+		if err := adapter.SendAudio(ctx, p.clip[off:min(off+frame, len(p.clip))]); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-cb.final:
+		return nil
+	case err := <-cb.err:
+		return err
+	case <-ctx.Done():
+		return errProbeNoFinal
+	}
+}
+
+// probeCallback signals the first final or error of a probe.
+type probeCallback struct {
+	final chan struct{}
+	err   chan error
+}
+
+func (c *probeCallback) OnPartial(string) {}
+func (c *probeCallback) OnFinal(string, float64) {
+	select {
+	case c.final <- struct{}{}:
+	default:
+	}
+}
+func (c *probeCallback) OnEndOfUtterance() {}
+func (c *probeCallback) OnError(err error) {
+	select {
+	case c.err <- err:
+	default:
+	}
+}
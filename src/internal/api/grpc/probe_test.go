@@ -0,0 +1,90 @@
+package grpcapi
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/service/stt/mock"
+)
+
+// runProbe runs p until it has reported n times.
+func runProbe(t *testing.T, p *STTProbe, reports <-chan bool, n int) []bool {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	got := make([]bool, 0, n)
+	for len(got) < n {
+		select {
+		case ready := <-reports:
+			got = append(got, ready)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("probe reported %d times, want %d", len(got), n)
+		}
+	}
+	return got
+}
+
+func TestSTTProbe_MockSucceeds(t *testing.T) {
+	s, m := newTestServer(t)
+	reports := make(chan bool, 10)
+	probe, err := s.NewSTTProbe(config.ProbeConfig{Interval: time.Minute, Timeout: 5 * time.Second},
+		func(ready bool) { reports <- ready })
+	if err != nil {
+		t.Fatalf("NewSTTProbe: %v", err)
+	}
+
+	if got := runProbe(t, probe, reports, 1); !got[0] {
+		t.Error("expected the mock provider to pass the probe")
+	}
+	if got := testutil.ToFloat64(m.STTProbeSuccess); got != 1 {
+		t.Errorf("stt_synthetic_probe_success = %v, want 1", got)
+	}
+}
+
+func TestSTTProbe_ReportsNotReadyAfterConsecutiveFailures(t *testing.T) {
+	s, m := newTestServer(t)
+	// An exhausted script never produces a final
+	s.newMock = func() *mock.Adapter { return mock.NewWithScript(nil) }
+
+	reports := make(chan bool, 10)
+	probe, err := s.NewSTTProbe(config.ProbeConfig{Interval: time.Millisecond, Timeout: 20 * time.Millisecond},
+		func(ready bool) { reports <- ready })
+	if err != nil {
+		t.Fatalf("NewSTTProbe: %v", err)
+	}
+
+	got := runProbe(t, probe, reports, 2)
+
+	if !got[0] || got[1] {
+		t.Errorf("reports = %v, want ready after one failure and not ready after two", got)
+	}
+	if got := testutil.ToFloat64(m.STTProbeSuccess); got != 0 {
+		t.Errorf("stt_synthetic_probe_success = %v, want 0", got)
+	}
+}
+
+func TestLoadProbeClip_RejectsLongClip(t *testing.T) {
+	path := t.TempDir() + "/clip.raw"
+	sttCfg := config.STTConfig{SampleRateHz: 8000, Encoding: "LINEAR16", Channels: 1}
+	// 6s of 8kHz LINEAR16
+	if err := os.WriteFile(path, make([]byte, 6*16000), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadProbeClip(path, sttCfg); err == nil {
+		t.Error("expected a clip over the limit to be rejected")
+	}
+}
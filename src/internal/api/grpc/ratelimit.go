@@ -0,0 +1,95 @@
+package grpcapi
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"ai-speech-ingress-service/internal/config"
+)
+
+// limiterSweepInterval is how often idle tenant limiters are evicted.
+const limiterSweepInterval = time.Minute
+
+// tenantRateLimiter is a token-bucket rate limiter for stream creation,
+// keyed by tenantId. Tenant IDs come from clients, so buckets that have been
+// idle long enough to refill are evicted; a fresh bucket is full too, so
+// eviction doesn't change what is allowed.
+type tenantRateLimiter struct {
+	mu        sync.Mutex
+	def       config.TenantRate
+	overrides map[string]config.TenantRate
+	limiters  map[string]*tenantLimiter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// tenantLimiter is one tenant's bucket.
+type tenantLimiter struct {
+	lim      *rate.Limiter
+	refill   time.Duration // Time for an empty bucket to refill
+	lastUsed time.Time
+}
+
+// newTenantRateLimiter creates a limiter from config. Returns nil if neither a
+// default rate nor any override is configured.
+func newTenantRateLimiter(cfg config.RateLimitConfig) *tenantRateLimiter {
+	if cfg.Default.Rate <= 0 && len(cfg.Overrides) == 0 {
+		return nil
+	}
+	return &tenantRateLimiter{
+		def:       cfg.Default,
+		overrides: cfg.Overrides,
+		limiters:  make(map[string]*tenantLimiter),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a new stream for the tenant may be created now.
+// Tenants with no effective rate (<= 0) are not limited.
+func (l *tenantRateLimiter) Allow(tenantId string) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	now := l.now()
+	l.sweepLocked(now)
+	tl, ok := l.limiters[tenantId]
+	if !ok {
+		r := l.def
+		if o, found := l.overrides[tenantId]; found {
+			r = o
+		}
+		if r.Rate <= 0 {
+			l.mu.Unlock()
+			return true
+		}
+		burst := max(r.Burst, 1)
+		tl = &tenantLimiter{
+			lim:    rate.NewLimiter(rate.Limit(r.Rate), burst),
+			refill: time.Duration(float64(burst) / r.Rate * float64(time.Second)),
+		}
+		l.limiters[tenantId] = tl
+	}
+	tl.lastUsed = now
+	l.mu.Unlock()
+
+	return tl.lim.Allow()
+}
+
+// sweepLocked evicts the buckets of tenants idle for longer than their refill
+// time, at most once per limiterSweepInterval. Callers must hold l.mu.
+func (l *tenantRateLimiter) sweepLocked(now time.Time) {
+	if now.Sub(l.lastSweep) < limiterSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for tenantId, tl := range l.limiters {
+		if now.Sub(tl.lastUsed) > tl.refill {
+			delete(l.limiters, tenantId)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package grpcapi
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"ai-speech-ingress-service/internal/config"
+)
+
+func TestTenantRateLimiter_DisabledWhenUnconfigured(t *testing.T) {
+	l := newTenantRateLimiter(config.RateLimitConfig{})
+	if l != nil {
+		t.Fatal("expected nil limiter when no rate configured")
+	}
+	for i := 0; i < 100; i++ {
+		if !l.Allow("tenant-a") {
+			t.Fatal("nil limiter should always allow")
+		}
+	}
+}
+
+func TestTenantRateLimiter_EnforcesBurstPerTenant(t *testing.T) {
+	l := newTenantRateLimiter(config.RateLimitConfig{
+		Default: config.TenantRate{Rate: 0.001, Burst: 2},
+	})
+
+	if !l.Allow("tenant-a") || !l.Allow("tenant-a") {
+		t.Fatal("expected burst of 2 to be allowed")
+	}
+	if l.Allow("tenant-a") {
+		t.Error("expected third stream to be rate limited")
+	}
+
+	// Other tenants have their own bucket
+	if !l.Allow("tenant-b") {
+		t.Error("tenant-b should not be affected by tenant-a")
+	}
+}
+
+func TestTenantRateLimiter_Overrides(t *testing.T) {
+	l := newTenantRateLimiter(config.RateLimitConfig{
+		Default: config.TenantRate{Rate: 0.001, Burst: 1},
+		Overrides: map[string]config.TenantRate{
+			"big":       {Rate: 0.001, Burst: 3},
+			"unlimited": {Rate: 0},
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow("big") {
+			t.Fatalf("big: stream %d should be allowed", i+1)
+		}
+	}
+	if l.Allow("big") {
+		t.Error("big: expected limit after burst of 3")
+	}
+
+	for i := 0; i < 10; i++ {
+		if !l.Allow("unlimited") {
+			t.Fatal("unlimited: rate 0 override should disable limiting")
+		}
+	}
+
+	if !l.Allow("default") || l.Allow("default") {
+		t.Error("default: expected burst of 1")
+	}
+}
+
+func TestTenantRateLimiter_EvictsIdleTenants(t *testing.T) {
+	l := newTenantRateLimiter(config.RateLimitConfig{
+		Default: config.TenantRate{Rate: 1, Burst: 2}, // Refills in 2s
+		Overrides: map[string]config.TenantRate{
+			"unlimited": {Rate: 0},
+		},
+	})
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 100; i++ {
+		l.Allow(fmt.Sprintf("random-%d", i))
+	}
+	l.Allow("unlimited")
+	if len(l.limiters) != 100 {
+		t.Fatalf("tracking %d tenants, want 100 (unlimited tenants untracked)", len(l.limiters))
+	}
+
+	// Only the tenant active within its refill time is kept
+	now = now.Add(limiterSweepInterval - time.Second)
+	l.Allow("active")
+	now = now.Add(time.Second)
+	l.Allow("active")
+	if len(l.limiters) != 1 || l.limiters["active"] == nil {
+		t.Errorf("tracking %d tenants after the sweep, want only the active one", len(l.limiters))
+	}
+}
@@ -0,0 +1,19 @@
+package grpcapi
+
+import (
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// RegisterReflection registers the gRPC reflection service if enabled.
+// Reflection lets any client list and describe every service without the
+// proto files, so it is meant for development and off by default.
+func RegisterReflection(g *grpc.Server, enabled bool) {
+	if !enabled {
+		return
+	}
+	log.Println("gRPC reflection enabled")
+	reflection.Register(g)
+}
@@ -0,0 +1,19 @@
+package grpcapi
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestRegisterReflection(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		g := grpc.NewServer()
+		RegisterReflection(g, enabled)
+
+		_, registered := g.GetServiceInfo()["grpc.reflection.v1.ServerReflection"]
+		if registered != enabled {
+			t.Errorf("enabled=%v: reflection registered = %v", enabled, registered)
+		}
+	}
+}
@@ -3,18 +3,31 @@ package grpcapi
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
-	"ai-speech-ingress-service/internal/events"
+	"ai-speech-ingress-service/internal/audit"
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/models"
+	"ai-speech-ingress-service/internal/observability/metrics"
 	"ai-speech-ingress-service/internal/schema"
 	"ai-speech-ingress-service/internal/service/audio"
+	"ai-speech-ingress-service/internal/service/recording"
 	"ai-speech-ingress-service/internal/service/segment"
 	"ai-speech-ingress-service/internal/service/stt"
 	"ai-speech-ingress-service/internal/service/stt/google"
 	"ai-speech-ingress-service/internal/service/stt/mock"
+	"ai-speech-ingress-service/internal/service/stt/whisper"
+	"ai-speech-ingress-service/internal/service/transform"
 	pb "ai-speech-ingress-service/proto"
 )
 
@@ -22,73 +35,314 @@ import (
 type Server struct {
 	pb.UnimplementedAudioStreamServiceServer
 	segments    *segment.Generator
-	publisher   *events.Publisher
+	publisher   audio.Publisher
 	validator   *schema.Validator
 	sttProvider string
+	whisper     config.WhisperConfig
+
+	// Per-stream settings, replaced by Reload
+	mu         sync.RWMutex
+	sttConfig  config.STTConfig
+	handlerCfg audio.Config
+	limits     tenantLimits
+
+	recording    config.RecordingConfig
+	maxDuration  time.Duration
+	maxFileBytes int
+	onDisconnect string
+	streams      *audio.Registry
+	debugEnabled bool
+	rateLimiter  *tenantRateLimiter
+	auditLogger  audit.Logger
+	metrics      *metrics.Metrics
+	transcribe   func(ctx context.Context, cfg google.Config, audio []byte) ([]google.Result, error)
+	newMock      func() *mock.Adapter
 }
 
-// Register creates a new Server and registers it with the gRPC server.
-func Register(g *grpc.Server, publisher *events.Publisher, sttProvider string) {
+// RegisterWithConfig creates a new Server from the service configuration and
+// registers it with the gRPC server.
+func RegisterWithConfig(g *grpc.Server, publisher audio.Publisher, cfg *config.Config) (*Server, error) {
+	hc, err := handlerConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	switch cfg.Stream.OnDisconnect {
+	case "", disconnectDrop, disconnectFinalize:
+	default:
+		return nil, fmt.Errorf("unknown disconnect policy %q", cfg.Stream.OnDisconnect)
+	}
+
 	s := &Server{
-		segments:    segment.New(),
-		publisher:   publisher,
-		validator:   schema.New(),
-		sttProvider: sttProvider,
+		segments:     segment.New(),
+		publisher:    publisher,
+		validator:    schema.New(),
+		sttProvider:  cfg.STTProvider,
+		sttConfig:    cfg.STT,
+		whisper:      cfg.Whisper,
+		handlerCfg:   hc,
+		limits:       cfg.Segment.LimitsByTenant,
+		recording:    cfg.Recording,
+		maxDuration:  cfg.Stream.MaxInteractionDuration,
+		maxFileBytes: fileByteLimit(cfg.Stream.MaxRecvMsgBytes),
+		onDisconnect: cfg.Stream.OnDisconnect,
+		streams:      audio.NewRegistry(),
+		debugEnabled: cfg.HTTP.DebugEndpointsEnabled,
+		rateLimiter:  newTenantRateLimiter(cfg.RateLimit),
+		metrics:      metrics.Default,
+		transcribe:   google.Transcribe,
+		newMock:      mock.New,
+	}
+	log.Printf("Using STT provider: %s (encoding=%s sampleRate=%d language=%s)",
+		cfg.STTProvider, cfg.STT.Encoding, cfg.STT.SampleRateHz, cfg.STT.LanguageCode)
+	if cfg.Recording.Dir != "" {
+		log.Printf("Recording stream audio to %s (keepDropped=%v)", cfg.Recording.Dir, cfg.Recording.KeepDropped)
 	}
-	log.Printf("Using STT provider: %s", sttProvider)
 	pb.RegisterAudioStreamServiceServer(g, s)
+	return s, nil
+}
+
+// SetAuditLogger sets where streams record dropped segments and STT errors.
+// Nil (the default) disables auditing.
+func (s *Server) SetAuditLogger(l audit.Logger) {
+	s.auditLogger = l
+}
+
+// streamSettings returns the STT and handler config for a new stream.
+func (s *Server) streamSettings() (config.STTConfig, audio.Config) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sttConfig, s.handlerCfg
+}
+
+// tenantSettings applies the tenant's limit overrides to hc and returns it
+// with the stream's interaction duration cap.
+func (s *Server) tenantSettings(tenantId string, hc audio.Config) (audio.Config, time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.limits.apply(tenantId, hc, s.maxDuration)
+}
+
+// Reload applies the STT tuning and handler settings of cfg to streams started
+// from now on; streams in flight keep the settings they started with. The STT
+// provider and its connection settings are not reloadable.
+func (s *Server) Reload(cfg *config.Config) error {
+	hc, err := handlerConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.STTProvider != s.sttProvider {
+		log.Printf("Reload: STT provider change %s -> %s requires a restart, ignoring", s.sttProvider, cfg.STTProvider)
+	}
+
+	s.mu.Lock()
+	oldSTT, oldHC := s.sttConfig, s.handlerCfg
+	s.sttConfig, s.handlerCfg, s.limits = cfg.STT, hc, cfg.Segment.LimitsByTenant
+	s.mu.Unlock()
+
+	log.Printf("Reloaded STT config: old=%+v new=%+v", oldSTT, cfg.STT)
+	log.Printf("Reloaded partials: old=(debounce=%s minChars=%d minDelta=%d) new=(debounce=%s minChars=%d minDelta=%d) dropEmptyFinals=%v->%v",
+		oldHC.PartialDebounce, oldHC.PartialMinChars, oldHC.PartialMinDelta,
+		hc.PartialDebounce, hc.PartialMinChars, hc.PartialMinDelta,
+		oldHC.DropEmptyFinals, hc.DropEmptyFinals)
+	return nil
 }
 
 // StreamAudio handles bidirectional audio streaming for speech-to-text transcription.
 // It receives audio frames from the client, forwards them to the STT provider,
 // and publishes transcript events (partial and final) to the event bus.
 func (s *Server) StreamAudio(stream pb.AudioStreamService_StreamAudioServer) error {
+	ack, err := s.runStream(stream, nil)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(ack)
+}
+
+// StreamTranscribe is StreamAudio with transcripts streamed back to the
+// client as they are published.
+func (s *Server) StreamTranscribe(stream pb.AudioStreamService_StreamTranscribeServer) error {
+	var mu sync.Mutex
+	sendFailed := false
+	onTranscript := func(event any) {
+		mu.Lock()
+		defer mu.Unlock()
+		if sendFailed {
+			return
+		}
+		if err := stream.Send(toTranscript(event)); err != nil {
+			// The client went away; the receive loop will notice and end the stream
+			sendFailed = true
+			log.Printf("Failed to send transcript to client: %v", err)
+		}
+	}
+
+	_, err := s.runStream(stream, onTranscript)
+	return err
+}
+
+// audioStream is the receiving side shared by StreamAudio and StreamTranscribe.
+type audioStream interface {
+	Context() context.Context
+	Recv() (*pb.AudioFrame, error)
+	SetTrailer(metadata.MD)
+}
+
+// runStream drives a single audio stream to completion and returns the ack
+// describing how it ended. onTranscript, if set, receives every published
+// transcript event.
+func (s *Server) runStream(stream audioStream, onTranscript audio.TranscriptCallback) (*pb.StreamAck, error) {
 	ctx := stream.Context()
 
 	// Read first frame to extract metadata (interactionId, tenantId)
 	frame, err := stream.Recv()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := validateFirstFrame(frame); err != nil {
+		log.Printf("Rejecting stream: %v", err)
+		return nil, err
 	}
 
 	interactionId := frame.InteractionId
 	tenantId := frame.TenantId
+
+	if !s.rateLimiter.Allow(tenantId) {
+		s.metrics.StreamsRateLimited.WithLabelValues(s.metrics.TenantLabel(tenantId)).Inc()
+		log.Printf("Stream rate limited: interactionId=%s tenantId=%s", interactionId, tenantId)
+		return nil, status.Errorf(codes.ResourceExhausted, "stream rate limit exceeded for tenant %s", tenantId)
+	}
+
+	// Settings are fixed for the stream's lifetime; a Reload only affects new streams
+	baseCfg, hc := s.streamSettings()
+	hc, maxDuration := s.tenantSettings(tenantId, hc)
+	baseCfg = languageSettings(baseCfg, frame.LanguageCode)
+	sttCfg, err := s.negotiateFormat(baseCfg, interactionId, declaredFormat{
+		sampleRateHz: frame.SampleRateHz,
+		encoding:     frame.Encoding,
+		channels:     frame.Channels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	segmentId := s.segments.Next(interactionId)
 
-	log.Printf("Starting stream: interactionId=%s tenantId=%s segmentId=%s", interactionId, tenantId, segmentId)
+	log.Printf("Starting stream: interactionId=%s tenantId=%s segmentId=%s language=%s", interactionId, tenantId, segmentId, sttCfg.LanguageCode)
+	if cn := clientCommonName(ctx); cn != "" {
+		log.Printf("Stream client certificate: interactionId=%s tenantId=%s clientCN=%s", interactionId, tenantId, cn)
+	}
 
 	// Create and initialize STT adapter
-	adapter, err := s.createSTTAdapter(ctx)
+	adapter, err := s.createSTTAdapter(ctx, sttCfg)
 	if err != nil {
 		log.Printf("Failed to create STT adapter: %v", err)
-		return err
+		return nil, err
 	}
 
 	// Create audio handler to coordinate STT and event publishing
 	// Pass segment generator so handler can create new segments on utterance boundaries
-	handler := audio.NewHandler(adapter, s.publisher, s.segments, interactionId, tenantId, segmentId)
+	hc.SampleRateHz = sttCfg.SampleRateHz
+	hc.Encoding = sttCfg.Encoding
+	hc.Channels = sttCfg.Channels
+	hc.Audit = s.auditLogger
+	handler := audio.NewHandlerWithConfig(adapter, s.publisher, s.segments, interactionId, tenantId, segmentId, hc)
+	handler.SetMetrics(s.metrics)
+	if onTranscript != nil {
+		handler.SetTranscriptCallback(onTranscript)
+	}
 
 	// Start the STT streaming session
 	if err := handler.Start(ctx); err != nil {
 		log.Printf("Failed to start STT session: %v", err)
-		return err
+		adapter.Close()
+		return nil, err
 	}
+	// Deferred before Close so it runs after it, counting the last segment
+	defer logSummary(handler)
 	defer handler.Close()
 
+	s.streams.Register(handler)
+	defer s.streams.Unregister(handler)
+
 	// Start background goroutine to receive STT responses
 	if ga, ok := adapter.(*google.Adapter); ok {
 		go ga.Listen()
 	}
 
-	// Send first frame's audio if present
-	if len(frame.Audio) > 0 {
+	// Optionally record raw audio per segment for debugging
+	var recorder *recording.Recorder
+	if s.recording.Dir != "" {
+		recorder = recording.New(s.recording.Dir, interactionId, sttCfg.SampleRateHz, sttCfg.Encoding, s.recording.KeepDropped)
+		handler.SetSegmentDropCallback(func(segmentId string, _ audio.DropReason) {
+			recorder.DiscardSegment(segmentId)
+		})
+		defer func() {
+			if err := recorder.Close(); err != nil {
+				log.Printf("Failed to close recording: interactionId=%s err=%v", interactionId, err)
+			}
+		}()
+	}
+
+	// Detect lost or reordered frames from the client's sequence numbers
+	var seqs seqTracker
+	checkSeq := func(frame *pb.AudioFrame) {
+		if expected, ok := seqs.observe(frame.Seq); !ok {
+			s.metrics.AudioFrameGaps.Inc()
+			log.Printf("Audio frame gap: interactionId=%s expectedSeq=%d receivedSeq=%d",
+				interactionId, expected, frame.Seq)
+		}
+	}
+	checkSeq(frame)
+
+	// Later frames may repeat the first frame's identifiers, e.g. a client
+	// resending its opening frame, but can't move the stream elsewhere
+	identityWarned := false
+	checkIdentity := func(frame *pb.AudioFrame) {
+		changed := frame.InteractionId != "" && frame.InteractionId != interactionId ||
+			frame.TenantId != "" && frame.TenantId != tenantId
+		if changed && !identityWarned {
+			identityWarned = true
+			log.Printf("Ignoring changed identifiers on a later frame: interactionId=%s tenantId=%s frameInteractionId=%s frameTenantId=%s",
+				interactionId, tenantId, frame.InteractionId, frame.TenantId)
+		}
+	}
+
+	sendAudio := func(frame *pb.AudioFrame) error {
+		if recorder != nil {
+			if err := recorder.Write(handler.GetSegmentId(), frame.Audio); err != nil {
+				log.Printf("Failed to record audio: interactionId=%s err=%v", interactionId, err)
+			}
+		}
 		if err := handler.SendAudio(ctx, frame.Audio, frame.AudioOffsetMs); err != nil {
 			log.Printf("Failed to send audio: %v", err)
+			if errors.Is(err, audio.ErrSendTimeout) {
+				return status.Error(codes.Unavailable, err.Error())
+			}
 			return err
 		}
+		return nil
+	}
+
+	// Client-initiated cancellation drops the current segment and continues
+	// the stream in a new one
+	if frame.CancelSegment {
+		handler.CancelSegment(audio.DropClientCancel)
+	}
+	if frame.FinalizeSegment {
+		handler.FinalizeCurrentSegment()
 	}
 
-	// Stream remaining audio frames until EOF or EndOfUtterance
+	// Send first frame's audio if present
+	if len(frame.Audio) > 0 {
+		if err := sendAudio(frame); err != nil {
+			return nil, err
+		}
+	}
+
+	// Stream remaining audio frames until EOF, EndOfUtterance or the
+	// interaction duration cap. Hitting the cap ends the stream like EOF, so
+	// the current segment is finalized by the normal close path.
+	capped := false
 	for {
 		frame, err := stream.Recv()
 		if err == io.EOF {
@@ -96,36 +350,273 @@ func (s *Server) StreamAudio(stream pb.AudioStreamService_StreamAudioServer) err
 		}
 		if err != nil {
 			log.Printf("Stream recv error: %v", err)
-			return err
+			if !s.finalizeOnDisconnect(handler) {
+				handler.DropSegment(audio.DropClientDisconnect)
+			}
+			return nil, err
+		}
+		checkSeq(frame)
+		checkIdentity(frame)
+
+		if frame.CancelSegment {
+			handler.CancelSegment(audio.DropClientCancel)
+		}
+		if frame.FinalizeSegment {
+			handler.FinalizeCurrentSegment()
 		}
 
 		if len(frame.Audio) > 0 {
-			if err := handler.SendAudio(ctx, frame.Audio, frame.AudioOffsetMs); err != nil {
-				log.Printf("Failed to send audio: %v", err)
-				return err
+			if err := sendAudio(frame); err != nil {
+				return nil, err
 			}
 		}
 
 		if frame.EndOfUtterance {
 			break
 		}
+
+		if maxDuration > 0 && handler.GetStreamDuration() > maxDuration {
+			capped = true
+			s.metrics.InteractionsCapped.Inc()
+			log.Printf("Interaction duration cap reached: interactionId=%s limit=%s, closing stream",
+				interactionId, maxDuration)
+			break
+		}
+	}
+
+	ack, trailer := streamAck(handler, capped)
+	log.Printf("Stream completed: interactionId=%s segmentId=%s state=%s utterances=%d capped=%v",
+		interactionId, handler.GetSegmentId(), ack.SegmentState, handler.GetUtteranceCount(), capped)
+
+	stream.SetTrailer(trailer)
+	return ack, nil
+}
+
+// toTranscript converts a published transcript event to its proto form.
+func toTranscript(event any) *pb.Transcript {
+	switch ev := event.(type) {
+	case models.TranscriptPartial:
+		return &pb.Transcript{
+			EventType:     ev.EventType,
+			InteractionId: ev.InteractionID,
+			TenantId:      ev.TenantID,
+			SegmentId:     ev.SegmentID,
+			Text:          ev.Text,
+			Timestamp:     ev.Timestamp,
+		}
+	case models.TranscriptFinal:
+		alts := make([]*pb.Alternative, len(ev.Alternatives))
+		for i, alt := range ev.Alternatives {
+			alts[i] = &pb.Alternative{Text: alt.Text, Confidence: alt.Confidence}
+		}
+		return &pb.Transcript{
+			EventType:     ev.EventType,
+			InteractionId: ev.InteractionID,
+			TenantId:      ev.TenantID,
+			SegmentId:     ev.SegmentID,
+			Text:          ev.Text,
+			IsFinal:       true,
+			Confidence:    ev.Confidence,
+			AudioOffsetMs: ev.AudioOffsetMs,
+			Alternatives:  alts,
+			Timestamp:     ev.Timestamp,
+		}
+	default:
+		return &pb.Transcript{}
+	}
+}
+
+// Policies for the open segment when a client disconnects mid-stream.
+const (
+	disconnectDrop     = "drop"     // Drop the segment (reason "client_disconnect")
+	disconnectFinalize = "finalize" // Publish the latest partial as the final
+)
+
+// finalizeOnDisconnect applies the finalize policy, publishing the segment's
+// latest partial as its final. Returns false if the policy is drop or there
+// was no partial to finalize from.
+func (s *Server) finalizeOnDisconnect(handler *audio.Handler) bool {
+	if s.onDisconnect != disconnectFinalize {
+		return false
+	}
+	if !handler.FinalizeFromPartial(0) {
+		return false
+	}
+	log.Printf("Finalized segment from last partial on disconnect: interactionId=%s segmentId=%s",
+		handler.GetInteractionId(), handler.GetSegmentId())
+	return true
+}
+
+// logSummary logs one record totalling the interaction, for billing and QA.
+func logSummary(handler *audio.Handler) {
+	sum := handler.Summary()
+	log.Printf("Interaction summary: interactionId=%s tenantId=%s segments=%d completed=%d dropped=%d audioBytes=%d audioMs=%d partials=%d utterances=%d duration=%s",
+		handler.GetInteractionId(), handler.GetTenantId(), sum.SegmentsCreated, sum.SegmentsCompleted, sum.SegmentsDropped,
+		sum.AudioBytes, sum.AudioDurationMs, sum.Partials, sum.Utterances, sum.Duration)
+}
+
+// streamAck builds the final ack and trailer, surfacing the last segment's
+// state and drop reason so clients can detect silently dropped segments.
+func streamAck(handler *audio.Handler, capped bool) (*pb.StreamAck, metadata.MD) {
+	ack := &pb.StreamAck{
+		InteractionId:     handler.GetInteractionId(),
+		InteractionCapped: capped,
+		SegmentState:      handler.GetSegmentState().String(),
+	}
+	trailer := metadata.Pairs("x-segment-state", ack.SegmentState)
+	if handler.IsSegmentDropped() {
+		ack.SegmentDropped = true
+		ack.DropReason = handler.GetDropReason().String()
+		trailer.Set("x-drop-reason", ack.DropReason)
 	}
+	return ack, trailer
+}
 
-	log.Printf("Stream completed: interactionId=%s segmentId=%s utterances=%d",
-		interactionId, handler.GetSegmentId(), handler.GetUtteranceCount())
+// seqTracker checks that client frame sequence numbers increase by one.
+type seqTracker struct {
+	last uint64
+}
 
-	return stream.SendAndClose(&pb.StreamAck{InteractionId: interactionId})
+// observe records seq and reports whether it was the expected next number.
+// Unset (0) sequence numbers are not checked. After a gap, tracking resumes
+// from the highest sequence number seen.
+func (t *seqTracker) observe(seq uint64) (expected uint64, ok bool) {
+	if seq == 0 {
+		return 0, true
+	}
+	expected = t.last + 1
+	if t.last == 0 {
+		// First numbered frame; clients may start anywhere
+		expected = seq
+	}
+	if seq > t.last {
+		t.last = seq
+	}
+	return expected, seq == expected
 }
 
-// createSTTAdapter creates an STT adapter instance based on configuration.
-func (s *Server) createSTTAdapter(ctx context.Context) (stt.Adapter, error) {
+// createSTTAdapter creates an STT adapter instance for the stream's
+// negotiated STT config.
+func (s *Server) createSTTAdapter(ctx context.Context, cfg config.STTConfig) (stt.Adapter, error) {
 	switch s.sttProvider {
 	case "google":
-		return google.New(ctx)
+		return google.NewWithConfig(ctx, googleConfig(cfg))
+	case "whisper":
+		wc := whisper.DefaultConfig()
+		wc.Endpoint = s.whisper.Endpoint
+		wc.APIKey = s.whisper.APIKey
+		wc.Model = s.whisper.Model
+		wc.LanguageCode = cfg.LanguageCode
+		wc.SampleRateHz = cfg.SampleRateHz
+		wc.Encoding = cfg.Encoding
+		wc.SilenceDuration = s.whisper.Silence
+		wc.MaxChunkDuration = s.whisper.MaxChunk
+		wc.RequestTimeout = s.whisper.RequestTTL
+		return whisper.New(wc), nil
 	case "mock":
-		return mock.New(), nil
+		return s.newMock(), nil
 	default:
 		log.Printf("Unknown STT provider '%s', using mock", s.sttProvider)
-		return mock.New(), nil
+		return s.newMock(), nil
+	}
+}
+
+// googleConfig maps the STT config to the Google adapter's recognition settings.
+func googleConfig(cfg config.STTConfig) google.Config {
+	return google.Config{
+		SampleRateHz: int32(cfg.SampleRateHz),
+		Encoding:     cfg.Encoding,
+		Channels:     int32(cfg.Channels),
+		LanguageCode: cfg.LanguageCode,
+		Model:        cfg.Model,
+		UseEnhanced:  cfg.UseEnhanced,
+
+		MaxAlternatives:    int32(cfg.MaxAlternatives),
+		InterimStability:   float32(cfg.InterimStability),
+		InteractionType:    cfg.InteractionType,
+		IndustryNaicsCode:  uint32(cfg.IndustryNaicsCode),
+		MicrophoneDistance: cfg.MicrophoneDistance,
+
+		AllowUnknownEncoding: cfg.AllowUnknownEncoding,
+		StartRetries:         cfg.StartRetries,
+		StartBackoff:         cfg.StartBackoff,
+	}
+}
+
+// handlerConfig builds the per-stream handler config from service config.
+func handlerConfig(cfg *config.Config) (audio.Config, error) {
+	hc := audio.DefaultConfig()
+	hc.DropEmptyFinals = cfg.Segment.DropEmptyFinals
+	hc.LowConfidenceThreshold = cfg.Segment.LowConfidenceThreshold
+	hc.ConfidenceFloor = cfg.Segment.ConfidenceFloor
+	hc.ConfidenceFloorRetries = cfg.Segment.ConfidenceFloorRetries
+	hc.FinalHold = cfg.Segment.FinalHold
+	if cfg.Segment.VADSilence > 0 {
+		hc.VAD = audio.EnergyVAD(cfg.Segment.VADThreshold, cfg.Segment.VADSilence)
+	}
+	hc.PartialDebounce = cfg.Partials.Debounce
+	hc.PartialMinChars = cfg.Partials.MinChars
+	hc.PartialMinDelta = cfg.Partials.MinDelta
+	hc.LatePartialGrace = cfg.Partials.LateGrace
+	hc.Encoding = cfg.STT.Encoding
+	hc.SampleRateHz = cfg.STT.SampleRateHz
+	hc.MaxFrameBytes = cfg.Stream.MaxFrameBytes
+	hc.MaxFrameDuration = cfg.Stream.MaxFrameDuration
+	hc.SilenceTrim = cfg.Stream.SilenceTrim
+	hc.SilenceThreshold = cfg.Stream.SilenceThreshold
+	hc.SendTimeout = cfg.STT.SendTimeout
+	hc.PublishPartials = cfg.Kafka.PublishPartials
+	hc.PublishSegmentClosed = cfg.Kafka.PublishSegmentClosed
+
+	switch policy := audio.FrameRejectionPolicy(cfg.Stream.FrameRejectionPolicy); policy {
+	case "":
+		// Keep the default
+	case audio.RejectDropFrame, audio.RejectDropSegment:
+		hc.FrameRejection = policy
+	default:
+		return hc, fmt.Errorf("unknown frame rejection policy %q", policy)
+	}
+
+	switch name := cfg.Segment.BoundaryPolicy; name {
+	case "":
+		// Keep the default
+	case audio.BoundarySingleUtterance:
+		hc.Boundary = audio.SingleUtteranceBoundary{}
+	case audio.BoundaryContinuous:
+		hc.Boundary = audio.ContinuousBoundary{}
+	case audio.BoundaryFixedInterval:
+		hc.Boundary = audio.FixedIntervalBoundary{Every: cfg.Segment.FixedInterval}
+	default:
+		return hc, fmt.Errorf("unknown segment boundary policy %q", name)
+	}
+
+	switch source := audio.TimestampSource(cfg.Kafka.EventTimestampSource); source {
+	case "":
+		// Keep the default
+	case audio.TimestampWallclock, audio.TimestampAudio:
+		hc.TimestampSource = source
+	default:
+		return hc, fmt.Errorf("unknown event timestamp source %q", source)
 	}
+
+	if cfg.STT.EnableITN {
+		hc.Normalizer = transform.NumberNormalizer{}
+		log.Println("Inverse text normalization enabled for finals")
+	}
+
+	if cfg.Redaction.Enabled {
+		patterns := cfg.Redaction.Patterns
+		if len(patterns) == 0 {
+			patterns = transform.DefaultRedactionPatterns
+		}
+		redactor, err := transform.NewRegexRedactor(patterns)
+		if err != nil {
+			return hc, err
+		}
+		hc.Transforms = append(hc.Transforms, redactor)
+		hc.TransformPartials = cfg.Redaction.Partials
+		log.Printf("PII redaction enabled: patterns=%d partials=%v", len(patterns), cfg.Redaction.Partials)
+	}
+
+	return hc, nil
 }
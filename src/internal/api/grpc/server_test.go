@@ -0,0 +1,476 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"ai-speech-ingress-service/internal/audit"
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/events"
+	"ai-speech-ingress-service/internal/models"
+	"ai-speech-ingress-service/internal/observability/metrics"
+	"ai-speech-ingress-service/internal/service/audio"
+	"ai-speech-ingress-service/internal/service/segment"
+	"ai-speech-ingress-service/internal/service/stt/mock"
+	pb "ai-speech-ingress-service/proto"
+)
+
+// fakeAudioStream feeds canned frames to StreamAudio and captures the ack.
+type fakeAudioStream struct {
+	grpc.ServerStream
+	frames  []*pb.AudioFrame
+	err     error // Returned after the frames instead of io.EOF
+	ack     *pb.StreamAck
+	trailer metadata.MD
+}
+
+func (f *fakeAudioStream) Context() context.Context { return context.Background() }
+
+func (f *fakeAudioStream) Recv() (*pb.AudioFrame, error) {
+	if len(f.frames) == 0 {
+		if f.err != nil {
+			return nil, f.err
+		}
+		return nil, io.EOF
+	}
+	frame := f.frames[0]
+	f.frames = f.frames[1:]
+	return frame, nil
+}
+
+func (f *fakeAudioStream) SendAndClose(ack *pb.StreamAck) error {
+	f.ack = ack
+	return nil
+}
+
+func (f *fakeAudioStream) SetTrailer(md metadata.MD) { f.trailer = md }
+
+// newTestServer creates a mock-provider server with isolated metrics.
+func newTestServer(t *testing.T) (*Server, *metrics.Metrics) {
+	t.Helper()
+	return newTestServerWithConfig(t, config.StreamConfig{})
+}
+
+func newTestServerWithConfig(t *testing.T, sc config.StreamConfig) (*Server, *metrics.Metrics) {
+	t.Helper()
+	s, err := RegisterWithConfig(grpc.NewServer(), events.New(&events.Config{}), &config.Config{
+		STTProvider: "mock",
+		STT:         config.STTConfig{SampleRateHz: 8000, Encoding: "LINEAR16", Channels: 1, LanguageCode: "en-US"},
+		Stream:      sc,
+	})
+	if err != nil {
+		t.Fatalf("RegisterWithConfig: %v", err)
+	}
+	m := metrics.New(prometheus.NewRegistry())
+	s.metrics = m
+	return s, m
+}
+
+// newSyncMock returns a mock adapter that delivers transcripts inline.
+func newSyncMock() *mock.Adapter {
+	a := mock.New()
+	a.SetSynchronous(true)
+	return a
+}
+
+func frames(seqs ...uint64) []*pb.AudioFrame {
+	out := make([]*pb.AudioFrame, len(seqs))
+	for i, seq := range seqs {
+		out[i] = &pb.AudioFrame{
+			InteractionId: "int-1",
+			TenantId:      "tenant-1",
+			Audio:         make([]byte, 320),
+			AudioOffsetMs: int64(i * 20),
+			Seq:           seq,
+		}
+	}
+	return out
+}
+
+func TestStreamAck_ReportsDroppedSegment(t *testing.T) {
+	gen := segment.New()
+	h := audio.NewHandler(mock.New(), nil, gen, "int-1", "tenant-1", gen.Next("int-1"))
+
+	ack, trailer := streamAck(h, false)
+	if ack.SegmentDropped || ack.SegmentState != "OPEN" || ack.InteractionId != "int-1" {
+		t.Errorf("unexpected ack for open segment: %v", ack)
+	}
+	if len(trailer.Get("x-drop-reason")) != 0 {
+		t.Errorf("unexpected drop reason trailer: %v", trailer)
+	}
+
+	h.DropSegment(audio.DropEmptyFinal)
+	ack, trailer = streamAck(h, true)
+	if !ack.SegmentDropped || ack.DropReason != "empty_final" || ack.SegmentState != "DROPPED" || !ack.InteractionCapped {
+		t.Errorf("unexpected ack for dropped segment: %v", ack)
+	}
+	if got := trailer.Get("x-drop-reason"); len(got) != 1 || got[0] != "empty_final" {
+		t.Errorf("x-drop-reason trailer = %v", got)
+	}
+	if got := trailer.Get("x-segment-state"); len(got) != 1 || got[0] != "DROPPED" {
+		t.Errorf("x-segment-state trailer = %v", got)
+	}
+}
+
+func TestStreamAudio_DetectsSequenceGaps(t *testing.T) {
+	tests := []struct {
+		name string
+		seqs []uint64
+		want float64
+	}{
+		{"in order", []uint64{1, 2, 3, 4}, 0},
+		{"unset", []uint64{0, 0, 0}, 0},
+		{"starts above one", []uint64{7, 8, 9}, 0},
+		{"lost frame", []uint64{1, 2, 4, 5}, 1},
+		{"reordered", []uint64{1, 3, 2, 4}, 2},
+		{"duplicate", []uint64{1, 2, 2, 3}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestServer(t)
+			stream := &fakeAudioStream{frames: frames(tt.seqs...)}
+
+			if err := s.StreamAudio(stream); err != nil {
+				t.Fatalf("StreamAudio: %v", err)
+			}
+			if stream.ack == nil || stream.ack.InteractionId != "int-1" {
+				t.Fatalf("unexpected ack: %v", stream.ack)
+			}
+			if got := testutil.ToFloat64(m.AudioFrameGaps); got != tt.want {
+				t.Errorf("audio_frame_gaps_total = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamAudio_RejectsMissingIdentifiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		frame   *pb.AudioFrame
+		wantMsg string
+	}{
+		{"empty", &pb.AudioFrame{}, "missing interactionId and tenantId"},
+		{"no tenant", &pb.AudioFrame{InteractionId: "int-1", TenantId: " "}, "missing tenantId"},
+		{"audio only", &pb.AudioFrame{Audio: make([]byte, 320)}, "has audio but no interactionId or tenantId"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestServer(t)
+			adapters := 0
+			s.newMock = func() *mock.Adapter { adapters++; return mock.New() }
+
+			err := s.StreamAudio(&fakeAudioStream{frames: []*pb.AudioFrame{tt.frame}})
+			if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("err = %v, want InvalidArgument mentioning %q", err, tt.wantMsg)
+			}
+			if adapters != 0 {
+				t.Errorf("created %d STT adapters for a rejected stream", adapters)
+			}
+		})
+	}
+}
+
+func TestStreamAudio_LaterFramesKeepIdentifiers(t *testing.T) {
+	s, _ := newTestServer(t)
+	in := frames(1, 2, 3) // Every frame repeats the identifiers
+	in[2].InteractionId, in[2].TenantId = "int-2", "tenant-2"
+	stream := &fakeAudioStream{frames: in}
+
+	if err := s.StreamAudio(stream); err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+	if stream.ack.InteractionId != "int-1" {
+		t.Errorf("ack interactionId = %s, want int-1 from the first frame", stream.ack.InteractionId)
+	}
+}
+
+// tickingAudioStream advances clock by step before returning each frame.
+type tickingAudioStream struct {
+	fakeAudioStream
+	clock *audio.ManualClock
+	step  time.Duration
+}
+
+func (f *tickingAudioStream) Recv() (*pb.AudioFrame, error) {
+	f.clock.Advance(f.step)
+	return f.fakeAudioStream.Recv()
+}
+
+func TestStreamAudio_MaxDurationCap(t *testing.T) {
+	s, m := newTestServerWithConfig(t, config.StreamConfig{MaxInteractionDuration: time.Minute})
+	clock := audio.NewManualClock(time.Unix(1700000000, 0))
+	s.handlerCfg.Clock = clock
+	stream := &tickingAudioStream{fakeAudioStream: fakeAudioStream{frames: frames(1, 2, 3, 4, 5)}, clock: clock, step: 25 * time.Second}
+
+	if err := s.StreamAudio(stream); err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+	if !stream.ack.InteractionCapped {
+		t.Errorf("unexpected ack: %v, want the interaction capped", stream.ack)
+	}
+	// Capped at the frame received 75s in, leaving the last one unread
+	if len(stream.frames) != 1 {
+		t.Errorf("%d frames left unread, want 1", len(stream.frames))
+	}
+	if got := testutil.ToFloat64(m.InteractionsCapped); got != 1 {
+		t.Errorf("interactions_capped_total = %v, want 1", got)
+	}
+}
+
+func TestStreamAudio_CancelSegmentContinuesStream(t *testing.T) {
+	s, m := newTestServer(t)
+	in := frames(1, 2, 3, 4)
+	in[2].CancelSegment = true
+	stream := &fakeAudioStream{frames: in}
+
+	if err := s.StreamAudio(stream); err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+
+	if got := testutil.ToFloat64(m.SegmentsDropped.WithLabelValues("client_cancel")); got != 1 {
+		t.Errorf("segments_dropped_total{client_cancel} = %v, want 1", got)
+	}
+	if len(stream.frames) != 0 {
+		t.Errorf("%d frames left unread after cancel", len(stream.frames))
+	}
+	// The stream continued in a fresh segment, which is still open
+	if stream.ack.SegmentDropped || stream.ack.SegmentState != "OPEN" {
+		t.Errorf("unexpected ack after cancel: %v", stream.ack)
+	}
+	if next := s.segments.Next("int-1"); next != "int-1-seg-3" {
+		t.Errorf("next segment = %s, want int-1-seg-3 (one segment started by the cancel)", next)
+	}
+}
+
+func TestStreamAudio_FinalizeSegmentContinuesStream(t *testing.T) {
+	s, m := newTestServer(t)
+	in := frames(1, 2, 3, 4)
+	in[2].FinalizeSegment = true
+	stream := &fakeAudioStream{frames: in}
+
+	if err := s.StreamAudio(stream); err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+
+	if got := testutil.CollectAndCount(m.SegmentsDropped); got != 0 {
+		t.Errorf("%d segments_dropped_total series, want none", got)
+	}
+	if stream.ack.SegmentDropped || stream.ack.SegmentState != "OPEN" {
+		t.Errorf("unexpected ack after finalize: %v", stream.ack)
+	}
+	if next := s.segments.Next("int-1"); next != "int-1-seg-3" {
+		t.Errorf("next segment = %s, want int-1-seg-3 (one segment started by the finalize)", next)
+	}
+}
+
+// captureAuditLogger records audit records in memory.
+type captureAuditLogger struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (l *captureAuditLogger) Log(_ context.Context, rec audit.Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, rec)
+	return nil
+}
+
+func (l *captureAuditLogger) Close() error { return nil }
+
+func TestStreamAudio_AuditsDroppedSegments(t *testing.T) {
+	s, _ := newTestServer(t)
+	logger := &captureAuditLogger{}
+	s.SetAuditLogger(logger)
+	in := frames(1, 2, 3)
+	in[1].CancelSegment = true
+
+	if err := s.StreamAudio(&fakeAudioStream{frames: in}); err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+	if len(logger.records) != 1 {
+		t.Fatalf("audited %d records, want 1", len(logger.records))
+	}
+	if rec := logger.records[0]; rec.Reason != "client_cancel" || rec.TenantID != "tenant-1" || rec.SegmentID != "int-1-seg-1" {
+		t.Errorf("unexpected audit record: %+v", rec)
+	}
+}
+
+func TestStreamAudio_InvalidFrameDiscardsRecording(t *testing.T) {
+	s, m := newTestServerWithConfig(t, config.StreamConfig{FrameRejectionPolicy: "drop-segment"})
+	dir := t.TempDir()
+	s.recording = config.RecordingConfig{Dir: dir}
+	in := frames(1, 2, 3)
+	in[1].Audio = make([]byte, 3) // Misaligned LINEAR16
+
+	if err := s.StreamAudio(&fakeAudioStream{frames: in}); err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+
+	if got := testutil.ToFloat64(m.SegmentsDropped.WithLabelValues("invalid_frame")); got != 1 {
+		t.Fatalf("segments_dropped_total{invalid_frame} = %v, want 1", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "int-1", "int-1-seg-1.pcm")); !os.IsNotExist(err) {
+		t.Errorf("expected the dropped segment's recording to be removed, stat err=%v", err)
+	}
+}
+
+// fakeTranscribeStream is a fakeAudioStream that also captures sent transcripts.
+type fakeTranscribeStream struct {
+	fakeAudioStream
+
+	mu   sync.Mutex
+	sent []*pb.Transcript
+}
+
+func (f *fakeTranscribeStream) Send(t *pb.Transcript) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, t)
+	return nil
+}
+
+func TestStreamTranscribe_SendsTranscripts(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.newMock = newSyncMock
+	stream := &fakeTranscribeStream{
+		fakeAudioStream: fakeAudioStream{frames: frames(1)},
+	}
+
+	if err := s.StreamTranscribe(stream); err != nil {
+		t.Fatalf("StreamTranscribe: %v", err)
+	}
+
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	if len(stream.sent) != 1 {
+		t.Fatalf("sent %d transcripts, want the frame's partial", len(stream.sent))
+	}
+	if tr := stream.sent[0]; tr.IsFinal || tr.InteractionId != "int-1" || tr.Text == "" {
+		t.Errorf("unexpected transcript: %v", tr)
+	}
+	if got := stream.trailer.Get("x-segment-state"); len(got) != 1 {
+		t.Errorf("x-segment-state trailer = %v", got)
+	}
+}
+
+func TestToTranscript_Final(t *testing.T) {
+	tr := toTranscript(models.TranscriptFinal{
+		EventType:     "interaction.transcript.final",
+		InteractionID: "int-1",
+		SegmentID:     "int-1-seg-1",
+		Text:          "hello",
+		Confidence:    0.9,
+		AudioOffsetMs: 1200,
+		Alternatives:  []models.Alternative{{Text: "hello", Confidence: 0.9}, {Text: "yellow", Confidence: 0.2}},
+	})
+
+	if !tr.IsFinal || tr.Text != "hello" || tr.Confidence != 0.9 || tr.AudioOffsetMs != 1200 {
+		t.Errorf("unexpected transcript: %v", tr)
+	}
+	if len(tr.Alternatives) != 2 || tr.Alternatives[1].Text != "yellow" {
+		t.Errorf("alternatives = %v", tr.Alternatives)
+	}
+}
+
+func TestStreamAudio_DisconnectPolicy(t *testing.T) {
+	disconnect := status.Error(codes.Canceled, "client went away")
+
+	tests := []struct {
+		policy        string
+		wantCompleted float64
+		wantDropped   float64
+	}{
+		{"drop", 0, 1},
+		{"finalize", 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			s, m := newTestServerWithConfig(t, config.StreamConfig{OnDisconnect: tt.policy})
+			// The frame's partial is delivered before the disconnect
+			s.newMock = newSyncMock
+			stream := &fakeAudioStream{frames: frames(1), err: disconnect}
+
+			if err := s.StreamAudio(stream); err != disconnect {
+				t.Fatalf("StreamAudio = %v, want the recv error", err)
+			}
+			if got := testutil.ToFloat64(m.SegmentsCompleted); got != tt.wantCompleted {
+				t.Errorf("segments_completed_total = %v, want %v", got, tt.wantCompleted)
+			}
+			if got := testutil.ToFloat64(m.SegmentsDropped.WithLabelValues("client_disconnect")); got != tt.wantDropped {
+				t.Errorf("segments_dropped_total{client_disconnect} = %v, want %v", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestStreamAudio_FinalizeWithoutPartialDrops(t *testing.T) {
+	s, m := newTestServerWithConfig(t, config.StreamConfig{OnDisconnect: "finalize"})
+	stream := &fakeAudioStream{frames: frames(1), err: status.Error(codes.Canceled, "gone")}
+
+	s.StreamAudio(stream)
+	if got := testutil.ToFloat64(m.SegmentsDropped.WithLabelValues("client_disconnect")); got != 1 {
+		t.Errorf("segments_dropped_total{client_disconnect} = %v, want 1", got)
+	}
+}
+
+func TestRegisterWithConfig_RejectsUnknownDisconnectPolicy(t *testing.T) {
+	_, err := RegisterWithConfig(grpc.NewServer(), events.New(&events.Config{}), &config.Config{
+		STTProvider: "mock",
+		Stream:      config.StreamConfig{OnDisconnect: "keep"},
+	})
+	if err == nil {
+		t.Error("expected error for unknown disconnect policy")
+	}
+}
+
+func TestReload_AppliesToNewStreams(t *testing.T) {
+	s, _ := newTestServer(t)
+	before, _ := s.streamSettings()
+
+	err := s.Reload(&config.Config{
+		STTProvider: "mock",
+		STT:         config.STTConfig{SampleRateHz: 16000, Encoding: "LINEAR16", LanguageCode: "en-GB"},
+		Partials:    config.PartialConfig{MinChars: 5},
+	})
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	sttCfg, hc := s.streamSettings()
+	if sttCfg.LanguageCode != "en-GB" || sttCfg.SampleRateHz != 16000 || hc.PartialMinChars != 5 {
+		t.Errorf("settings after reload = %+v / minChars=%d", sttCfg, hc.PartialMinChars)
+	}
+	if before.LanguageCode != "en-US" {
+		t.Errorf("snapshot taken before reload changed: %+v", before)
+	}
+}
+
+func TestReload_InvalidConfigKeepsSettings(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	err := s.Reload(&config.Config{
+		STTProvider: "mock",
+		STT:         config.STTConfig{LanguageCode: "en-GB"},
+		Stream:      config.StreamConfig{FrameRejectionPolicy: "bogus"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unknown frame rejection policy")
+	}
+	if sttCfg, _ := s.streamSettings(); sttCfg.LanguageCode != "en-US" {
+		t.Errorf("language = %s after failed reload, want en-US", sttCfg.LanguageCode)
+	}
+}
@@ -0,0 +1,184 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"ai-speech-ingress-service/internal/config"
+)
+
+// testPKI is a throwaway CA with a server and a client certificate, written
+// as PEM files.
+type testPKI struct {
+	caFile, serverCert, serverKey string
+	roots                         *x509.CertPool
+	client                        tls.Certificate
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	dir := t.TempDir()
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	issue := func(serial int64, cn string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: cn},
+			DNSNames:     []string{cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, _ := x509.MarshalPKCS8PrivateKey(key)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	}
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	p := &testPKI{roots: x509.NewCertPool()}
+	p.roots.AddCert(ca)
+	p.caFile = write("ca.pem", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+	certPEM, keyPEM := issue(2, "speech-ingress", x509.ExtKeyUsageServerAuth)
+	p.serverCert, p.serverKey = write("server.pem", certPEM), write("server-key.pem", keyPEM)
+	certPEM, keyPEM = issue(3, "dialer-1", x509.ExtKeyUsageClientAuth)
+	if p.client, err = tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// serveTLS starts a health server with creds on an in-memory listener and
+// returns a dialer for it. Each call's client CN is sent to cns.
+func serveTLS(t *testing.T, creds credentials.TransportCredentials, cns chan<- string) func(*tls.Config) error {
+	t.Helper()
+	g := grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			cns <- clientCommonName(ctx)
+			return handler(ctx, req)
+		}))
+	grpc_health_v1.RegisterHealthServer(g, health.NewServer())
+	lis := bufconn.Listen(1 << 16)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	return func(tlsCfg *tls.Config) error {
+		conn, err := grpc.NewClient("passthrough:///speech-ingress",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)),
+		)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		return err
+	}
+}
+
+func TestServerCredentials_MutualTLS(t *testing.T) {
+	pki := newTestPKI(t)
+	creds, err := ServerCredentials(config.GRPCConfig{
+		TLSCertFile:     pki.serverCert,
+		TLSKeyFile:      pki.serverKey,
+		TLSClientCAFile: pki.caFile,
+	})
+	if err != nil {
+		t.Fatalf("ServerCredentials: %v", err)
+	}
+	cns := make(chan string, 1)
+	check := serveTLS(t, creds, cns)
+
+	if err := check(&tls.Config{RootCAs: pki.roots, ServerName: "speech-ingress", Certificates: []tls.Certificate{pki.client}}); err != nil {
+		t.Fatalf("client with certificate: %v", err)
+	}
+	if cn := <-cns; cn != "dialer-1" {
+		t.Errorf("client CN = %q, want dialer-1", cn)
+	}
+
+	if err := check(&tls.Config{RootCAs: pki.roots, ServerName: "speech-ingress"}); err == nil {
+		t.Error("client without a certificate was accepted")
+	}
+}
+
+func TestServerCredentials_ServerOnlyTLS(t *testing.T) {
+	pki := newTestPKI(t)
+	creds, err := ServerCredentials(config.GRPCConfig{TLSCertFile: pki.serverCert, TLSKeyFile: pki.serverKey})
+	if err != nil {
+		t.Fatalf("ServerCredentials: %v", err)
+	}
+	cns := make(chan string, 1)
+	check := serveTLS(t, creds, cns)
+
+	if err := check(&tls.Config{RootCAs: pki.roots, ServerName: "speech-ingress"}); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if cn := <-cns; cn != "" {
+		t.Errorf("client CN = %q without client auth, want none", cn)
+	}
+}
+
+func TestServerCredentials_Config(t *testing.T) {
+	if creds, err := ServerCredentials(config.GRPCConfig{}); creds != nil || err != nil {
+		t.Errorf("no certificate: creds=%v err=%v, want plaintext", creds, err)
+	}
+
+	pki := newTestPKI(t)
+	bad := []config.GRPCConfig{
+		{TLSCertFile: pki.serverCert, TLSKeyFile: "missing.pem"},
+		{TLSCertFile: pki.serverCert, TLSKeyFile: pki.serverKey, TLSClientCAFile: "missing.pem"},
+		// A key is not a CA certificate
+		{TLSCertFile: pki.serverCert, TLSKeyFile: pki.serverKey, TLSClientCAFile: pki.serverKey},
+	}
+	for _, cfg := range bad {
+		if _, err := ServerCredentials(cfg); err == nil {
+			t.Errorf("ServerCredentials(%+v) succeeded", cfg)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package grpcapi
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ai-speech-ingress-service/internal/models"
+	"ai-speech-ingress-service/internal/service/audio"
+	"ai-speech-ingress-service/internal/subtitles"
+	pb "ai-speech-ingress-service/proto"
+)
+
+// Limits of Google's synchronous Recognize API. Requests beyond them are
+// rejected up front rather than by Google after the upload.
+const (
+	maxFileBytes    = 10 << 20
+	maxFileDuration = time.Minute
+)
+
+// fileRequestOverhead is the room left in a TranscribeFile request for the
+// fields besides the audio.
+const fileRequestOverhead = 64 << 10
+
+// fileByteLimit is the most audio TranscribeFile accepts: Google's limit, or
+// less when the gRPC message limit is lower, so that oversized audio the
+// transport lets through is still rejected as INVALID_ARGUMENT.
+func fileByteLimit(maxRecvMsgBytes int) int {
+	if maxRecvMsgBytes <= 0 {
+		return maxFileBytes
+	}
+	return max(min(maxFileBytes, maxRecvMsgBytes-fileRequestOverhead), 0)
+}
+
+// TranscribeFile recognizes a complete audio clip in one request. Unlike
+// StreamAudio, results are returned to the caller and not published.
+// Only the google provider supports batch recognition.
+func (s *Server) TranscribeFile(ctx context.Context, req *pb.TranscribeFileRequest) (*pb.TranscribeFileResponse, error) {
+	if s.sttProvider != "google" {
+		return nil, status.Errorf(codes.Unimplemented, "file transcription is not supported by provider %s", s.sttProvider)
+	}
+	if len(req.Audio) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "audio is empty")
+	}
+	if len(req.Audio) > s.maxFileBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "audio is %d bytes, limit is %d", len(req.Audio), s.maxFileBytes)
+	}
+	var subtitleFormat subtitles.Format
+	if req.SubtitleFormat != "" {
+		f, err := subtitles.ParseFormat(req.SubtitleFormat)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		subtitleFormat = f
+	}
+
+	base, _ := s.streamSettings()
+	base = languageSettings(base, "")
+	sttCfg, err := s.negotiateFormat(base, req.InteractionId, declaredFormat{
+		sampleRateHz: req.SampleRateHz,
+		encoding:     req.Encoding,
+		channels:     req.Channels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	durationMs := audio.Config{SampleRateHz: sttCfg.SampleRateHz, Encoding: sttCfg.Encoding, Channels: sttCfg.Channels}.AudioDurationMs(int64(len(req.Audio)))
+	if durationMs > maxFileDuration.Milliseconds() {
+		return nil, status.Errorf(codes.InvalidArgument, "audio is %dms long, limit is %s", durationMs, maxFileDuration)
+	}
+
+	log.Printf("Transcribing file: interactionId=%s tenantId=%s bytes=%d audioMs=%d",
+		req.InteractionId, req.TenantId, len(req.Audio), durationMs)
+
+	results, err := s.transcribe(ctx, googleConfig(sttCfg), req.Audio)
+	if err != nil {
+		log.Printf("File transcription failed: interactionId=%s err=%v", req.InteractionId, err)
+		return nil, err
+	}
+
+	resp := &pb.TranscribeFileResponse{InteractionId: req.InteractionId}
+	texts := make([]string, 0, len(results))
+	for _, r := range results {
+		alts := make([]*pb.Alternative, len(r.Alternatives))
+		for i, alt := range r.Alternatives {
+			alts[i] = &pb.Alternative{Text: alt.Text, Confidence: alt.Confidence}
+		}
+		resp.Segments = append(resp.Segments, &pb.FileSegment{
+			SegmentId:    s.segments.Next(req.InteractionId),
+			Text:         alts[0].Text,
+			Confidence:   alts[0].Confidence,
+			EndOffsetMs:  r.EndOffsetMs,
+			Alternatives: alts,
+		})
+		texts = append(texts, alts[0].Text)
+	}
+	resp.Text = strings.Join(texts, " ")
+
+	if subtitleFormat != "" {
+		finals := make([]models.TranscriptFinal, len(resp.Segments))
+		for i, seg := range resp.Segments {
+			finals[i] = models.TranscriptFinal{SegmentID: seg.SegmentId, Text: seg.Text, AudioOffsetMs: seg.EndOffsetMs}
+		}
+		resp.Subtitles, err = subtitles.Render(subtitleFormat, finals, subtitles.DefaultOptions())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,137 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ai-speech-ingress-service/internal/config"
+	"ai-speech-ingress-service/internal/service/stt"
+	"ai-speech-ingress-service/internal/service/stt/google"
+	pb "ai-speech-ingress-service/proto"
+)
+
+func newFileTestServer(t *testing.T) (*Server, *google.Config) {
+	t.Helper()
+	s, _ := newTestServer(t)
+	s.sttProvider = "google"
+	var got google.Config
+	s.transcribe = func(_ context.Context, cfg google.Config, _ []byte) ([]google.Result, error) {
+		got = cfg
+		return []google.Result{
+			{Alternatives: []stt.Alternative{{Text: "hello", Confidence: 0.9}, {Text: "hallo", Confidence: 0.1}}, EndOffsetMs: 800},
+			{Alternatives: []stt.Alternative{{Text: "world", Confidence: 0.8}}, EndOffsetMs: 1600},
+		}, nil
+	}
+	return s, &got
+}
+
+func TestTranscribeFile(t *testing.T) {
+	s, got := newFileTestServer(t)
+
+	resp, err := s.TranscribeFile(context.Background(), &pb.TranscribeFileRequest{
+		InteractionId: "int-1",
+		Audio:         make([]byte, 32000),
+		SampleRateHz:  16000,
+	})
+	if err != nil {
+		t.Fatalf("TranscribeFile: %v", err)
+	}
+
+	if got.SampleRateHz != 16000 || got.Encoding != "LINEAR16" {
+		t.Errorf("recognized with %s/%d, want the declared rate", got.Encoding, got.SampleRateHz)
+	}
+	if resp.Text != "hello world" || len(resp.Segments) != 2 {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+	seg := resp.Segments[0]
+	if seg.Text != "hello" || seg.Confidence != 0.9 || seg.EndOffsetMs != 800 || len(seg.Alternatives) != 2 || seg.SegmentId == "" {
+		t.Errorf("unexpected first segment: %v", seg)
+	}
+	if resp.Segments[1].SegmentId == seg.SegmentId {
+		t.Error("expected a distinct segment ID per result")
+	}
+}
+
+func TestTranscribeFile_Subtitles(t *testing.T) {
+	s, _ := newFileTestServer(t)
+
+	resp, err := s.TranscribeFile(context.Background(), &pb.TranscribeFileRequest{
+		Audio:          make([]byte, 32000),
+		SubtitleFormat: "srt",
+	})
+	if err != nil {
+		t.Fatalf("TranscribeFile: %v", err)
+	}
+	want := "1\n00:00:00,000 --> 00:00:01,000\nhello\n\n2\n00:00:01,000 --> 00:00:02,000\nworld\n\n"
+	if resp.Subtitles != want {
+		t.Errorf("subtitles = %q, want %q", resp.Subtitles, want)
+	}
+}
+
+func TestTranscribeFile_Limits(t *testing.T) {
+	s, _ := newFileTestServer(t)
+	tests := []struct {
+		name string
+		req  *pb.TranscribeFileRequest
+	}{
+		{"empty", &pb.TranscribeFileRequest{}},
+		{"too many bytes", &pb.TranscribeFileRequest{Audio: make([]byte, maxFileBytes+1), Encoding: "OGG_OPUS"}},
+		// 61s of 8kHz LINEAR16
+		{"too long", &pb.TranscribeFileRequest{Audio: make([]byte, 61*16000)}},
+		{"bad format", &pb.TranscribeFileRequest{Audio: make([]byte, 320), SampleRateHz: 1000}},
+		{"bad subtitle format", &pb.TranscribeFileRequest{Audio: make([]byte, 320), SubtitleFormat: "ass"}},
+	}
+	for _, tt := range tests {
+		if _, err := s.TranscribeFile(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: err = %v, want InvalidArgument", tt.name, err)
+		}
+	}
+}
+
+func TestLoopback_TranscribeFileOversized(t *testing.T) {
+	lb := newLoopback(t)
+	lb.server.sttProvider = "google"
+	lb.server.transcribe = func(context.Context, google.Config, []byte) ([]google.Result, error) {
+		t.Error("oversized audio reached the recognizer")
+		return nil, nil
+	}
+
+	// Over the limit but within the default gRPC message size, so the
+	// request reaches TranscribeFile
+	size := fileByteLimit(config.Defaults().Stream.MaxRecvMsgBytes) + 1
+	_, err := lb.client.TranscribeFile(loopbackContext(t), &pb.TranscribeFileRequest{
+		Audio:    make([]byte, size),
+		Encoding: "OGG_OPUS",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestFileByteLimit(t *testing.T) {
+	tests := []struct {
+		maxRecvMsgBytes int
+		want            int
+	}{
+		{0, maxFileBytes},
+		{4 << 20, 4<<20 - fileRequestOverhead},
+		{64 << 20, maxFileBytes},
+	}
+	for _, tt := range tests {
+		if got := fileByteLimit(tt.maxRecvMsgBytes); got != tt.want {
+			t.Errorf("fileByteLimit(%d) = %d, want %d", tt.maxRecvMsgBytes, got, tt.want)
+		}
+	}
+}
+
+func TestTranscribeFile_UnsupportedProvider(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	_, err := s.TranscribeFile(context.Background(), &pb.TranscribeFileRequest{Audio: make([]byte, 320)})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("err = %v, want Unimplemented", err)
+	}
+}
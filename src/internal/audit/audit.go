@@ -0,0 +1,122 @@
+// Package audit keeps an append-only trail of dropped segments and STT errors
+// for compliance retention. It is separate from the operational logs and from
+// the transcript events published for application consumers.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Audit record events.
+const (
+	EventSegmentDropped = "segment.dropped"
+	EventSTTError       = "stt.error"
+)
+
+// Record is one audit entry. Times are Unix milliseconds.
+type Record struct {
+	Event            string `json:"event"`
+	Timestamp        int64  `json:"timestamp"`
+	InteractionID    string `json:"interactionId"`
+	TenantID         string `json:"tenantId"`
+	SegmentID        string `json:"segmentId"`
+	Reason           string `json:"reason"` // Drop reason, or the error for EventSTTError
+	State            string `json:"state"`  // Segment state when the event occurred
+	SegmentStartedAt int64  `json:"segmentStartedAt"`
+	AudioBytes       int64  `json:"audioBytes"`
+	AudioDurationMs  int64  `json:"audioDurationMs"`
+	PartialCount     int    `json:"partialCount"`
+	DurationMs       int64  `json:"durationMs"`
+}
+
+// Logger writes audit records. Implementations are safe for concurrent use.
+type Logger interface {
+	Log(ctx context.Context, rec Record) error
+	Close() error
+}
+
+// FileLogger appends records to a file as JSON lines. Each record is synced
+// to disk before Log returns.
+type FileLogger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileLogger opens path for appending, creating it if needed. Existing
+// records are never rewritten.
+func NewFileLogger(path string) (*FileLogger, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	return &FileLogger{file: f}, nil
+}
+
+func (l *FileLogger) Log(_ context.Context, rec Record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(line); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+func (l *FileLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// messageWriter is the subset of *kafka.Writer used by KafkaLogger.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// KafkaLogger writes records as JSON to a dedicated topic, keyed by
+// interactionId so each interaction's records stay in order.
+type KafkaLogger struct {
+	writer messageWriter
+}
+
+// NewKafkaLogger creates a logger for topic. Writes wait for all in-sync
+// replicas, since audit records must not be lost on a broker failure.
+func NewKafkaLogger(brokers []string, topic string) *KafkaLogger {
+	return &KafkaLogger{writer: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		BatchTimeout: 10 * time.Millisecond,
+		WriteTimeout: 10 * time.Second,
+		RequiredAcks: kafka.RequireAll,
+	}}
+}
+
+func (l *KafkaLogger) Log(ctx context.Context, rec Record) error {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return l.writer.WriteMessages(ctx, kafka.Message{
+		Key:     []byte(rec.InteractionID),
+		Value:   payload,
+		Headers: []kafka.Header{{Key: "event", Value: []byte(rec.Event)}},
+	})
+}
+
+func (l *KafkaLogger) Close() error {
+	return l.writer.Close()
+}
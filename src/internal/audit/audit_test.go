@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestFileLogger_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if err := os.WriteFile(path, []byte(`{"event":"earlier"}`+"\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewFileLogger(path)
+	if err != nil {
+		t.Fatalf("NewFileLogger: %v", err)
+	}
+	for _, reason := range []string{"empty_final", "client_cancel"} {
+		rec := Record{Event: EventSegmentDropped, InteractionID: "int-1", SegmentID: "int-1-seg-1", Reason: reason}
+		if err := l.Log(context.Background(), rec); err != nil {
+			t.Fatalf("Log: %v", err)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var events, reasons []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var rec Record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("line %q is not JSON: %v", sc.Text(), err)
+		}
+		events = append(events, rec.Event)
+		reasons = append(reasons, rec.Reason)
+	}
+	if len(events) != 3 || events[0] != "earlier" || reasons[1] != "empty_final" || reasons[2] != "client_cancel" {
+		t.Errorf("events=%v reasons=%v, want the earlier record followed by both drops", events, reasons)
+	}
+}
+
+type captureWriter struct {
+	msgs []kafka.Message
+}
+
+func (w *captureWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func (w *captureWriter) Close() error { return nil }
+
+func TestKafkaLogger_KeysByInteraction(t *testing.T) {
+	w := &captureWriter{}
+	l := &KafkaLogger{writer: w}
+
+	if err := l.Log(context.Background(), Record{Event: EventSTTError, InteractionID: "int-1", Reason: "stream reset"}); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+	if len(w.msgs) != 1 {
+		t.Fatalf("wrote %d messages, want 1", len(w.msgs))
+	}
+	msg := w.msgs[0]
+	var rec Record
+	if err := json.Unmarshal(msg.Value, &rec); err != nil || rec.Reason != "stream reset" {
+		t.Errorf("payload = %s (%v), want the record", msg.Value, err)
+	}
+	if string(msg.Key) != "int-1" || len(msg.Headers) != 1 || string(msg.Headers[0].Value) != EventSTTError {
+		t.Errorf("key=%s headers=%v, want key int-1 and the event header", msg.Key, msg.Headers)
+	}
+}
@@ -1,16 +1,197 @@
-// Package config provides configuration loading from environment variables.
+// Package config provides configuration loading from environment variables
+// and an optional JSON config file.
 package config
 
 import (
+	"encoding/json"
+	"fmt"
+	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds all service configuration.
 type Config struct {
 	Port        string
-	STTProvider string // "google" or "mock"
+	STTProvider string // "google", "whisper" or "mock"
+	STT         STTConfig
+	Whisper     WhisperConfig
+	Segment     SegmentConfig
+	Partials    PartialConfig
+	Redaction   RedactionConfig
 	Kafka       KafkaConfig
+	Recording   RecordingConfig
+	HTTP        HTTPConfig
+	GRPC        GRPCConfig
+	RateLimit   RateLimitConfig
+	Stream      StreamConfig
+	Metrics     MetricsConfig
+	Log         LogConfig
+	Shutdown    ShutdownConfig
+	Probe       ProbeConfig
+	Audit       AuditConfig
+}
+
+// AuditConfig holds the audit trail settings for dropped segments and STT
+// errors.
+type AuditConfig struct {
+	Sink  string // "none" (default), "file" or "kafka"
+	File  string // JSON-lines file for the file sink
+	Topic string // Kafka topic for the kafka sink
+}
+
+// ProbeConfig holds the synthetic STT probe settings.
+type ProbeConfig struct {
+	Interval  time.Duration // How often to probe; 0 disables
+	Timeout   time.Duration // Time allowed for a final to come back
+	AudioFile string        // Raw clip in the STT_* format; required unless the provider is mock
+}
+
+// ShutdownConfig holds graceful shutdown settings.
+type ShutdownConfig struct {
+	// DrainDelay is how long the stream service reports NOT_SERVING before the
+	// server stops accepting streams, so load balancers can route around it.
+	DrainDelay time.Duration
+}
+
+// RateLimitConfig holds per-tenant stream creation rate limits.
+type RateLimitConfig struct {
+	Default   TenantRate            // Applied to tenants without an override; Rate 0 disables
+	Overrides map[string]TenantRate // Per-tenant overrides keyed by tenantId
+}
+
+// TenantRate is a token-bucket rate (streams per second) and burst size.
+type TenantRate struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// MetricsConfig holds Prometheus metrics configuration.
+type MetricsConfig struct {
+	TenantAllowlist []string // Tenants reported by name in tenant labels; others are "other"
+}
+
+// LogConfig holds logging settings.
+type LogConfig struct {
+	PartialSampleRate int // Log the payload of 1 in N published partials; 0 or 1 logs all
+}
+
+// HTTPConfig holds the observability HTTP server configuration.
+type HTTPConfig struct {
+	Port                  string
+	DebugEndpointsEnabled bool // Exposes /debug/* endpoints with call metadata
+}
+
+// GRPCConfig holds gRPC server settings.
+type GRPCConfig struct {
+	ReflectionEnabled bool   // Serves the reflection API (lets grpcurl list services); defaults on only with ENV=dev
+	TLSCertFile       string // PEM server certificate; enables TLS
+	TLSKeyFile        string // PEM private key for TLSCertFile
+	TLSClientCAFile   string // PEM CA bundle; when set, clients must present a certificate it signed (mTLS)
+	AllowInsecure     bool   // Serve plaintext when no certificate is configured; defaults on only with ENV=dev
+}
+
+// STTConfig holds speech recognition settings shared by STT adapters.
+type STTConfig struct {
+	SampleRateHz    int    // Audio sample rate in Hz
+	Encoding        string // Audio encoding, e.g. "LINEAR16", "MULAW"
+	Channels        int    // Interleaved audio channels; only the first is recognized
+	LanguageCode    string // BCP-47 language code
+	Model           string // Provider model name, e.g. "phone_call"; passed through unvalidated
+	UseEnhanced     bool   // Use the provider's enhanced model variant where available
+	MaxAlternatives int    // N-best hypotheses included in finals (Google)
+	EnableITN       bool   // Rewrite spoken numbers/times in finals as digits
+
+	// Recognition metadata hints (Google); empty/zero values are omitted
+	InteractionType    string // e.g. "PHONE_CALL"
+	IndustryNaicsCode  int    // 6-digit NAICS code
+	MicrophoneDistance string // e.g. "NEARFIELD"
+
+	AllowUnknownEncoding bool          // Recognize an unrecognized Encoding as LINEAR16 instead of failing (Google)
+	SendTimeout          time.Duration // Fail an audio send the provider hasn't accepted within this; 0 disables
+	InterimStability     float64       // Skip partials less stable than this (0-1, Google); 0 keeps all
+	StartRetries         int           // Retries of a recognition stream that failed to open (Google)
+	StartBackoff         time.Duration // Base of the jittered exponential delay between those retries
+
+	LanguageProfiles map[string]LanguageProfile // Per-language overrides keyed by language code
+}
+
+// LanguageProfile overrides STT settings for streams in one language. Zero
+// values keep the global setting.
+type LanguageProfile struct {
+	SampleRateHz int    `json:"sampleRateHz"`
+	Encoding     string `json:"encoding"`
+	Model        string `json:"model"`
+}
+
+// WhisperConfig holds settings for the Whisper STT provider.
+type WhisperConfig struct {
+	Endpoint   string        // OpenAI-compatible /v1/audio/transcriptions URL
+	APIKey     string        // Bearer token; empty for unauthenticated local servers
+	Model      string        // e.g. "whisper-1"
+	Silence    time.Duration // Trailing silence that ends an utterance
+	MaxChunk   time.Duration // Longest audio chunk sent in one request
+	RequestTTL time.Duration // Timeout for a single transcription request
+}
+
+// StreamConfig holds per-stream (interaction) settings.
+type StreamConfig struct {
+	MaxInteractionDuration time.Duration // Cap on total stream length across segments; 0 disables
+	FrameRejectionPolicy   string        // "drop-frame" or "drop-segment" for malformed audio frames
+	OnDisconnect           string        // "drop" or "finalize" (from the last partial) the open segment on client disconnect
+	RequiredMetadata       []string      // gRPC metadata keys every audio stream must carry; empty disables
+	MaxFrameBytes          int           // Reject audio frames larger than this; 0 disables
+	MaxFrameDuration       time.Duration // Reject frames holding more audio than this in the stream's format; 0 disables
+	SilenceTrim            bool          // Skip LINEAR16 frames at the start of each segment until one is louder than SilenceThreshold
+	SilenceThreshold       float64       // RMS amplitude (0-32768) separating silence from speech for SilenceTrim
+	MaxRecvMsgBytes        int           // gRPC server's maximum received message size
+}
+
+// SegmentConfig holds per-segment handling settings.
+type SegmentConfig struct {
+	DropEmptyFinals        bool          // Drop segments whose final text is empty instead of publishing it
+	LowConfidenceThreshold float64       // Finals below this confidence are counted as low quality; 0 disables
+	ConfidenceFloor        float64       // Finals below this are re-emitted as partials while retries last; 0 disables
+	ConfidenceFloorRetries int           // Low-confidence finals deferred per segment before one is accepted
+	BoundaryPolicy         string        // "single-utterance" (default), "continuous" or "fixed-interval"
+	FixedInterval          time.Duration // Segment length under the fixed-interval policy
+	FinalHold              time.Duration // Hold finals this long in case the speaker continues; 0 disables
+	VADSilence             time.Duration // End an utterance after this much silence following speech, for providers without utterance events; 0 disables
+	VADThreshold           float64       // RMS amplitude (0-32768) that counts as speech for VADSilence
+
+	LimitsByTenant map[string]SegmentLimits // Per-tenant overrides of the global limits keyed by tenantId
+}
+
+// SegmentLimits overrides the stream and segment limits for one tenant. Zero
+// values keep the global setting.
+type SegmentLimits struct {
+	MaxInteractionDuration time.Duration `json:"maxInteractionDuration"` // INTERACTION_MAX_DURATION
+	MaxFrameBytes          int           `json:"maxFrameBytes"`          // MAX_FRAME_BYTES
+	FixedInterval          time.Duration `json:"fixedInterval"`          // SEGMENT_FIXED_INTERVAL, under the fixed-interval policy
+}
+
+// PartialConfig holds partial transcript publishing settings.
+type PartialConfig struct {
+	Debounce time.Duration // Publish only the latest partial per window; 0 publishes every partial
+	MinChars int           // Skip partials shorter than this
+	MinDelta int           // Skip partials that grew by less than this since the last published one
+
+	LateGrace time.Duration // Quietly ignore partials arriving this soon after their segment's final
+}
+
+// RedactionConfig holds PII redaction settings for published transcripts.
+type RedactionConfig struct {
+	Enabled  bool
+	Patterns []string // Regex patterns to mask; empty uses the built-in defaults
+	Partials bool     // Also redact partial transcripts
+}
+
+// RecordingConfig holds debug audio recording configuration.
+type RecordingConfig struct {
+	Dir         string // Directory to record raw audio into; empty disables recording
+	KeepDropped bool   // Keep recordings of segments that were dropped
 }
 
 // KafkaConfig holds Kafka publisher configuration.
@@ -20,19 +201,266 @@ type KafkaConfig struct {
 	TopicPartial string // Topic for partial transcripts
 	TopicFinal   string // Topic for final transcripts
 	Principal    string
+
+	SerializationFormat string // "json" (default) or "avro"
+	SchemaRegistryURL   string // Required when SerializationFormat is "avro"
+
+	EventFormat string // "raw" (default) or "cloudevents"
+	EventSource string // CloudEvents source attribute
+
+	EventTimestampSource string // "wallclock" (default) or "audio" (stream start + audio offset)
+
+	PublishPartials      bool // Publish partials; false sends only finals to Kafka
+	PublishSegmentClosed bool // Publish interaction.segment.closed on the final topic
+
+	MaxMessageBytes int // Events above this JSON size are truncated; 0 disables
+
+	BufferSize     int // Events queued for the background publisher; 0 publishes synchronously
+	PublishWorkers int // Background publishers; an interaction's events all go through one
+
+	Compression string // "none", "gzip", "snappy", "lz4", "zstd"
+
+	PartitionStrategy string // "least-bytes" (default) or "by-key"
+
+	StaticHeaders map[string]string // Headers added to every message
+	TenantTopics  map[string]string // tenantId -> dedicated topic for both partials and finals
 }
 
-// Load reads configuration from environment variables.
-func Load() *Config {
+// Defaults returns the built-in configuration, used for settings that neither
+// the config file nor the environment sets.
+func Defaults() *Config {
 	return &Config{
-		Port:        envOrDefault("GRPC_PORT", "50051"),
-		STTProvider: envOrDefault("STT_PROVIDER", "mock"), // default to mock for local dev
+		Port:        "50051",
+		STTProvider: "mock", // default to mock for local dev
+		STT: STTConfig{
+			SampleRateHz:    8000,
+			Encoding:        "LINEAR16",
+			Channels:        1,
+			LanguageCode:    "en-US",
+			Model:           "phone_call",
+			UseEnhanced:     true,
+			MaxAlternatives: 1,
+			SendTimeout:     5 * time.Second,
+			StartRetries:    2,
+			StartBackoff:    200 * time.Millisecond,
+		},
+		Whisper: WhisperConfig{
+			Endpoint:   "https://api.openai.com/v1/audio/transcriptions",
+			Model:      "whisper-1",
+			Silence:    800 * time.Millisecond,
+			MaxChunk:   30 * time.Second,
+			RequestTTL: 30 * time.Second,
+		},
+		Stream: StreamConfig{
+			FrameRejectionPolicy: "drop-frame",
+			OnDisconnect:         "drop",
+			MaxFrameBytes:        1 << 20,
+			SilenceThreshold:     500,
+			MaxRecvMsgBytes:      4 << 20,
+		},
+		Segment: SegmentConfig{
+			DropEmptyFinals:        true,
+			BoundaryPolicy:         "single-utterance",
+			ConfidenceFloorRetries: 1,
+			VADThreshold:           500,
+		},
+		Redaction: RedactionConfig{
+			Partials: true,
+		},
+		Kafka: KafkaConfig{
+			Brokers:              []string{"localhost:9092"},
+			TopicPartial:         "interaction.transcript.partial",
+			TopicFinal:           "interaction.transcript.final",
+			Principal:            "svc-speech-ingress",
+			SerializationFormat:  "json",
+			EventFormat:          "raw",
+			EventSource:          "/ai-speech-ingress-service",
+			EventTimestampSource: "wallclock",
+			PublishPartials:      true,
+			MaxMessageBytes:      1000000,
+			BufferSize:           1000,
+			PublishWorkers:       4,
+			Compression:          "none",
+			PartitionStrategy:    "least-bytes",
+		},
+		Log: LogConfig{
+			PartialSampleRate: 1,
+		},
+		Probe: ProbeConfig{
+			Timeout: 10 * time.Second,
+		},
+		Audit: AuditConfig{
+			Sink:  "none",
+			Topic: "interaction.audit",
+		},
+		HTTP: HTTPConfig{
+			Port: "8080",
+		},
+		GRPC: GRPCConfig{
+			// make run sets ENV=dev
+			ReflectionEnabled: os.Getenv("ENV") == "dev",
+			AllowInsecure:     os.Getenv("ENV") == "dev",
+		},
+		RateLimit: RateLimitConfig{
+			Default: TenantRate{Burst: 1},
+		},
+	}
+}
+
+// Load reads configuration from the JSON file named by CONFIG_FILE, if set,
+// and environment variables. Environment variables take precedence over the
+// file, which takes precedence over Defaults.
+func Load() (*Config, error) {
+	base := Defaults()
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := loadFile(path, base); err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	return fromEnv(base), nil
+}
+
+// fromEnv overlays environment variables on base.
+func fromEnv(base *Config) *Config {
+	return &Config{
+		Port:        envOrDefault("GRPC_PORT", base.Port),
+		STTProvider: envOrDefault("STT_PROVIDER", base.STTProvider),
+		STT: STTConfig{
+			SampleRateHz:    envIntOrDefault("STT_SAMPLE_RATE", base.STT.SampleRateHz),
+			Encoding:        envOrDefault("STT_ENCODING", base.STT.Encoding),
+			Channels:        envIntOrDefault("STT_CHANNELS", base.STT.Channels),
+			LanguageCode:    envOrDefault("STT_LANGUAGE", base.STT.LanguageCode),
+			Model:           envOrDefault("STT_MODEL", base.STT.Model),
+			UseEnhanced:     envBoolOrDefault("STT_USE_ENHANCED", base.STT.UseEnhanced),
+			MaxAlternatives: envIntOrDefault("STT_MAX_ALTERNATIVES", base.STT.MaxAlternatives),
+			EnableITN:       envBoolOrDefault("ENABLE_ITN", base.STT.EnableITN),
+
+			InteractionType:    envOrDefault("STT_INTERACTION_TYPE", base.STT.InteractionType),
+			IndustryNaicsCode:  envIntOrDefault("STT_INDUSTRY_NAICS_CODE", base.STT.IndustryNaicsCode),
+			MicrophoneDistance: envOrDefault("STT_MICROPHONE_DISTANCE", base.STT.MicrophoneDistance),
+
+			AllowUnknownEncoding: envBoolOrDefault("ALLOW_UNKNOWN_ENCODING", base.STT.AllowUnknownEncoding),
+			SendTimeout:          envDurationOrDefault("STT_SEND_TIMEOUT", base.STT.SendTimeout),
+			InterimStability:     envFloatOrDefault("STT_INTERIM_STABILITY", base.STT.InterimStability),
+			StartRetries:         envIntOrDefault("STT_START_RETRIES", base.STT.StartRetries),
+			StartBackoff:         envMillisOrDefault("STT_START_BACKOFF_MS", base.STT.StartBackoff),
+
+			LanguageProfiles: languageProfilesOrDefault("STT_LANGUAGE_PROFILES", base.STT.LanguageProfiles),
+		},
+		Whisper: WhisperConfig{
+			Endpoint:   envOrDefault("WHISPER_ENDPOINT", base.Whisper.Endpoint),
+			APIKey:     envOrDefault("WHISPER_API_KEY", base.Whisper.APIKey),
+			Model:      envOrDefault("WHISPER_MODEL", base.Whisper.Model),
+			Silence:    envMillisOrDefault("WHISPER_SILENCE_MS", base.Whisper.Silence),
+			MaxChunk:   envMillisOrDefault("WHISPER_MAX_CHUNK_MS", base.Whisper.MaxChunk),
+			RequestTTL: envDurationOrDefault("WHISPER_REQUEST_TIMEOUT", base.Whisper.RequestTTL),
+		},
+		Stream: StreamConfig{
+			MaxInteractionDuration: envDurationOrDefault("INTERACTION_MAX_DURATION", base.Stream.MaxInteractionDuration),
+			FrameRejectionPolicy:   envOrDefault("FRAME_REJECTION_POLICY", base.Stream.FrameRejectionPolicy),
+			OnDisconnect:           envOrDefault("ON_DISCONNECT", base.Stream.OnDisconnect),
+			RequiredMetadata:       envListOrDefault("REQUIRED_METADATA_KEYS", base.Stream.RequiredMetadata),
+			MaxFrameBytes:          envIntOrDefault("MAX_FRAME_BYTES", base.Stream.MaxFrameBytes),
+			MaxFrameDuration:       envDurationOrDefault("MAX_FRAME_DURATION", base.Stream.MaxFrameDuration),
+			SilenceTrim:            envBoolOrDefault("SILENCE_TRIM_ENABLED", base.Stream.SilenceTrim),
+			SilenceThreshold:       envFloatOrDefault("SILENCE_TRIM_THRESHOLD", base.Stream.SilenceThreshold),
+			MaxRecvMsgBytes:        envIntOrDefault("GRPC_MAX_RECV_MSG_BYTES", base.Stream.MaxRecvMsgBytes),
+		},
+		Segment: SegmentConfig{
+			DropEmptyFinals:        envBoolOrDefault("DROP_EMPTY_FINALS", base.Segment.DropEmptyFinals),
+			LowConfidenceThreshold: envFloatOrDefault("FINAL_LOW_CONFIDENCE_THRESHOLD", base.Segment.LowConfidenceThreshold),
+			ConfidenceFloor:        envFloatOrDefault("STT_FINAL_CONFIDENCE_FLOOR", base.Segment.ConfidenceFloor),
+			ConfidenceFloorRetries: envIntOrDefault("STT_FINAL_CONFIDENCE_FLOOR_RETRIES", base.Segment.ConfidenceFloorRetries),
+			BoundaryPolicy:         envOrDefault("SEGMENT_BOUNDARY_POLICY", base.Segment.BoundaryPolicy),
+			FixedInterval:          envDurationOrDefault("SEGMENT_FIXED_INTERVAL", base.Segment.FixedInterval),
+			FinalHold:              envMillisOrDefault("FINAL_HOLD_MS", base.Segment.FinalHold),
+			VADSilence:             envDurationOrDefault("VAD_SILENCE", base.Segment.VADSilence),
+			VADThreshold:           envFloatOrDefault("VAD_THRESHOLD", base.Segment.VADThreshold),
+
+			LimitsByTenant: segmentLimitsOrDefault("SEGMENT_LIMITS_BY_TENANT", base.Segment.LimitsByTenant),
+		},
+		Partials: PartialConfig{
+			Debounce: envMillisOrDefault("PARTIAL_DEBOUNCE_MS", base.Partials.Debounce),
+			MinChars: envIntOrDefault("PARTIAL_MIN_CHARS", base.Partials.MinChars),
+			MinDelta: envIntOrDefault("PARTIAL_MIN_DELTA", base.Partials.MinDelta),
+
+			LateGrace: envMillisOrDefault("PARTIAL_LATE_GRACE_MS", base.Partials.LateGrace),
+		},
+		Redaction: RedactionConfig{
+			Enabled:  envBoolOrDefault("REDACTION_ENABLED", base.Redaction.Enabled),
+			Patterns: jsonStringListOrDefault("REDACTION_PATTERNS", base.Redaction.Patterns),
+			Partials: envBoolOrDefault("REDACTION_PARTIALS", base.Redaction.Partials),
+		},
 		Kafka: KafkaConfig{
-			Enabled:      envOrDefault("KAFKA_ENABLED", "false") == "true",
-			Brokers:      strings.Split(envOrDefault("KAFKA_BROKERS", "localhost:9092"), ","),
-			TopicPartial: envOrDefault("KAFKA_TOPIC_PARTIAL", "interaction.transcript.partial"),
-			TopicFinal:   envOrDefault("KAFKA_TOPIC_FINAL", "interaction.transcript.final"),
-			Principal:    envOrDefault("KAFKA_PRINCIPAL", "svc-speech-ingress"),
+			Enabled:      envBoolOrDefault("KAFKA_ENABLED", base.Kafka.Enabled),
+			Brokers:      envListOrDefault("KAFKA_BROKERS", base.Kafka.Brokers),
+			TopicPartial: envOrDefault("KAFKA_TOPIC_PARTIAL", base.Kafka.TopicPartial),
+			TopicFinal:   envOrDefault("KAFKA_TOPIC_FINAL", base.Kafka.TopicFinal),
+			Principal:    envOrDefault("KAFKA_PRINCIPAL", base.Kafka.Principal),
+
+			SerializationFormat: envOrDefault("KAFKA_SERIALIZATION_FORMAT", base.Kafka.SerializationFormat),
+			SchemaRegistryURL:   envOrDefault("KAFKA_SCHEMA_REGISTRY_URL", base.Kafka.SchemaRegistryURL),
+
+			EventFormat: envOrDefault("EVENT_FORMAT", base.Kafka.EventFormat),
+			EventSource: envOrDefault("EVENT_SOURCE", base.Kafka.EventSource),
+
+			EventTimestampSource: envOrDefault("EVENT_TIMESTAMP_SOURCE", base.Kafka.EventTimestampSource),
+
+			PublishPartials:      envBoolOrDefault("KAFKA_PUBLISH_PARTIALS", base.Kafka.PublishPartials),
+			PublishSegmentClosed: envBoolOrDefault("KAFKA_PUBLISH_SEGMENT_CLOSED", base.Kafka.PublishSegmentClosed),
+
+			MaxMessageBytes: envIntOrDefault("KAFKA_MAX_MESSAGE_BYTES", base.Kafka.MaxMessageBytes),
+
+			BufferSize:     envIntOrDefault("KAFKA_BUFFER_SIZE", base.Kafka.BufferSize),
+			PublishWorkers: envIntOrDefault("KAFKA_PUBLISH_WORKERS", base.Kafka.PublishWorkers),
+
+			Compression: envOrDefault("KAFKA_COMPRESSION", base.Kafka.Compression),
+
+			PartitionStrategy: envOrDefault("KAFKA_PARTITION_STRATEGY", base.Kafka.PartitionStrategy),
+
+			StaticHeaders: keyValuePairsOrDefault("KAFKA_STATIC_HEADERS", base.Kafka.StaticHeaders),
+			TenantTopics:  jsonStringMapOrDefault("KAFKA_TENANT_TOPICS", base.Kafka.TenantTopics),
+		},
+		HTTP: HTTPConfig{
+			Port:                  envOrDefault("HTTP_PORT", base.HTTP.Port),
+			DebugEndpointsEnabled: envBoolOrDefault("DEBUG_ENDPOINTS_ENABLED", base.HTTP.DebugEndpointsEnabled),
+		},
+		GRPC: GRPCConfig{
+			ReflectionEnabled: envBoolOrDefault("GRPC_REFLECTION_ENABLED", base.GRPC.ReflectionEnabled),
+			TLSCertFile:       envOrDefault("TLS_CERT_FILE", base.GRPC.TLSCertFile),
+			TLSKeyFile:        envOrDefault("TLS_KEY_FILE", base.GRPC.TLSKeyFile),
+			TLSClientCAFile:   envOrDefault("TLS_CLIENT_CA_FILE", base.GRPC.TLSClientCAFile),
+			AllowInsecure:     envBoolOrDefault("GRPC_ALLOW_INSECURE", base.GRPC.AllowInsecure),
+		},
+		RateLimit: RateLimitConfig{
+			Default: TenantRate{
+				Rate:  envFloatOrDefault("TENANT_STREAM_RATE", base.RateLimit.Default.Rate),
+				Burst: envIntOrDefault("TENANT_STREAM_BURST", base.RateLimit.Default.Burst),
+			},
+			Overrides: tenantRateOverridesOrDefault("TENANT_STREAM_RATE_OVERRIDES", base.RateLimit.Overrides),
+		},
+		Metrics: MetricsConfig{
+			TenantAllowlist: envListOrDefault("METRICS_TENANT_ALLOWLIST", base.Metrics.TenantAllowlist),
+		},
+		Log: LogConfig{
+			PartialSampleRate: envIntOrDefault("LOG_SAMPLE_RATE", base.Log.PartialSampleRate),
+		},
+		Recording: RecordingConfig{
+			Dir:         envOrDefault("RECORD_AUDIO_DIR", base.Recording.Dir),
+			KeepDropped: envBoolOrDefault("RECORD_KEEP_DROPPED", base.Recording.KeepDropped),
+		},
+		Shutdown: ShutdownConfig{
+			DrainDelay: envDurationOrDefault("SHUTDOWN_DRAIN_DELAY", base.Shutdown.DrainDelay),
+		},
+		Probe: ProbeConfig{
+			Interval:  envDurationOrDefault("STT_PROBE_INTERVAL", base.Probe.Interval),
+			Timeout:   envDurationOrDefault("STT_PROBE_TIMEOUT", base.Probe.Timeout),
+			AudioFile: envOrDefault("STT_PROBE_AUDIO_FILE", base.Probe.AudioFile),
+		},
+		Audit: AuditConfig{
+			Sink:  envOrDefault("AUDIT_SINK", base.Audit.Sink),
+			File:  envOrDefault("AUDIT_FILE", base.Audit.File),
+			Topic: envOrDefault("AUDIT_TOPIC", base.Audit.Topic),
 		},
 	}
 }
@@ -43,3 +471,177 @@ func envOrDefault(key, def string) string {
 	}
 	return def
 }
+
+// envBoolOrDefault returns true only for the value "true".
+func envBoolOrDefault(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	return v == "true"
+}
+
+// envListOrDefault parses a comma-separated list, dropping empty entries.
+func envListOrDefault(key string, def []string) []string {
+	if v := os.Getenv(key); v != "" {
+		return splitNonEmpty(v)
+	}
+	return def
+}
+
+// envMillisOrDefault parses an integer number of milliseconds.
+func envMillisOrDefault(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %s", key, v, def)
+		return def
+	}
+	return time.Duration(n) * time.Millisecond
+}
+
+func envIntOrDefault(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+func envFloatOrDefault(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %v", key, v, def)
+		return def
+	}
+	return f
+}
+
+// splitNonEmpty splits a comma-separated list, dropping empty entries.
+func splitNonEmpty(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// keyValuePairsOrDefault parses an env var holding comma-separated key=value
+// pairs. Entries without a key are skipped.
+func keyValuePairsOrDefault(key string, def map[string]string) map[string]string {
+	pairs := splitNonEmpty(os.Getenv(key))
+	if len(pairs) == 0 {
+		return def
+	}
+	out := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		k, v, _ := strings.Cut(pair, "=")
+		if k = strings.TrimSpace(k); k == "" {
+			log.Printf("Invalid %s entry %q, ignoring", key, pair)
+			continue
+		}
+		out[k] = strings.TrimSpace(v)
+	}
+	return out
+}
+
+// tenantRateOverridesOrDefault parses an env var holding a JSON object of
+// tenantId -> {"rate":..,"burst":..}.
+func tenantRateOverridesOrDefault(key string, def map[string]TenantRate) map[string]TenantRate {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	var out map[string]TenantRate
+	if err := json.Unmarshal([]byte(v), &out); err != nil {
+		log.Printf("Invalid %s, ignoring: %v", key, err)
+		return def
+	}
+	return out
+}
+
+// languageProfilesOrDefault parses an env var holding a JSON object of
+// languageCode -> {"sampleRateHz":..,"encoding":..,"model":..}.
+func languageProfilesOrDefault(key string, def map[string]LanguageProfile) map[string]LanguageProfile {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	var out map[string]LanguageProfile
+	if err := json.Unmarshal([]byte(v), &out); err != nil {
+		log.Printf("Invalid %s, ignoring: %v", key, err)
+		return def
+	}
+	return out
+}
+
+// segmentLimitsOrDefault parses an env var holding a JSON object of tenantId ->
+// {"maxInteractionDuration":..,"maxFrameBytes":..,"fixedInterval":..}, with
+// durations as Go duration strings like in the config file.
+func segmentLimitsOrDefault(key string, def map[string]SegmentLimits) map[string]SegmentLimits {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	var out map[string]SegmentLimits
+	if err := unmarshalWithDurations([]byte(v), &out); err != nil {
+		log.Printf("Invalid %s, ignoring: %v", key, err)
+		return def
+	}
+	return out
+}
+
+func envDurationOrDefault(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %s", key, v, def)
+		return def
+	}
+	return d
+}
+
+// jsonStringListOrDefault parses an env var holding a JSON array of strings.
+func jsonStringListOrDefault(key string, def []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	var out []string
+	if err := json.Unmarshal([]byte(v), &out); err != nil {
+		log.Printf("Invalid %s, ignoring: %v", key, err)
+		return def
+	}
+	return out
+}
+
+// jsonStringMapOrDefault parses an env var holding a JSON object of strings.
+func jsonStringMapOrDefault(key string, def map[string]string) map[string]string {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	var out map[string]string
+	if err := json.Unmarshal([]byte(v), &out); err != nil {
+		log.Printf("Invalid %s, ignoring: %v", key, err)
+		return def
+	}
+	return out
+}
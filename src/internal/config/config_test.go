@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, contents string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+}
+
+func TestLoad_FileOnly(t *testing.T) {
+	writeConfigFile(t, `{
+		"sttProvider": "google",
+		"stt": {"sampleRateHz": 16000},
+		"partials": {"debounce": "250ms"},
+		"kafka": {"brokers": ["kafka-1:9092", "kafka-2:9092"]}
+	}`)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.STTProvider != "google" || cfg.STT.SampleRateHz != 16000 {
+		t.Errorf("provider/rate = %s/%d, want google/16000", cfg.STTProvider, cfg.STT.SampleRateHz)
+	}
+	if cfg.Partials.Debounce != 250*time.Millisecond {
+		t.Errorf("Partials.Debounce = %s, want 250ms", cfg.Partials.Debounce)
+	}
+	if len(cfg.Kafka.Brokers) != 2 {
+		t.Errorf("Kafka.Brokers = %v, want 2 brokers", cfg.Kafka.Brokers)
+	}
+	if cfg.STT.Encoding != "LINEAR16" {
+		t.Errorf("STT.Encoding = %q, want default LINEAR16", cfg.STT.Encoding)
+	}
+}
+
+func TestLoad_ReflectionDefaultsOnlyInDev(t *testing.T) {
+	tests := []struct {
+		env, flag string
+		want      bool
+	}{
+		{"", "", false},
+		{"prod", "", false},
+		{"dev", "", true},
+		{"dev", "false", false},
+		{"prod", "true", true},
+	}
+	for _, tt := range tests {
+		t.Setenv("ENV", tt.env)
+		t.Setenv("GRPC_REFLECTION_ENABLED", tt.flag)
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.GRPC.ReflectionEnabled != tt.want {
+			t.Errorf("ENV=%q GRPC_REFLECTION_ENABLED=%q: reflection = %v, want %v", tt.env, tt.flag, cfg.GRPC.ReflectionEnabled, tt.want)
+		}
+	}
+}
+
+func TestLoad_EnvOnly(t *testing.T) {
+	t.Setenv("STT_PROVIDER", "whisper")
+	t.Setenv("PARTIAL_DEBOUNCE_MS", "100")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.STTProvider != "whisper" {
+		t.Errorf("STTProvider = %q, want whisper", cfg.STTProvider)
+	}
+	if cfg.Partials.Debounce != 100*time.Millisecond {
+		t.Errorf("Partials.Debounce = %s, want 100ms", cfg.Partials.Debounce)
+	}
+}
+
+func TestLoad_EnvOverridesFile(t *testing.T) {
+	writeConfigFile(t, `{"sttProvider": "google", "stt": {"languageCode": "de-DE"}}`)
+	t.Setenv("STT_PROVIDER", "mock")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.STTProvider != "mock" {
+		t.Errorf("STTProvider = %q, want env value mock", cfg.STTProvider)
+	}
+	if cfg.STT.LanguageCode != "de-DE" {
+		t.Errorf("STT.LanguageCode = %q, want file value de-DE", cfg.STT.LanguageCode)
+	}
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	for name, contents := range map[string]string{
+		"malformed":        `{"sttProvider":`,
+		"unknown field":    `{"sttProvidr": "google"}`,
+		"invalid duration": `{"partials": {"debounce": "soon"}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			writeConfigFile(t, contents)
+			if _, err := Load(); err == nil {
+				t.Error("Load succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestLoad_SegmentLimitsByTenant(t *testing.T) {
+	writeConfigFile(t, `{"segment": {"limitsByTenant": {"acme": {"maxInteractionDuration": "2h"}}}}`)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := cfg.Segment.LimitsByTenant["acme"].MaxInteractionDuration; got != 2*time.Hour {
+		t.Errorf("file limit = %s, want 2h", got)
+	}
+
+	t.Setenv("SEGMENT_LIMITS_BY_TENANT", `{"globex": {"maxFrameBytes": 65536, "fixedInterval": "30s"}}`)
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := cfg.Segment.LimitsByTenant["globex"]; got.MaxFrameBytes != 65536 || got.FixedInterval != 30*time.Second {
+		t.Errorf("env limits = %+v, want maxFrameBytes 65536 and fixedInterval 30s", got)
+	}
+	if _, ok := cfg.Segment.LimitsByTenant["acme"]; ok {
+		t.Error("env should replace the file's overrides")
+	}
+}
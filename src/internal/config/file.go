@@ -0,0 +1,99 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// loadFile overlays the JSON config file at path on cfg. The file mirrors the
+// Config struct, with field names matched case-insensitively, e.g.
+//
+//	{"sttProvider": "google", "stt": {"sampleRateHz": 16000}, "partials": {"debounce": "200ms"}}
+//
+// Durations are Go duration strings. Settings missing from the file keep
+// their value in cfg; unknown fields are an error.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalWithDurations(data, cfg)
+}
+
+// unmarshalWithDurations decodes JSON into v, accepting Go duration strings
+// for time.Duration fields. Unknown fields are an error.
+func unmarshalWithDurations(data []byte, v any) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if err := parseDurations(raw, reflect.TypeOf(v).Elem(), ""); err != nil {
+		return err
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parseDurations walks the decoded JSON alongside the type t and replaces
+// duration strings with nanosecond counts, the form encoding/json expects for
+// time.Duration. Maps of structs are walked per entry.
+func parseDurations(raw any, t reflect.Type, path string) error {
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if t.Kind() == reflect.Map {
+		for key, v := range obj {
+			if err := parseDurations(v, t.Elem(), path+key+"."); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	for key, v := range obj {
+		f, ok := fieldByNameFold(t, key)
+		if !ok {
+			continue // Reported by DisallowUnknownFields
+		}
+		switch {
+		case f.Type == durationType:
+			str, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("%s%s: duration must be a string like \"500ms\"", path, key)
+			}
+			d, err := time.ParseDuration(str)
+			if err != nil {
+				return fmt.Errorf("%s%s: %w", path, key, err)
+			}
+			obj[key] = int64(d)
+		case f.Type.Kind() == reflect.Struct, f.Type.Kind() == reflect.Map:
+			if err := parseDurations(v, f.Type, path+key+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func fieldByNameFold(t reflect.Type, name string) (reflect.StructField, bool) {
+	return t.FieldByNameFunc(func(field string) bool {
+		return strings.EqualFold(field, name)
+	})
+}
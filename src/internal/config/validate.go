@@ -0,0 +1,177 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxChannels is the most interleaved audio channels Google accepts.
+const MaxChannels = 8
+
+// MinProbeInterval bounds how often the synthetic STT probe may run.
+const MinProbeInterval = 10 * time.Second
+
+// Validate checks the configuration for invalid values and contradictory
+// settings. All problems found are returned together.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	switch c.STTProvider {
+	case "google", "whisper", "mock":
+	default:
+		errs = append(errs, fmt.Errorf("STT_PROVIDER %q is not one of google, whisper, mock", c.STTProvider))
+	}
+	check(c.STT.SampleRateHz > 0, "STT_SAMPLE_RATE must be positive, got %d", c.STT.SampleRateHz)
+	if strings.EqualFold(c.STT.Encoding, "MULAW") {
+		check(c.STT.SampleRateHz == 8000, "STT_ENCODING MULAW requires an 8000 Hz sample rate, got %d", c.STT.SampleRateHz)
+	}
+	check(c.STT.Channels >= 1 && c.STT.Channels <= MaxChannels, "STT_CHANNELS must be between 1 and %d, got %d", MaxChannels, c.STT.Channels)
+	for lang, p := range c.STT.LanguageProfiles {
+		check(p.SampleRateHz >= 0, "STT_LANGUAGE_PROFILES: negative sample rate for %q", lang)
+		if strings.EqualFold(p.Encoding, "MULAW") {
+			rate := p.SampleRateHz
+			if rate == 0 {
+				rate = c.STT.SampleRateHz
+			}
+			check(rate == 8000, "STT_LANGUAGE_PROFILES: MULAW for %q requires an 8000 Hz sample rate, got %d", lang, rate)
+		}
+	}
+	check(c.STT.MaxAlternatives >= 0, "STT_MAX_ALTERNATIVES must not be negative, got %d", c.STT.MaxAlternatives)
+	if c.STTProvider == "whisper" {
+		check(c.Whisper.Endpoint != "", "STT_PROVIDER whisper requires WHISPER_ENDPOINT")
+		check(c.STT.Channels <= 1, "STT_PROVIDER whisper supports mono audio only, got STT_CHANNELS=%d", c.STT.Channels)
+	}
+
+	check(c.Segment.LowConfidenceThreshold >= 0 && c.Segment.LowConfidenceThreshold <= 1,
+		"FINAL_LOW_CONFIDENCE_THRESHOLD must be between 0 and 1, got %g", c.Segment.LowConfidenceThreshold)
+
+	check(c.Partials.Debounce >= 0, "PARTIAL_DEBOUNCE_MS must not be negative")
+	check(c.Partials.MinChars >= 0, "PARTIAL_MIN_CHARS must not be negative, got %d", c.Partials.MinChars)
+	check(c.Partials.MinDelta >= 0, "PARTIAL_MIN_DELTA must not be negative, got %d", c.Partials.MinDelta)
+	check(c.Partials.LateGrace >= 0, "PARTIAL_LATE_GRACE_MS must not be negative")
+
+	switch c.Stream.FrameRejectionPolicy {
+	case "", "drop-frame", "drop-segment":
+	default:
+		errs = append(errs, fmt.Errorf("FRAME_REJECTION_POLICY %q is not one of drop-frame, drop-segment", c.Stream.FrameRejectionPolicy))
+	}
+	switch c.Stream.OnDisconnect {
+	case "", "drop", "finalize":
+	default:
+		errs = append(errs, fmt.Errorf("ON_DISCONNECT %q is not one of drop, finalize", c.Stream.OnDisconnect))
+	}
+	check(c.Segment.FinalHold >= 0, "FINAL_HOLD_MS must not be negative")
+	check(c.Segment.ConfidenceFloor >= 0 && c.Segment.ConfidenceFloor <= 1,
+		"STT_FINAL_CONFIDENCE_FLOOR must be between 0 and 1, got %g", c.Segment.ConfidenceFloor)
+	if c.Segment.ConfidenceFloor > 0 {
+		// Providers that never re-finalize would otherwise lose every low-confidence final
+		check(c.Segment.ConfidenceFloorRetries >= 1,
+			"STT_FINAL_CONFIDENCE_FLOOR_RETRIES must be at least 1 with a confidence floor, got %d", c.Segment.ConfidenceFloorRetries)
+	}
+	check(c.STT.InterimStability >= 0 && c.STT.InterimStability <= 1,
+		"STT_INTERIM_STABILITY must be between 0 and 1, got %g", c.STT.InterimStability)
+	check(c.STT.StartRetries >= 0, "STT_START_RETRIES must not be negative, got %d", c.STT.StartRetries)
+	check(c.STT.StartBackoff >= 0, "STT_START_BACKOFF_MS must not be negative")
+	check(c.STT.SendTimeout >= 0, "STT_SEND_TIMEOUT must not be negative")
+	check(c.Stream.MaxInteractionDuration >= 0, "INTERACTION_MAX_DURATION must not be negative")
+	check(c.Stream.MaxRecvMsgBytes > 0, "GRPC_MAX_RECV_MSG_BYTES must be positive, got %d", c.Stream.MaxRecvMsgBytes)
+	check(c.Stream.MaxFrameBytes >= 0, "MAX_FRAME_BYTES must not be negative, got %d", c.Stream.MaxFrameBytes)
+	check(c.Stream.MaxFrameDuration >= 0, "MAX_FRAME_DURATION must not be negative")
+	if c.Stream.SilenceTrim {
+		check(c.Stream.SilenceThreshold > 0 && c.Stream.SilenceThreshold <= 32768,
+			"SILENCE_TRIM_THRESHOLD must be in (0, 32768] when SILENCE_TRIM_ENABLED is set, got %g", c.Stream.SilenceThreshold)
+	}
+	// Larger frames would already fail at the gRPC layer, with a less useful error
+	check(c.Stream.MaxFrameBytes < c.Stream.MaxRecvMsgBytes,
+		"MAX_FRAME_BYTES (%d) must be below GRPC_MAX_RECV_MSG_BYTES (%d)", c.Stream.MaxFrameBytes, c.Stream.MaxRecvMsgBytes)
+
+	for tenant, l := range c.Segment.LimitsByTenant {
+		check(l.MaxInteractionDuration >= 0 && l.MaxFrameBytes >= 0 && l.FixedInterval >= 0,
+			"SEGMENT_LIMITS_BY_TENANT: negative limit for %q", tenant)
+		check(l.MaxFrameBytes < c.Stream.MaxRecvMsgBytes,
+			"SEGMENT_LIMITS_BY_TENANT: maxFrameBytes for %q (%d) must be below GRPC_MAX_RECV_MSG_BYTES (%d)", tenant, l.MaxFrameBytes, c.Stream.MaxRecvMsgBytes)
+	}
+
+	check((c.GRPC.TLSCertFile == "") == (c.GRPC.TLSKeyFile == ""), "TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	check(c.GRPC.TLSClientCAFile == "" || c.GRPC.TLSCertFile != "", "TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE")
+	check(c.GRPC.TLSCertFile != "" || c.GRPC.AllowInsecure,
+		"TLS_CERT_FILE and TLS_KEY_FILE are required unless GRPC_ALLOW_INSECURE is set (the default only with ENV=dev)")
+
+	check(c.Segment.VADSilence >= 0, "VAD_SILENCE must not be negative")
+	if c.Segment.VADSilence > 0 {
+		check(c.Segment.VADThreshold > 0 && c.Segment.VADThreshold <= 32768,
+			"VAD_THRESHOLD must be in (0, 32768] when VAD_SILENCE is set, got %g", c.Segment.VADThreshold)
+	}
+
+	switch c.Segment.BoundaryPolicy {
+	case "", "single-utterance", "continuous":
+	case "fixed-interval":
+		check(c.Segment.FixedInterval > 0, "SEGMENT_FIXED_INTERVAL must be positive for the fixed-interval policy")
+	default:
+		errs = append(errs, fmt.Errorf("SEGMENT_BOUNDARY_POLICY %q is not one of single-utterance, continuous, fixed-interval", c.Segment.BoundaryPolicy))
+	}
+
+	switch c.Kafka.EventTimestampSource {
+	case "", "wallclock", "audio":
+	default:
+		errs = append(errs, fmt.Errorf("EVENT_TIMESTAMP_SOURCE %q is not one of wallclock, audio", c.Kafka.EventTimestampSource))
+	}
+
+	check(c.Log.PartialSampleRate >= 0, "LOG_SAMPLE_RATE must not be negative, got %d", c.Log.PartialSampleRate)
+	check(c.Kafka.MaxMessageBytes >= 0, "KAFKA_MAX_MESSAGE_BYTES must not be negative, got %d", c.Kafka.MaxMessageBytes)
+	check(c.Kafka.BufferSize >= 0, "KAFKA_BUFFER_SIZE must not be negative, got %d", c.Kafka.BufferSize)
+	if c.Kafka.BufferSize > 0 {
+		check(c.Kafka.PublishWorkers > 0, "KAFKA_BUFFER_SIZE requires KAFKA_PUBLISH_WORKERS of at least 1, got %d", c.Kafka.PublishWorkers)
+	}
+
+	if c.Kafka.Enabled {
+		check(len(splitNonEmpty(strings.Join(c.Kafka.Brokers, ","))) > 0, "KAFKA_ENABLED requires KAFKA_BROKERS")
+		check(c.Kafka.TopicPartial != "" && c.Kafka.TopicFinal != "",
+			"KAFKA_ENABLED requires KAFKA_TOPIC_PARTIAL and KAFKA_TOPIC_FINAL")
+		if c.Kafka.SerializationFormat == "avro" {
+			check(c.Kafka.SchemaRegistryURL != "", "KAFKA_SERIALIZATION_FORMAT avro requires KAFKA_SCHEMA_REGISTRY_URL")
+			// A tenant topic carries both event schemas under one subject
+			check(len(c.Kafka.TenantTopics) == 0, "KAFKA_TENANT_TOPICS is not supported with KAFKA_SERIALIZATION_FORMAT avro")
+			// Segment events would share the final topic's subject
+			check(!c.Kafka.PublishSegmentClosed, "KAFKA_PUBLISH_SEGMENT_CLOSED is not supported with KAFKA_SERIALIZATION_FORMAT avro")
+		}
+		for tenantId, topic := range c.Kafka.TenantTopics {
+			check(topic != "", "KAFKA_TENANT_TOPICS: empty topic for tenant %q", tenantId)
+		}
+	}
+
+	if c.RateLimit.Default.Rate > 0 {
+		check(c.RateLimit.Default.Burst >= 1, "TENANT_STREAM_BURST must be at least 1 when TENANT_STREAM_RATE is set")
+	}
+
+	check(c.Shutdown.DrainDelay >= 0, "SHUTDOWN_DRAIN_DELAY must not be negative")
+
+	if c.Probe.Interval != 0 {
+		// Every probe is billed by the provider
+		check(c.Probe.Interval >= MinProbeInterval, "STT_PROBE_INTERVAL must be 0 or at least %s, got %s", MinProbeInterval, c.Probe.Interval)
+		check(c.Probe.Timeout > 0 && c.Probe.Timeout < c.Probe.Interval,
+			"STT_PROBE_TIMEOUT must be positive and below STT_PROBE_INTERVAL, got %s", c.Probe.Timeout)
+		check(c.Probe.AudioFile != "" || c.STTProvider == "mock",
+			"STT_PROBE_AUDIO_FILE is required for the %s provider", c.STTProvider)
+	}
+
+	switch c.Audit.Sink {
+	case "", "none":
+	case "file":
+		check(c.Audit.File != "", "AUDIT_FILE is required for the file audit sink")
+	case "kafka":
+		check(c.Kafka.Enabled, "AUDIT_SINK kafka requires KAFKA_ENABLED")
+		check(c.Audit.Topic != "", "AUDIT_TOPIC is required for the kafka audit sink")
+	default:
+		errs = append(errs, fmt.Errorf("AUDIT_SINK %q is not one of none, file, kafka", c.Audit.Sink))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func validConfig() *Config {
+	return &Config{
+		Port:        "50051",
+		STTProvider: "mock",
+		STT:         STTConfig{SampleRateHz: 8000, Encoding: "LINEAR16", Channels: 1, MaxAlternatives: 1},
+		Stream:      StreamConfig{FrameRejectionPolicy: "drop-frame", OnDisconnect: "drop", MaxRecvMsgBytes: 4 << 20},
+		GRPC:        GRPCConfig{AllowInsecure: true},
+		Kafka: KafkaConfig{
+			Brokers:      []string{"localhost:9092"},
+			TopicPartial: "interaction.transcript.partial",
+			TopicFinal:   "interaction.transcript.final",
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string // Substring of the error; empty means valid
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"unknown provider", func(c *Config) { c.STTProvider = "azure" }, "STT_PROVIDER"},
+		{"zero sample rate", func(c *Config) { c.STT.SampleRateHz = 0 }, "STT_SAMPLE_RATE"},
+		{"mulaw at 16kHz", func(c *Config) { c.STT.Encoding = "MULAW"; c.STT.SampleRateHz = 16000 }, "MULAW"},
+		{"mulaw at 8kHz", func(c *Config) { c.STT.Encoding = "MULAW" }, ""},
+		{"negative alternatives", func(c *Config) { c.STT.MaxAlternatives = -1 }, "STT_MAX_ALTERNATIVES"},
+		{"whisper without endpoint", func(c *Config) { c.STTProvider = "whisper" }, "WHISPER_ENDPOINT"},
+		{"negative partial min chars", func(c *Config) { c.Partials.MinChars = -1 }, "PARTIAL_MIN_CHARS"},
+		{"unknown frame policy", func(c *Config) { c.Stream.FrameRejectionPolicy = "ignore" }, "FRAME_REJECTION_POLICY"},
+		{"unknown disconnect policy", func(c *Config) { c.Stream.OnDisconnect = "keep" }, "ON_DISCONNECT"},
+		{"frame larger than message", func(c *Config) { c.Stream.MaxFrameBytes = c.Stream.MaxRecvMsgBytes }, "MAX_FRAME_BYTES"},
+		{"negative frame duration", func(c *Config) { c.Stream.MaxFrameDuration = -time.Second }, "MAX_FRAME_DURATION"},
+		{"silence trim without threshold", func(c *Config) { c.Stream.SilenceTrim = true }, "SILENCE_TRIM_THRESHOLD"},
+		{"silence trim", func(c *Config) { c.Stream.SilenceTrim, c.Stream.SilenceThreshold = true, 500 }, ""},
+		{"negative vad silence", func(c *Config) { c.Segment.VADSilence = -time.Second }, "VAD_SILENCE"},
+		{"vad without threshold", func(c *Config) { c.Segment.VADSilence = time.Second }, "VAD_THRESHOLD"},
+		{"plaintext outside dev", func(c *Config) { c.GRPC.AllowInsecure = false }, "GRPC_ALLOW_INSECURE"},
+		{"tls", func(c *Config) {
+			c.GRPC = GRPCConfig{TLSCertFile: "server.pem", TLSKeyFile: "server-key.pem", TLSClientCAFile: "ca.pem"}
+		}, ""},
+		{"cert without key", func(c *Config) { c.GRPC.TLSCertFile = "server.pem" }, "TLS_KEY_FILE"},
+		{"client CA without cert", func(c *Config) { c.GRPC.TLSClientCAFile = "ca.pem" }, "TLS_CLIENT_CA_FILE"},
+		{"zero max message size", func(c *Config) { c.Stream.MaxRecvMsgBytes = 0 }, "GRPC_MAX_RECV_MSG_BYTES"},
+		{"kafka without brokers", func(c *Config) { c.Kafka.Enabled = true; c.Kafka.Brokers = []string{""} }, "KAFKA_BROKERS"},
+		{"kafka without topics", func(c *Config) { c.Kafka.Enabled = true; c.Kafka.TopicFinal = "" }, "KAFKA_TOPIC_FINAL"},
+		{"disabled kafka without topics", func(c *Config) { c.Kafka.TopicFinal = "" }, ""},
+		{"avro without registry", func(c *Config) { c.Kafka.Enabled = true; c.Kafka.SerializationFormat = "avro" }, "KAFKA_SCHEMA_REGISTRY_URL"},
+		{"negative drain delay", func(c *Config) { c.Shutdown.DrainDelay = -time.Second }, "SHUTDOWN_DRAIN_DELAY"},
+		{"empty tenant topic", func(c *Config) { c.Kafka.Enabled = true; c.Kafka.TenantTopics = map[string]string{"t1": ""} }, "KAFKA_TENANT_TOPICS"},
+		{"tenant topics with avro", func(c *Config) {
+			c.Kafka.Enabled = true
+			c.Kafka.SerializationFormat = "avro"
+			c.Kafka.SchemaRegistryURL = "http://registry:8081"
+			c.Kafka.TenantTopics = map[string]string{"t1": "t1.transcripts"}
+		}, "KAFKA_TENANT_TOPICS"},
+		{"segment closed with avro", func(c *Config) {
+			c.Kafka.Enabled = true
+			c.Kafka.SerializationFormat = "avro"
+			c.Kafka.SchemaRegistryURL = "http://registry:8081"
+			c.Kafka.PublishSegmentClosed = true
+		}, "KAFKA_PUBLISH_SEGMENT_CLOSED"},
+		{"negative late partial grace", func(c *Config) { c.Partials.LateGrace = -time.Millisecond }, "PARTIAL_LATE_GRACE_MS"},
+		{"negative log sample rate", func(c *Config) { c.Log.PartialSampleRate = -1 }, "LOG_SAMPLE_RATE"},
+		{"negative max message bytes", func(c *Config) { c.Kafka.MaxMessageBytes = -1 }, "KAFKA_MAX_MESSAGE_BYTES"},
+		{"negative buffer size", func(c *Config) { c.Kafka.BufferSize = -1 }, "KAFKA_BUFFER_SIZE"},
+		{"buffer without workers", func(c *Config) { c.Kafka.BufferSize = 100 }, "KAFKA_PUBLISH_WORKERS"},
+		{"buffer with workers", func(c *Config) { c.Kafka.BufferSize, c.Kafka.PublishWorkers = 100, 4 }, ""},
+		{"fixed interval", func(c *Config) {
+			c.Segment.BoundaryPolicy = "fixed-interval"
+			c.Segment.FixedInterval = 5 * time.Second
+		}, ""},
+		{"fixed interval without interval", func(c *Config) { c.Segment.BoundaryPolicy = "fixed-interval" }, "SEGMENT_FIXED_INTERVAL"},
+		{"probe with mock", func(c *Config) { c.Probe = ProbeConfig{Interval: time.Minute, Timeout: 5 * time.Second} }, ""},
+		{"probe too frequent", func(c *Config) { c.Probe = ProbeConfig{Interval: time.Second, Timeout: 500 * time.Millisecond} }, "STT_PROBE_INTERVAL"},
+		{"probe timeout above interval", func(c *Config) { c.Probe = ProbeConfig{Interval: time.Minute, Timeout: time.Hour} }, "STT_PROBE_TIMEOUT"},
+		{"google probe without clip", func(c *Config) {
+			c.STTProvider = "google"
+			c.Probe = ProbeConfig{Interval: time.Minute, Timeout: 5 * time.Second}
+		}, "STT_PROBE_AUDIO_FILE"},
+		{"language profile", func(c *Config) {
+			c.STT.LanguageProfiles = map[string]LanguageProfile{"es-ES": {SampleRateHz: 16000, Model: "latest_long"}}
+		}, ""},
+		{"language profile mulaw at 16kHz", func(c *Config) {
+			c.STT.LanguageProfiles = map[string]LanguageProfile{"es-ES": {SampleRateHz: 16000, Encoding: "MULAW"}}
+		}, "STT_LANGUAGE_PROFILES"},
+		{"tenant limits", func(c *Config) {
+			c.Segment.LimitsByTenant = map[string]SegmentLimits{"acme": {MaxInteractionDuration: time.Hour, MaxFrameBytes: 64 << 10}}
+		}, ""},
+		{"negative tenant limit", func(c *Config) {
+			c.Segment.LimitsByTenant = map[string]SegmentLimits{"acme": {FixedInterval: -time.Second}}
+		}, "SEGMENT_LIMITS_BY_TENANT"},
+		{"tenant frame limit above recv limit", func(c *Config) {
+			c.Segment.LimitsByTenant = map[string]SegmentLimits{"acme": {MaxFrameBytes: 8 << 20}}
+		}, "SEGMENT_LIMITS_BY_TENANT"},
+		{"file audit sink", func(c *Config) { c.Audit = AuditConfig{Sink: "file", File: "/var/log/audit.jsonl"} }, ""},
+		{"file audit sink without file", func(c *Config) { c.Audit.Sink = "file" }, "AUDIT_FILE"},
+		{"kafka audit sink without kafka", func(c *Config) { c.Audit = AuditConfig{Sink: "kafka", Topic: "audit"} }, "KAFKA_ENABLED"},
+		{"unknown audit sink", func(c *Config) { c.Audit.Sink = "syslog" }, "AUDIT_SINK"},
+		{"confidence floor", func(c *Config) {
+			c.Segment.ConfidenceFloor = 0.5
+			c.Segment.ConfidenceFloorRetries = 2
+		}, ""},
+		{"confidence floor above 1", func(c *Config) { c.Segment.ConfidenceFloor = 50 }, "STT_FINAL_CONFIDENCE_FLOOR"},
+		{"confidence floor without retries", func(c *Config) { c.Segment.ConfidenceFloor = 0.5 }, "STT_FINAL_CONFIDENCE_FLOOR_RETRIES"},
+		{"negative final hold", func(c *Config) { c.Segment.FinalHold = -time.Millisecond }, "FINAL_HOLD_MS"},
+		{"interim stability above 1", func(c *Config) { c.STT.InterimStability = 1.5 }, "STT_INTERIM_STABILITY"},
+		{"negative start retries", func(c *Config) { c.STT.StartRetries = -1 }, "STT_START_RETRIES"},
+		{"negative send timeout", func(c *Config) { c.STT.SendTimeout = -time.Second }, "STT_SEND_TIMEOUT"},
+		{"unknown boundary policy", func(c *Config) { c.Segment.BoundaryPolicy = "sentence" }, "SEGMENT_BOUNDARY_POLICY"},
+		{"unknown timestamp source", func(c *Config) { c.Kafka.EventTimestampSource = "ntp" }, "EVENT_TIMESTAMP_SOURCE"},
+		{"rate limit without burst", func(c *Config) { c.RateLimit.Default = TenantRate{Rate: 1} }, "TENANT_STREAM_BURST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig()
+			tt.mutate(c)
+
+			err := c.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate = %v, want error mentioning %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_ReportsAllProblems(t *testing.T) {
+	c := validConfig()
+	c.STTProvider = "azure"
+	c.STT.SampleRateHz = 0
+
+	err := c.Validate()
+	if err == nil || !strings.Contains(err.Error(), "STT_PROVIDER") || !strings.Contains(err.Error(), "STT_SAMPLE_RATE") {
+		t.Errorf("Validate = %v, want both problems reported", err)
+	}
+}
+
+func TestLoad_DefaultsAreValid(t *testing.T) {
+	// Outside dev the defaults have no TLS certificate and refuse plaintext
+	t.Setenv("ENV", "dev")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("default config is invalid: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"ai-speech-ingress-service/internal/models"
+)
+
+// Serialization formats supported by the publisher.
+const (
+	FormatJSON = "json"
+	FormatAvro = "avro"
+)
+
+// Avro schemas for the transcript events. Field order must match the
+// encode functions below, since Avro binary encoding is positional.
+const (
+	transcriptPartialSchema = `{"type":"record","name":"TranscriptPartial","namespace":"ai.speech.ingress","fields":[` +
+		`{"name":"eventType","type":"string"},` +
+		`{"name":"interactionId","type":"string"},` +
+		`{"name":"tenantId","type":"string"},` +
+		`{"name":"timestamp","type":"long"},` +
+		`{"name":"segmentId","type":"string"},` +
+		`{"name":"text","type":"string"},` +
+		`{"name":"schemaVersion","type":"string","default":"1.0"}]}`
+
+	transcriptFinalSchema = `{"type":"record","name":"TranscriptFinal","namespace":"ai.speech.ingress","fields":[` +
+		`{"name":"eventType","type":"string"},` +
+		`{"name":"interactionId","type":"string"},` +
+		`{"name":"tenantId","type":"string"},` +
+		`{"name":"timestamp","type":"long"},` +
+		`{"name":"segmentId","type":"string"},` +
+		`{"name":"text","type":"string"},` +
+		`{"name":"confidence","type":"double"},` +
+		`{"name":"audioOffsetMs","type":"long"},` +
+		`{"name":"alternatives","type":{"type":"array","items":{"type":"record","name":"Alternative","fields":[` +
+		`{"name":"text","type":"string"},` +
+		`{"name":"confidence","type":"double"}]}},"default":[]},` +
+		`{"name":"schemaVersion","type":"string","default":"1.0"}]}`
+)
+
+// wireMagicByte is the first byte of every Confluent wire-format message.
+const wireMagicByte = 0x00
+
+// avroEncoder writes Avro binary-encoded primitives.
+type avroEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *avroEncoder) writeLong(v int64) {
+	// Avro longs are zig-zag encoded varints
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(tmp[:], v)
+	e.buf.Write(tmp[:n])
+}
+
+func (e *avroEncoder) writeString(s string) {
+	e.writeLong(int64(len(s)))
+	e.buf.WriteString(s)
+}
+
+func (e *avroEncoder) writeDouble(v float64) {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], math.Float64bits(v))
+	e.buf.Write(tmp[:])
+}
+
+// encodeAvro encodes a transcript event using the Confluent wire format:
+// magic byte, 4-byte big-endian schema ID, then the Avro binary payload.
+func encodeAvro(schemaID int32, event any) ([]byte, error) {
+	e := &avroEncoder{}
+	e.buf.WriteByte(wireMagicByte)
+	var id [4]byte
+	binary.BigEndian.PutUint32(id[:], uint32(schemaID))
+	e.buf.Write(id[:])
+
+	switch ev := event.(type) {
+	case models.TranscriptPartial:
+		e.writeString(ev.EventType)
+		e.writeString(ev.InteractionID)
+		e.writeString(ev.TenantID)
+		e.writeLong(ev.Timestamp)
+		e.writeString(ev.SegmentID)
+		e.writeString(ev.Text)
+		e.writeString(ev.SchemaVersion)
+	case models.TranscriptFinal:
+		e.writeString(ev.EventType)
+		e.writeString(ev.InteractionID)
+		e.writeString(ev.TenantID)
+		e.writeLong(ev.Timestamp)
+		e.writeString(ev.SegmentID)
+		e.writeString(ev.Text)
+		e.writeDouble(ev.Confidence)
+		e.writeLong(ev.AudioOffsetMs)
+		// Arrays are encoded as a single block: item count, items, then 0
+		if len(ev.Alternatives) > 0 {
+			e.writeLong(int64(len(ev.Alternatives)))
+			for _, alt := range ev.Alternatives {
+				e.writeString(alt.Text)
+				e.writeDouble(alt.Confidence)
+			}
+		}
+		e.writeLong(0)
+		e.writeString(ev.SchemaVersion)
+	default:
+		return nil, fmt.Errorf("avro: unsupported event type %T", event)
+	}
+
+	return e.buf.Bytes(), nil
+}
+
+// registerSchema registers a schema under the given subject with a Confluent
+// schema registry and returns the schema ID. Registration is idempotent:
+// re-registering an identical schema returns the existing ID.
+func registerSchema(ctx context.Context, client *http.Client, registryURL, subject, schema string) (int32, error) {
+	body, err := json.Marshal(map[string]string{"schema": schema})
+	if err != nil {
+		return 0, err
+	}
+
+	url := strings.TrimRight(registryURL, "/") + "/subjects/" + subject + "/versions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("schema registry returned %s for subject %s", resp.Status, subject)
+	}
+
+	var out struct {
+		ID int32 `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return 0, fmt.Errorf("decode schema registry response: %w", err)
+	}
+	return out.ID, nil
+}
@@ -0,0 +1,123 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ai-speech-ingress-service/internal/models"
+)
+
+func TestEncodeAvro_WireFormatPrefix(t *testing.T) {
+	payload, err := encodeAvro(42, models.TranscriptPartial{EventType: "p"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []byte{0x00, 0x00, 0x00, 0x00, 42}
+	if !bytes.Equal(payload[:5], want) {
+		t.Errorf("prefix = %x, want %x", payload[:5], want)
+	}
+}
+
+func TestEncodeAvro_Partial(t *testing.T) {
+	payload, err := encodeAvro(1, models.TranscriptPartial{
+		EventType:     "p",
+		InteractionID: "i",
+		TenantID:      "t",
+		Timestamp:     1,
+		SegmentID:     "s",
+		Text:          "hi",
+		SchemaVersion: "1.1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Strings are zig-zag length-prefixed (len 1 -> 0x02), longs are zig-zag (1 -> 0x02)
+	want := []byte{
+		0x02, 'p',
+		0x02, 'i',
+		0x02, 't',
+		0x02,
+		0x02, 's',
+		0x04, 'h', 'i',
+		0x06, '1', '.', '1',
+	}
+	if !bytes.Equal(payload[5:], want) {
+		t.Errorf("body = %x, want %x", payload[5:], want)
+	}
+}
+
+func TestEncodeAvro_FinalAlternatives(t *testing.T) {
+	final := models.TranscriptFinal{Text: "a", Confidence: 0.5}
+	payload, err := encodeAvro(1, final)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// No alternatives: the array is just the terminating zero block, followed
+	// by the empty schemaVersion
+	if !bytes.Equal(payload[len(payload)-2:], []byte{0x00, 0x00}) {
+		t.Errorf("empty alternatives not terminated: %x", payload)
+	}
+
+	final.Alternatives = []models.Alternative{{Text: "a", Confidence: 0.5}, {Text: "b", Confidence: 0.25}}
+	withAlts, err := encodeAvro(1, final)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// One block of two items (0x04), each a string and a double, then 0
+	tail := withAlts[len(payload)-2 : len(withAlts)-1]
+	if tail[0] != 0x04 || len(tail) != 1+2*(2+8)+1 || tail[len(tail)-1] != 0x00 {
+		t.Errorf("alternatives encoding = %x", tail)
+	}
+	if !bytes.Equal(tail[1:3], []byte{0x02, 'a'}) || !bytes.Equal(tail[11:13], []byte{0x02, 'b'}) {
+		t.Errorf("alternative texts not encoded in order: %x", tail)
+	}
+}
+
+func TestEncodeAvro_UnsupportedType(t *testing.T) {
+	if _, err := encodeAvro(1, struct{}{}); err == nil {
+		t.Error("expected error for unsupported event type")
+	}
+}
+
+func TestRegisterSchema(t *testing.T) {
+	var gotPath, gotSchema string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotSchema = body["schema"]
+		_, _ = w.Write([]byte(`{"id":7}`))
+	}))
+	defer srv.Close()
+
+	id, err := registerSchema(context.Background(), srv.Client(), srv.URL+"/", "topic-value", transcriptFinalSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 7 {
+		t.Errorf("id = %d, want 7", id)
+	}
+	if gotPath != "/subjects/topic-value/versions" {
+		t.Errorf("path = %s", gotPath)
+	}
+	if gotSchema != transcriptFinalSchema {
+		t.Errorf("schema not sent as-is: %s", gotSchema)
+	}
+}
+
+func TestRegisterSchema_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer srv.Close()
+
+	if _, err := registerSchema(context.Background(), srv.Client(), srv.URL, "s", "{}"); err == nil {
+		t.Error("expected error for non-200 response")
+	}
+}
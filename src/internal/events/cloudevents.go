@@ -0,0 +1,92 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"ai-speech-ingress-service/internal/models"
+)
+
+// Event formats supported by the publisher.
+const (
+	EventFormatRaw         = "raw"
+	EventFormatCloudEvents = "cloudevents"
+)
+
+const cloudEventsSpecVersion = "1.0"
+
+// cloudEvent is a CloudEvents 1.0 structured-mode JSON envelope.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// newCloudEvent builds the envelope attributes for an event. The CloudEvents
+// type is taken from the event's EventType so consumers can route on it.
+func newCloudEvent(source string, event any, data []byte) cloudEvent {
+	return cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              newEventID(),
+		Source:          source,
+		Type:            eventTypeOf(event),
+		Time:            time.Now().UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// headers returns the CloudEvents attributes as Kafka headers, following the
+// Kafka protocol binding ("ce_" prefixed attribute names).
+func (ce cloudEvent) headers() []kafka.Header {
+	return []kafka.Header{
+		{Key: "ce_specversion", Value: []byte(ce.SpecVersion)},
+		{Key: "ce_id", Value: []byte(ce.ID)},
+		{Key: "ce_source", Value: []byte(ce.Source)},
+		{Key: "ce_type", Value: []byte(ce.Type)},
+		{Key: "ce_time", Value: []byte(ce.Time)},
+	}
+}
+
+// eventTypeOf returns the EventType of a transcript event, or an empty string
+// for unknown event types.
+func eventTypeOf(event any) string {
+	switch ev := event.(type) {
+	case models.TranscriptPartial:
+		return ev.EventType
+	case models.TranscriptFinal:
+		return ev.EventType
+	case models.SegmentClosed:
+		return ev.EventType
+	default:
+		return ""
+	}
+}
+
+// newEventID returns a random UUIDv4 string.
+func newEventID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+	return string(out[:])
+}
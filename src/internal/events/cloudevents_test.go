@@ -0,0 +1,65 @@
+package events
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"ai-speech-ingress-service/internal/models"
+)
+
+func TestNewCloudEvent_MapsTypeFromEventType(t *testing.T) {
+	ev := models.TranscriptFinal{EventType: "interaction.transcript.final", Text: "hello"}
+	data, _ := json.Marshal(ev)
+
+	ce := newCloudEvent("/test", ev, data)
+
+	if ce.Type != "interaction.transcript.final" {
+		t.Errorf("type = %q", ce.Type)
+	}
+	if ce.SpecVersion != "1.0" || ce.Source != "/test" || ce.DataContentType != "application/json" {
+		t.Errorf("unexpected attributes: %+v", ce)
+	}
+
+	out, err := json.Marshal(ce)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded struct {
+		Data models.TranscriptFinal `json:"data"`
+	}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.Data.Text != "hello" {
+		t.Errorf("data not embedded as JSON object: %s", out)
+	}
+}
+
+func TestCloudEvent_Headers(t *testing.T) {
+	ce := newCloudEvent("/test", models.TranscriptPartial{EventType: "p"}, []byte("{}"))
+
+	got := map[string]string{}
+	for _, h := range ce.headers() {
+		got[h.Key] = string(h.Value)
+	}
+	for _, key := range []string{"ce_specversion", "ce_id", "ce_source", "ce_type", "ce_time"} {
+		if got[key] == "" {
+			t.Errorf("missing header %s", key)
+		}
+	}
+	if got["ce_type"] != "p" {
+		t.Errorf("ce_type = %q", got["ce_type"])
+	}
+}
+
+func TestNewEventID_IsUUIDv4(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	a, b := newEventID(), newEventID()
+	if !re.MatchString(a) {
+		t.Errorf("not a UUIDv4: %s", a)
+	}
+	if a == b {
+		t.Error("expected unique IDs")
+	}
+}
@@ -4,21 +4,75 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/segmentio/kafka-go"
+
+	"ai-speech-ingress-service/internal/models"
+	"ai-speech-ingress-service/internal/observability/metrics"
 )
 
+// ErrMessageTooLarge is returned for events that exceed the maximum message
+// size even after truncation.
+var ErrMessageTooLarge = errors.New("event exceeds the maximum message size")
+
+// messageWriter is the subset of *kafka.Writer used by the publisher.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
 // Publisher publishes transcript events to separate Kafka topics.
 type Publisher struct {
-	writerPartial *kafka.Writer
-	writerFinal   *kafka.Writer
+	writerPartial messageWriter
+	writerFinal   messageWriter
 	principal     string
+	staticHeaders []kafka.Header
 	topicPartial  string
 	topicFinal    string
 	enabled       bool
+
+	disablePartials bool
+	maxMessageBytes int
+	metrics         *metrics.Metrics
+
+	partialLogEvery int
+	partialLogs     atomic.Uint64
+
+	// Tenant-scoped routing
+	tenantTopics  map[string]string        // tenantId -> dedicated topic
+	tenantWriters map[string]messageWriter // topic -> writer
+
+	// Serialization
+	format            string
+	schemaRegistryURL string
+	schemaIDs         map[string]int32 // topic -> registered Avro schema ID
+
+	// Envelope
+	eventFormat string
+	eventSource string
+
+	// In-flight writes, tracked so Flush can wait for them. Queued messages
+	// count as in flight.
+	mu       sync.Mutex
+	inflight int
+	drained  chan struct{} // Closed when inflight drops to zero
+
+	// Asynchronous publishing, enabled by a non-zero bufferSize. Each key is
+	// hashed to one worker, which writes its events in order.
+	bufferSize  int // Queued messages per worker
+	workers     []*publishWorker
+	stop        chan struct{} // Closed by Close to stop the workers
+	workersDone sync.WaitGroup
 }
 
 // Config holds Kafka publisher configuration.
@@ -28,6 +82,107 @@ type Config struct {
 	TopicFinal   string
 	Principal    string
 	Enabled      bool
+
+	// SerializationFormat selects the payload encoding: "json" (default) or "avro".
+	// Avro payloads use the Confluent wire format and require SchemaRegistryURL.
+	SerializationFormat string
+	SchemaRegistryURL   string
+
+	// EventFormat selects the message envelope: "raw" (default) publishes the bare
+	// event, "cloudevents" wraps it in a CloudEvents 1.0 envelope with ce_* headers.
+	EventFormat string
+	// EventSource is the CloudEvents "source" attribute.
+	EventSource string
+
+	// Compression is the producer compression codec: "none" (default), "gzip",
+	// "snappy", "lz4" or "zstd".
+	Compression string
+
+	// PartitionStrategy selects how messages are assigned to partitions:
+	// "least-bytes" (default) spreads load evenly, "by-key" hashes the
+	// interactionId key so all events of an interaction stay in order.
+	PartitionStrategy string
+
+	// StaticHeaders are added to every message, e.g. for lineage.
+	StaticHeaders map[string]string
+
+	// TenantTopics routes both partial and final events of the listed tenants
+	// (tenantId -> topic) to a dedicated topic instead of the default ones.
+	// Consumers tell the event kinds apart by the eventType header.
+	TenantTopics map[string]string
+
+	// MaxMessageBytes caps the JSON size of an event. Larger finals lose their
+	// alternatives and larger events get their text truncated, flagged by a
+	// "truncated" header. Zero disables the check.
+	MaxMessageBytes int
+
+	// PartialLogSampleRate logs the payload of only 1 in N published
+	// partials. Zero or one logs every partial; finals and errors are always
+	// logged.
+	PartialLogSampleRate int
+
+	// DisablePartials drops partial events instead of publishing them, for
+	// deployments whose consumers only read finals. No partial writer is
+	// created.
+	DisablePartials bool
+
+	// BufferSize makes publishing asynchronous: events are queued, up to
+	// BufferSize of them, and written by a background flusher so a slow broker
+	// doesn't block callers. When the buffer is full the oldest partial is
+	// dropped; finals and segment events are never dropped and wait for room
+	// instead. Zero writes each event before returning.
+	BufferSize int
+
+	// Workers is the number of background writers when BufferSize is set.
+	// Events with the same key (interactionId) always go to the same worker,
+	// so they are written in publish order; the buffer is split between the
+	// workers. Defaults to 1.
+	Workers int
+}
+
+// Partition strategies supported by the publisher.
+const (
+	PartitionLeastBytes = "least-bytes"
+	PartitionByKey      = "by-key"
+)
+
+// newBalancer returns the kafka-go balancer for a partition strategy.
+// Returns false for unrecognized strategies.
+func newBalancer(strategy string) (kafka.Balancer, bool) {
+	switch strategy {
+	case "", PartitionLeastBytes:
+		return &kafka.LeastBytes{}, true
+	case PartitionByKey:
+		return &kafka.Hash{}, true
+	default:
+		return &kafka.LeastBytes{}, false
+	}
+}
+
+// mustBalancer returns a fresh balancer for an already-validated strategy.
+// Each writer needs its own instance since balancers keep internal state.
+func mustBalancer(strategy string) kafka.Balancer {
+	b, _ := newBalancer(strategy)
+	return b
+}
+
+// parseCompression maps a codec name to a kafka-go compression codec.
+// Returns false for unrecognized names.
+func parseCompression(name string) (kafka.Compression, bool) {
+	switch name {
+	case "", "none":
+		return 0, true
+	case "gzip":
+		return kafka.Gzip, true
+	case "snappy":
+		return kafka.Snappy, true
+	case "lz4":
+		return kafka.Lz4, true
+	case "zstd":
+		return kafka.Zstd, true
+	default:
+		return 0, false
+	}
 }
 
 // New creates a new Kafka event publisher with separate topics for partial and final transcripts.
@@ -38,7 +193,10 @@ func New(cfg *Config) *Publisher {
 			principal:    cfg.Principal,
 			topicPartial: cfg.TopicPartial,
 			topicFinal:   cfg.TopicFinal,
+			tenantTopics: cfg.TenantTopics,
 			enabled:      false,
+
+			partialLogEvery: cfg.PartialLogSampleRate,
 		}
 	}
 
@@ -55,53 +213,202 @@ func New(cfg *Config) *Publisher {
 		Dial: dialer.DialFunc,
 	}
 
-	// Writer for partial transcripts
-	writerPartial := &kafka.Writer{
-		Addr:         kafka.TCP(cfg.Brokers...),
-		Topic:        cfg.TopicPartial,
-		Balancer:     &kafka.LeastBytes{},
-		BatchTimeout: 10 * time.Millisecond,
-		WriteTimeout: 10 * time.Second,
-		RequiredAcks: kafka.RequireOne,
-		Transport:    transport,
+	compression, ok := parseCompression(cfg.Compression)
+	if !ok {
+		log.Printf("[PUBLISHER] WARNING: unknown compression %q, falling back to none", cfg.Compression)
+	}
+	codec := "none"
+	if compression != 0 {
+		codec = compression.String()
+	}
+
+	strategy := cfg.PartitionStrategy
+	if _, ok := newBalancer(strategy); !ok {
+		log.Printf("[PUBLISHER] WARNING: unknown partition strategy %q, falling back to %s", strategy, PartitionLeastBytes)
+		strategy = PartitionLeastBytes
+	}
+	if strategy == "" {
+		strategy = PartitionLeastBytes
+	}
+
+	newWriter := func(topic string) *kafka.Writer {
+		return &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Topic:        topic,
+			Balancer:     mustBalancer(strategy),
+			BatchTimeout: 10 * time.Millisecond,
+			WriteTimeout: 10 * time.Second,
+			RequiredAcks: kafka.RequireOne,
+			Compression:  compression,
+			Transport:    transport,
+		}
+	}
+
+	// One writer per dedicated tenant topic, shared by tenants routed to it
+	tenantWriters := make(map[string]messageWriter)
+	for tenantId, topic := range cfg.TenantTopics {
+		if _, ok := tenantWriters[topic]; !ok {
+			tenantWriters[topic] = newWriter(topic)
+		}
+		log.Printf("[PUBLISHER] Routing tenant %s to topic %s", tenantId, topic)
+	}
+
+	log.Printf("[PUBLISHER] Kafka enabled: brokers=%v topicPartial=%s topicFinal=%s compression=%s partitionStrategy=%s",
+		cfg.Brokers, cfg.TopicPartial, cfg.TopicFinal, codec, strategy)
+
+	var writerPartial messageWriter
+	if cfg.DisablePartials {
+		log.Println("[PUBLISHER] Partial publishing disabled")
+	} else {
+		writerPartial = newWriter(cfg.TopicPartial)
+	}
+
+	return newWithWriters(cfg, writerPartial, newWriter(cfg.TopicFinal), tenantWriters)
+}
+
+// newWithWriters creates an enabled publisher that writes through the given
+// writers, with tenantWriters keyed by topic. Tests use it to capture messages
+// without a broker.
+func newWithWriters(cfg *Config, writerPartial, writerFinal messageWriter, tenantWriters map[string]messageWriter) *Publisher {
+	format := cfg.SerializationFormat
+	if format == "" {
+		format = FormatJSON
+	}
+	if format != FormatJSON && format != FormatAvro {
+		log.Printf("[PUBLISHER] Unknown serialization format %q, using %s", format, FormatJSON)
+		format = FormatJSON
+	}
+
+	eventFormat := cfg.EventFormat
+	if eventFormat == "" {
+		eventFormat = EventFormatRaw
+	}
+	if eventFormat != EventFormatRaw && eventFormat != EventFormatCloudEvents {
+		log.Printf("[PUBLISHER] Unknown event format %q, using %s", eventFormat, EventFormatRaw)
+		eventFormat = EventFormatRaw
 	}
 
-	// Writer for final transcripts
-	writerFinal := &kafka.Writer{
-		Addr:         kafka.TCP(cfg.Brokers...),
-		Topic:        cfg.TopicFinal,
-		Balancer:     &kafka.LeastBytes{},
-		BatchTimeout: 10 * time.Millisecond,
-		WriteTimeout: 10 * time.Second,
-		RequiredAcks: kafka.RequireOne,
-		Transport:    transport,
+	log.Printf("[PUBLISHER] Serialization: format=%s eventFormat=%s", format, eventFormat)
+
+	p := &Publisher{
+		writerPartial:     writerPartial,
+		writerFinal:       writerFinal,
+		principal:         cfg.Principal,
+		staticHeaders:     staticHeaders(cfg.StaticHeaders),
+		topicPartial:      cfg.TopicPartial,
+		topicFinal:        cfg.TopicFinal,
+		tenantTopics:      cfg.TenantTopics,
+		tenantWriters:     tenantWriters,
+		disablePartials:   cfg.DisablePartials,
+		maxMessageBytes:   cfg.MaxMessageBytes,
+		metrics:           metrics.Default,
+		partialLogEvery:   cfg.PartialLogSampleRate,
+		enabled:           true,
+		format:            format,
+		schemaRegistryURL: cfg.SchemaRegistryURL,
+		eventFormat:       eventFormat,
+		eventSource:       cfg.EventSource,
+	}
+	if cfg.BufferSize > 0 {
+		p.startWorkers(cfg.BufferSize, cfg.Workers)
 	}
+	return p
+}
 
-	log.Printf("[PUBLISHER] Kafka enabled: brokers=%v topicPartial=%s topicFinal=%s",
-		cfg.Brokers, cfg.TopicPartial, cfg.TopicFinal)
+// SetMetrics overrides the metrics instance (defaults to metrics.Default).
+func (p *Publisher) SetMetrics(m *metrics.Metrics) {
+	p.metrics = m
+}
 
-	return &Publisher{
-		writerPartial: writerPartial,
-		writerFinal:   writerFinal,
-		principal:     cfg.Principal,
-		topicPartial:  cfg.TopicPartial,
-		topicFinal:    cfg.TopicFinal,
-		enabled:       true,
+// staticHeaders converts configured static headers to Kafka headers, sorted
+// by key so every message carries them in the same order.
+func staticHeaders(m map[string]string) []kafka.Header {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+
+	headers := make([]kafka.Header, len(keys))
+	for i, k := range keys {
+		headers[i] = kafka.Header{Key: k, Value: []byte(m[k])}
+	}
+	return headers
 }
 
-// PublishPartial publishes a partial transcript event to the partial topic.
-func (p *Publisher) PublishPartial(ctx context.Context, key string, event any) error {
-	return p.publish(ctx, p.writerPartial, p.topicPartial, key, event)
+// RegisterSchemas registers the transcript Avro schemas with the schema registry
+// using the TopicNameStrategy ("<topic>-value" subjects). It must be called at
+// startup before publishing when the serialization format is Avro, and is a
+// no-op otherwise.
+func (p *Publisher) RegisterSchemas(ctx context.Context) error {
+	if !p.enabled || p.format != FormatAvro {
+		return nil
+	}
+	if p.schemaRegistryURL == "" {
+		return fmt.Errorf("avro serialization requires a schema registry URL")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	schemas := map[string]string{
+		p.topicFinal: transcriptFinalSchema,
+	}
+	if !p.disablePartials {
+		schemas[p.topicPartial] = transcriptPartialSchema
+	}
+
+	ids := make(map[string]int32, len(schemas))
+	for topic, schema := range schemas {
+		subject := topic + "-value"
+		id, err := registerSchema(ctx, client, p.schemaRegistryURL, subject, schema)
+		if err != nil {
+			return fmt.Errorf("register schema for %s: %w", subject, err)
+		}
+		log.Printf("[PUBLISHER] Registered Avro schema: subject=%s id=%d", subject, id)
+		ids[topic] = id
+	}
+	p.schemaIDs = ids
+	return nil
 }
 
-// PublishFinal publishes a final transcript event to the final topic.
-func (p *Publisher) PublishFinal(ctx context.Context, key string, event any) error {
-	return p.publish(ctx, p.writerFinal, p.topicFinal, key, event)
+// PublishPartial publishes a partial transcript event to the tenant's topic,
+// or the partial topic if the tenant has none. No-op if partials are disabled.
+func (p *Publisher) PublishPartial(ctx context.Context, tenantId, key string, event any) error {
+	if p.disablePartials {
+		return nil
+	}
+	writer, topic := p.route(tenantId, p.writerPartial, p.topicPartial)
+	return p.publish(ctx, writer, topic, p.topicPartial, key, event)
 }
 
-// publish is the internal method that writes to a specific Kafka writer.
-func (p *Publisher) publish(ctx context.Context, writer *kafka.Writer, topic string, key string, event any) error {
+// PublishFinal publishes a final transcript event to the tenant's topic, or
+// the final topic if the tenant has none.
+func (p *Publisher) PublishFinal(ctx context.Context, tenantId, key string, event any) error {
+	writer, topic := p.route(tenantId, p.writerFinal, p.topicFinal)
+	return p.publish(ctx, writer, topic, p.topicFinal, key, event)
+}
+
+// PublishSegmentEvent publishes a segment lifecycle event alongside the finals,
+// to the tenant's topic or the final topic, so it is ordered after the
+// segment's final. The eventType header carries the event's own type.
+func (p *Publisher) PublishSegmentEvent(ctx context.Context, tenantId, key string, event any) error {
+	writer, topic := p.route(tenantId, p.writerFinal, p.topicFinal)
+	return p.publish(ctx, writer, topic, eventTypeOf(event), key, event)
+}
+
+// route returns the writer and topic for a tenant's events, falling back to
+// the given default writer and topic for tenants without a dedicated topic.
+func (p *Publisher) route(tenantId string, writer messageWriter, topic string) (messageWriter, string) {
+	if t, ok := p.tenantTopics[tenantId]; ok {
+		return p.tenantWriters[t], t
+	}
+	return writer, topic
+}
+
+// publish is the internal method that writes to a specific Kafka writer, or
+// queues the message for the flusher when publishing is asynchronous.
+// eventType is the default topic of the event's kind, sent as a header so
+// consumers of shared tenant topics can tell partials from finals.
+func (p *Publisher) publish(ctx context.Context, writer messageWriter, topic, eventType, key string, event any) error {
 	payload, err := json.Marshal(event)
 	if err != nil {
 		log.Printf("[PUBLISHER] Failed to marshal event: %v", err)
@@ -109,23 +416,74 @@ func (p *Publisher) publish(ctx context.Context, writer *kafka.Writer, topic str
 	}
 
 	// Log the event
-	log.Printf("[PUBLISH] principal=%s topic=%s key=%s payload=%s", p.principal, topic, key, payload)
+	if p.shouldLog(event) {
+		log.Printf("[PUBLISH] principal=%s topic=%s key=%s payload=%s", p.principal, topic, key, payload)
+	}
 
 	// If Kafka is disabled, just log
 	if !p.enabled || writer == nil {
 		return nil
 	}
 
+	truncated := false
+	if p.maxMessageBytes > 0 && len(payload) > p.maxMessageBytes {
+		size := len(payload)
+		if event, payload, err = p.fitMessage(event, payload); err != nil {
+			p.metrics.KafkaOversizedMessages.WithLabelValues("rejected").Inc()
+			log.Printf("[PUBLISHER] Rejected oversized event: topic=%s key=%s bytes=%d max=%d", topic, key, size, p.maxMessageBytes)
+			return err
+		}
+		p.metrics.KafkaOversizedMessages.WithLabelValues("truncated").Inc()
+		log.Printf("[PUBLISHER] Truncated oversized event: topic=%s key=%s bytes=%d max=%d", topic, key, size, p.maxMessageBytes)
+		truncated = true
+	}
+
+	headers := append(p.lineageHeaders(eventType, event), p.staticHeaders...)
+	if truncated {
+		headers = append(headers, kafka.Header{Key: "truncated", Value: []byte("true")})
+	}
+
+	if p.eventFormat == EventFormatCloudEvents {
+		ce := newCloudEvent(p.eventSource, event, payload)
+		headers = append(headers, ce.headers()...)
+		if p.format == FormatJSON {
+			// Structured mode: the envelope carries the event as "data"
+			if payload, err = json.Marshal(ce); err != nil {
+				log.Printf("[PUBLISHER] Failed to marshal cloudevent: %v", err)
+				return err
+			}
+			headers = append(headers, kafka.Header{Key: "content-type", Value: []byte("application/cloudevents+json")})
+		} else {
+			// Binary mode: attributes travel only as ce_* headers
+			headers = append(headers, kafka.Header{Key: "content-type", Value: []byte("application/avro")})
+		}
+	}
+
+	if p.format == FormatAvro {
+		schemaID, ok := p.schemaIDs[topic]
+		if !ok {
+			return fmt.Errorf("no registered avro schema for topic %s", topic)
+		}
+		if payload, err = encodeAvro(schemaID, event); err != nil {
+			log.Printf("[PUBLISHER] Failed to encode avro event: %v", err)
+			return err
+		}
+	}
+
 	// Publish to Kafka
 	msg := kafka.Message{
-		Key:   []byte(key),
-		Value: payload,
-		Headers: []kafka.Header{
-			{Key: "eventType", Value: []byte(topic)},
-			{Key: "principal", Value: []byte(p.principal)},
-		},
+		Key:     []byte(key),
+		Value:   payload,
+		Headers: headers,
+	}
+
+	if p.bufferSize > 0 {
+		_, partial := event.(models.TranscriptPartial)
+		return p.enqueue(ctx, key, queuedMessage{writer: writer, topic: topic, msg: msg, partial: partial})
 	}
 
+	p.beginWrite()
+	defer p.endWrite()
 	if err := writer.WriteMessages(ctx, msg); err != nil {
 		log.Printf("[PUBLISHER] Failed to write to Kafka topic=%s: %v", topic, err)
 		return err
@@ -134,8 +492,149 @@ func (p *Publisher) publish(ctx context.Context, writer *kafka.Writer, topic str
 	return nil
 }
 
-// Close closes both Kafka writers.
+// shouldLog reports whether the payload of event is logged. Partials are
+// sampled to 1 in partialLogEvery, starting with the first.
+func (p *Publisher) shouldLog(event any) bool {
+	if _, ok := event.(models.TranscriptPartial); !ok || p.partialLogEvery <= 1 {
+		return true
+	}
+	return p.partialLogs.Add(1)%uint64(p.partialLogEvery) == 1
+}
+
+// fitMessage shrinks an event whose JSON payload exceeds maxMessageBytes:
+// finals drop their alternatives, then the text is cut by the remaining
+// excess. Returns the shrunk event and its payload, or ErrMessageTooLarge if
+// it still does not fit.
+func (p *Publisher) fitMessage(event any, payload []byte) (any, []byte, error) {
+	var err error
+	if ev, ok := event.(models.TranscriptFinal); ok && len(ev.Alternatives) > 0 {
+		ev.Alternatives = nil
+		event = ev
+		if payload, err = json.Marshal(event); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if excess := len(payload) - p.maxMessageBytes; excess > 0 {
+		switch ev := event.(type) {
+		case models.TranscriptPartial:
+			ev.Text = truncateText(ev.Text, len(ev.Text)-excess)
+			event = ev
+		case models.TranscriptFinal:
+			ev.Text = truncateText(ev.Text, len(ev.Text)-excess)
+			event = ev
+		}
+		if payload, err = json.Marshal(event); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(payload) > p.maxMessageBytes {
+		return nil, nil, ErrMessageTooLarge
+	}
+	return event, payload, nil
+}
+
+// truncateText cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncateText(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if n >= len(s) {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func (p *Publisher) beginWrite() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.beginWriteLocked(1)
+}
+
+func (p *Publisher) endWrite() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.endWriteLocked(1)
+}
+
+func (p *Publisher) beginWriteLocked(n int) {
+	if p.inflight == 0 {
+		p.drained = make(chan struct{})
+	}
+	p.inflight += n
+}
+
+func (p *Publisher) endWriteLocked(n int) {
+	p.inflight -= n
+	if p.inflight == 0 {
+		close(p.drained)
+	}
+}
+
+// Flush waits for in-flight writes, including queued messages and messages
+// the writers are still batching, to complete. Call it before Close during
+// shutdown, once no new events are being published. Returns ctx.Err() if the
+// writes don't finish before ctx is done. No-op when Kafka is disabled.
+func (p *Publisher) Flush(ctx context.Context) error {
+	if !p.enabled {
+		return nil
+	}
+
+	p.mu.Lock()
+	pending, drained := p.inflight, p.drained
+	p.mu.Unlock()
+	if pending == 0 {
+		return nil
+	}
+
+	log.Printf("[PUBLISHER] Flushing %d pending messages", pending)
+	select {
+	case <-drained:
+		log.Printf("[PUBLISHER] Flushed %d pending messages", pending)
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		left := p.inflight
+		p.mu.Unlock()
+		log.Printf("[PUBLISHER] Flush timed out: flushed=%d unflushed=%d", pending-left, left)
+		return ctx.Err()
+	}
+}
+
+// lineageHeaders returns the per-message provenance headers.
+func (p *Publisher) lineageHeaders(eventType string, event any) []kafka.Header {
+	var segmentId, tenantId string
+	switch ev := event.(type) {
+	case models.TranscriptPartial:
+		segmentId, tenantId = ev.SegmentID, ev.TenantID
+	case models.TranscriptFinal:
+		segmentId, tenantId = ev.SegmentID, ev.TenantID
+	case models.SegmentClosed:
+		segmentId, tenantId = ev.SegmentID, ev.TenantID
+	}
+	return []kafka.Header{
+		{Key: "eventType", Value: []byte(eventType)},
+		{Key: "principal", Value: []byte(p.principal)},
+		{Key: "producerPrincipal", Value: []byte(p.principal)},
+		{Key: "segmentId", Value: []byte(segmentId)},
+		{Key: "tenantId", Value: []byte(tenantId)},
+		{Key: "schemaVersion", Value: []byte(models.SchemaVersion)},
+	}
+}
+
+// Close stops the workers, once they finish the writes in progress, and
+// closes all Kafka writers. Events still queued are discarded; call Flush
+// first to write them.
 func (p *Publisher) Close() error {
+	if p.stop != nil {
+		close(p.stop)
+		p.workersDone.Wait()
+	}
+
 	var err error
 	if p.writerPartial != nil {
 		if e := p.writerPartial.Close(); e != nil {
@@ -147,5 +646,10 @@ func (p *Publisher) Close() error {
 			err = e
 		}
 	}
+	for _, w := range p.tenantWriters {
+		if e := w.Close(); e != nil {
+			err = e
+		}
+	}
 	return err
 }
@@ -0,0 +1,652 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/segmentio/kafka-go"
+
+	"ai-speech-ingress-service/internal/models"
+	"ai-speech-ingress-service/internal/observability/metrics"
+)
+
+// fakeWriter captures written messages instead of sending them to a broker.
+type fakeWriter struct {
+	mu       sync.Mutex
+	messages []kafka.Message
+	closed   bool
+	block    chan struct{} // If set, writes wait until it is closed
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	if w.block != nil {
+		<-w.block
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return nil
+}
+
+func TestParseCompression(t *testing.T) {
+	tests := []struct {
+		name string
+		want kafka.Compression
+		ok   bool
+	}{
+		{"", 0, true},
+		{"none", 0, true},
+		{"gzip", kafka.Gzip, true},
+		{"snappy", kafka.Snappy, true},
+		{"lz4", kafka.Lz4, true},
+		{"zstd", kafka.Zstd, true},
+		{"brotli", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseCompression(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseCompression(%q) = (%v, %v), want (%v, %v)", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNewBalancer(t *testing.T) {
+	if b, ok := newBalancer(PartitionByKey); !ok {
+		t.Error("by-key should be valid")
+	} else if _, isHash := b.(*kafka.Hash); !isHash {
+		t.Errorf("by-key balancer = %T, want *kafka.Hash", b)
+	}
+	if b, ok := newBalancer(""); !ok {
+		t.Error("empty strategy should be valid")
+	} else if _, isLB := b.(*kafka.LeastBytes); !isLB {
+		t.Errorf("default balancer = %T, want *kafka.LeastBytes", b)
+	}
+	if _, ok := newBalancer("round-robin"); ok {
+		t.Error("unknown strategy should be rejected")
+	}
+}
+
+func TestByKeyBalancer_StablePartitionPerKey(t *testing.T) {
+	balancer := mustBalancer(PartitionByKey)
+	partitions := []int{0, 1, 2, 3, 4, 5, 6, 7}
+
+	keys := []string{"int-123", "int-456", "call-abc-123"}
+	for _, key := range keys {
+		msg := kafka.Message{Key: []byte(key)}
+		want := balancer.Balance(msg, partitions...)
+		for i := 0; i < 50; i++ {
+			if got := balancer.Balance(msg, partitions...); got != want {
+				t.Fatalf("key %s: partition changed from %d to %d", key, want, got)
+			}
+		}
+
+		// A separate balancer instance (e.g. the other writer or another
+		// replica) must agree on the partition.
+		if got := mustBalancer(PartitionByKey).Balance(msg, partitions...); got != want {
+			t.Errorf("key %s: partition differs across balancers: %d vs %d", key, want, got)
+		}
+	}
+}
+
+// newTestPublisher returns an enabled publisher writing to fake writers.
+// Topic and principal default to the production values.
+func newTestPublisher(cfg *Config) (*Publisher, *fakeWriter, *fakeWriter) {
+	if cfg.TopicPartial == "" {
+		cfg.TopicPartial = "interaction.transcript.partial"
+	}
+	if cfg.TopicFinal == "" {
+		cfg.TopicFinal = "interaction.transcript.final"
+	}
+	if cfg.Principal == "" {
+		cfg.Principal = "svc-speech-ingress"
+	}
+	partial, final := &fakeWriter{}, &fakeWriter{}
+	return newWithWriters(cfg, partial, final, nil), partial, final
+}
+
+func headerMap(msg kafka.Message) map[string]string {
+	out := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		out[h.Key] = string(h.Value)
+	}
+	return out
+}
+
+func TestPublish_LineageHeaders(t *testing.T) {
+	p, _, w := newTestPublisher(&Config{
+		StaticHeaders: map[string]string{"env": "prod", "cluster": "eu-1"},
+	})
+
+	err := p.PublishFinal(context.Background(), "tenant-1", "int-1", models.TranscriptFinal{
+		InteractionID: "int-1",
+		TenantID:      "tenant-1",
+		SegmentID:     "int-1-seg-1",
+		Text:          "hello",
+	})
+	if err != nil {
+		t.Fatalf("PublishFinal: %v", err)
+	}
+	if len(w.messages) != 1 {
+		t.Fatalf("wrote %d messages, want 1", len(w.messages))
+	}
+
+	got := headerMap(w.messages[0])
+	want := map[string]string{
+		"eventType":         "interaction.transcript.final",
+		"principal":         "svc-speech-ingress",
+		"producerPrincipal": "svc-speech-ingress",
+		"segmentId":         "int-1-seg-1",
+		"tenantId":          "tenant-1",
+		"schemaVersion":     models.SchemaVersion,
+		"env":               "prod",
+		"cluster":           "eu-1",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("header %s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestPublishPartial_KeyAndPayload(t *testing.T) {
+	p, partial, final := newTestPublisher(&Config{})
+	ev := models.TranscriptPartial{
+		EventType:     "interaction.transcript.partial",
+		InteractionID: "int-1",
+		SegmentID:     "int-1-seg-1",
+		Text:          "hel",
+	}
+
+	if err := p.PublishPartial(context.Background(), "tenant-1", "int-1", ev); err != nil {
+		t.Fatalf("PublishPartial: %v", err)
+	}
+
+	if len(final.messages) != 0 {
+		t.Errorf("final writer received %d messages, want 0", len(final.messages))
+	}
+	if len(partial.messages) != 1 {
+		t.Fatalf("partial writer received %d messages, want 1", len(partial.messages))
+	}
+	msg := partial.messages[0]
+	if string(msg.Key) != "int-1" {
+		t.Errorf("key = %q, want int-1", msg.Key)
+	}
+	var got models.TranscriptPartial
+	if err := json.Unmarshal(msg.Value, &got); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if got != ev {
+		t.Errorf("payload = %+v, want %+v", got, ev)
+	}
+	if h := headerMap(msg); h["eventType"] != "interaction.transcript.partial" {
+		t.Errorf("eventType header = %q", h["eventType"])
+	}
+}
+
+func TestPublishSegmentEvent_FinalTopic(t *testing.T) {
+	p, partial, final := newTestPublisher(&Config{})
+	ev := models.SegmentClosed{
+		EventType:     "interaction.segment.closed",
+		InteractionID: "int-1",
+		TenantID:      "tenant-1",
+		SegmentID:     "int-1-seg-1",
+		AudioBytes:    3200,
+		PartialCount:  2,
+	}
+
+	if err := p.PublishSegmentEvent(context.Background(), "tenant-1", "int-1", ev); err != nil {
+		t.Fatalf("PublishSegmentEvent: %v", err)
+	}
+
+	if len(partial.messages) != 0 || len(final.messages) != 1 {
+		t.Fatalf("partial=%d final=%d messages, want 0/1", len(partial.messages), len(final.messages))
+	}
+	h := headerMap(final.messages[0])
+	if h["eventType"] != "interaction.segment.closed" || h["segmentId"] != "int-1-seg-1" {
+		t.Errorf("headers = %v", h)
+	}
+	var got models.SegmentClosed
+	if err := json.Unmarshal(final.messages[0].Value, &got); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if got != ev {
+		t.Errorf("payload = %+v, want %+v", got, ev)
+	}
+}
+
+func TestPublishPartial_Disabled(t *testing.T) {
+	p, partial, final := newTestPublisher(&Config{DisablePartials: true})
+
+	if err := p.PublishPartial(context.Background(), "tenant-1", "int-1", models.TranscriptPartial{Text: "hel"}); err != nil {
+		t.Fatalf("PublishPartial: %v", err)
+	}
+	if err := p.PublishFinal(context.Background(), "tenant-1", "int-1", models.TranscriptFinal{Text: "hello"}); err != nil {
+		t.Fatalf("PublishFinal: %v", err)
+	}
+	if len(partial.messages) != 0 || len(final.messages) != 1 {
+		t.Errorf("partial=%d final=%d messages, want 0/1", len(partial.messages), len(final.messages))
+	}
+}
+
+func TestPublishFinal_CloudEventsStructured(t *testing.T) {
+	p, _, final := newTestPublisher(&Config{EventFormat: EventFormatCloudEvents, EventSource: "/test"})
+	ev := models.TranscriptFinal{EventType: "interaction.transcript.final", InteractionID: "int-1", Text: "hello"}
+
+	if err := p.PublishFinal(context.Background(), "tenant-1", "int-1", ev); err != nil {
+		t.Fatalf("PublishFinal: %v", err)
+	}
+
+	msg := final.messages[0]
+	h := headerMap(msg)
+	if h["content-type"] != "application/cloudevents+json" || h["ce_type"] != ev.EventType || h["ce_source"] != "/test" {
+		t.Errorf("unexpected headers: %v", h)
+	}
+	var envelope struct {
+		Type string                 `json:"type"`
+		Data models.TranscriptFinal `json:"data"`
+	}
+	if err := json.Unmarshal(msg.Value, &envelope); err != nil {
+		t.Fatalf("payload is not a cloudevent: %v", err)
+	}
+	if envelope.Type != ev.EventType || envelope.Data.Text != "hello" {
+		t.Errorf("envelope = %+v", envelope)
+	}
+}
+
+func TestPublish_AvroPayload(t *testing.T) {
+	p, partial, _ := newTestPublisher(&Config{SerializationFormat: FormatAvro})
+	p.schemaIDs = map[string]int32{p.topicPartial: 42}
+
+	err := p.PublishPartial(context.Background(), "tenant-1", "int-1", models.TranscriptPartial{InteractionID: "int-1"})
+	if err != nil {
+		t.Fatalf("PublishPartial: %v", err)
+	}
+
+	value := partial.messages[0].Value
+	if len(value) < 5 || value[0] != 0 || binary.BigEndian.Uint32(value[1:5]) != 42 {
+		t.Errorf("payload does not start with the wire format prefix for schema 42: %x", value)
+	}
+}
+
+func TestPublish_AvroWithoutSchemaFails(t *testing.T) {
+	p, partial, _ := newTestPublisher(&Config{SerializationFormat: FormatAvro})
+
+	if err := p.PublishPartial(context.Background(), "tenant-1", "int-1", models.TranscriptPartial{}); err == nil {
+		t.Error("expected an error publishing avro without a registered schema")
+	}
+	if len(partial.messages) != 0 {
+		t.Errorf("wrote %d messages, want 0", len(partial.messages))
+	}
+}
+
+func TestClose_ClosesWriters(t *testing.T) {
+	p, partial, final := newTestPublisher(&Config{})
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !partial.closed || !final.closed {
+		t.Errorf("closed partial=%v final=%v, want both", partial.closed, final.closed)
+	}
+}
+
+func TestFlush_WaitsForInflightWrites(t *testing.T) {
+	p, partial, _ := newTestPublisher(&Config{})
+	partial.block = make(chan struct{})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.PublishPartial(context.Background(), "tenant-1", "int-1", models.TranscriptPartial{})
+	}()
+	for {
+		p.mu.Lock()
+		n := p.inflight
+		p.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Flush with a blocked write: err = %v, want deadline exceeded", err)
+	}
+
+	close(partial.block)
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("PublishPartial: %v", err)
+	}
+	if len(partial.messages) != 1 {
+		t.Errorf("wrote %d messages, want 1", len(partial.messages))
+	}
+}
+
+// waitForQueue waits until the workers have taken every queued message.
+func waitForQueue(p *Publisher) {
+	for {
+		p.mu.Lock()
+		n := 0
+		for _, w := range p.workers {
+			n += len(w.queue)
+		}
+		p.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPublish_Buffered(t *testing.T) {
+	p, partial, final := newTestPublisher(&Config{BufferSize: 2})
+	m := metrics.New(prometheus.NewRegistry())
+	p.SetMetrics(m)
+	defer p.Close()
+	partial.block = make(chan struct{})
+	ctx := context.Background()
+
+	// The worker takes the first partial and blocks writing it; publishing
+	// doesn't wait for it
+	p.PublishPartial(ctx, "tenant-1", "int-1", models.TranscriptPartial{Text: "a"})
+	waitForQueue(p)
+
+	// The buffer fills up, dropping the oldest partial for each new event,
+	// then the partial that finds only finals queued
+	p.PublishPartial(ctx, "tenant-1", "int-1", models.TranscriptPartial{Text: "b"})
+	p.PublishFinal(ctx, "tenant-1", "int-1", models.TranscriptFinal{Text: "f"})
+	p.PublishPartial(ctx, "tenant-1", "int-1", models.TranscriptPartial{Text: "c"})
+	p.PublishFinal(ctx, "tenant-1", "int-1", models.TranscriptFinal{Text: "g"})
+	p.PublishPartial(ctx, "tenant-1", "int-1", models.TranscriptPartial{Text: "d"})
+	if got := testutil.ToFloat64(m.EventsDropped.WithLabelValues("partial")); got != 3 {
+		t.Errorf("events_dropped_total = %v, want 3", got)
+	}
+
+	// Finals wait for room instead
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := p.PublishFinal(timeout, "tenant-1", "int-1", models.TranscriptFinal{Text: "x"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("PublishFinal on a full buffer: err = %v, want deadline exceeded", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- p.PublishFinal(ctx, "tenant-1", "int-1", models.TranscriptFinal{Text: "h"})
+	}()
+
+	close(partial.block)
+	if err := <-done; err != nil {
+		t.Fatalf("PublishFinal: %v", err)
+	}
+	if err := p.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	texts := func(w *fakeWriter) []string {
+		var out []string
+		for _, msg := range w.messages {
+			var ev struct{ Text string }
+			json.Unmarshal(msg.Value, &ev)
+			out = append(out, ev.Text)
+		}
+		return out
+	}
+	if got := texts(partial); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("partials = %v, want [a]", got)
+	}
+	if got := texts(final); !slices.Equal(got, []string{"f", "g", "h"}) {
+		t.Errorf("finals = %v, want [f g h]", got)
+	}
+}
+
+func TestPublish_BufferedKeepsInteractionOrder(t *testing.T) {
+	// Both event kinds go to one writer, so it records their relative order
+	w := &fakeWriter{}
+	p := newWithWriters(&Config{BufferSize: 16, Workers: 4}, w, w, nil)
+	defer p.Close()
+	ctx := context.Background()
+
+	const interactions, events = 8, 50
+	var wg sync.WaitGroup
+	for i := range interactions {
+		key := fmt.Sprintf("int-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seq := range events {
+				// Partials may be dropped under pressure, finals never are
+				if seq%2 == 0 {
+					p.PublishPartial(ctx, "tenant-1", key, models.TranscriptPartial{Text: strconv.Itoa(seq)})
+				} else {
+					p.PublishFinal(ctx, "tenant-1", key, models.TranscriptFinal{Text: strconv.Itoa(seq)})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := p.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	last := make(map[string]int)
+	finals := make(map[string]int)
+	for _, msg := range w.messages {
+		var ev struct{ Text string }
+		json.Unmarshal(msg.Value, &ev)
+		seq, _ := strconv.Atoi(ev.Text)
+		key := string(msg.Key)
+		if prev, ok := last[key]; ok && seq <= prev {
+			t.Fatalf("%s: event %d written after %d", key, seq, prev)
+		}
+		last[key] = seq
+		if seq%2 == 1 {
+			finals[key]++
+		}
+	}
+	for i := range interactions {
+		if key := fmt.Sprintf("int-%d", i); finals[key] != events/2 {
+			t.Errorf("%s: wrote %d finals, want %d", key, finals[key], events/2)
+		}
+	}
+}
+
+func TestPublish_BufferedWorkersIndependent(t *testing.T) {
+	blocked, open := &fakeWriter{block: make(chan struct{})}, &fakeWriter{}
+	p := newWithWriters(&Config{
+		BufferSize:   8,
+		Workers:      2,
+		TenantTopics: map[string]string{"tenant-a": "a.transcripts", "tenant-b": "b.transcripts"},
+	}, &fakeWriter{}, &fakeWriter{}, map[string]messageWriter{"a.transcripts": blocked, "b.transcripts": open})
+	defer p.Close()
+	ctx := context.Background()
+
+	// Find a key on the other worker
+	keyB := ""
+	for i := 0; keyB == ""; i++ {
+		if k := fmt.Sprintf("int-%d", i); p.worker(k) != p.worker("int-a") {
+			keyB = k
+		}
+	}
+
+	p.PublishFinal(ctx, "tenant-a", "int-a", models.TranscriptFinal{})
+	p.PublishFinal(ctx, "tenant-b", keyB, models.TranscriptFinal{})
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		open.mu.Lock()
+		n := len(open.messages)
+		open.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("an interaction on another worker waited for a blocked write")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(blocked.block)
+	if err := p.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+}
+
+func TestClose_StopsWorkers(t *testing.T) {
+	p, partial, _ := newTestPublisher(&Config{BufferSize: 10, Workers: 2})
+	p.PublishPartial(context.Background(), "tenant-1", "int-1", models.TranscriptPartial{})
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	// Close returns only once every worker has stopped
+	p.Close()
+
+	if len(partial.messages) != 1 || !partial.closed {
+		t.Errorf("wrote %d messages, closed=%v", len(partial.messages), partial.closed)
+	}
+}
+
+func TestFlush_NoopWhenDisabled(t *testing.T) {
+	p := New(&Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Flush(ctx); err != nil {
+		t.Errorf("Flush on disabled publisher: %v", err)
+	}
+}
+
+func TestPublish_TenantTopics(t *testing.T) {
+	cfg := &Config{
+		TopicPartial: "interaction.transcript.partial",
+		TopicFinal:   "interaction.transcript.final",
+		TenantTopics: map[string]string{"tenant-a": "tenant-a.transcripts"},
+	}
+	partial, final, dedicated := &fakeWriter{}, &fakeWriter{}, &fakeWriter{}
+	p := newWithWriters(cfg, partial, final, map[string]messageWriter{"tenant-a.transcripts": dedicated})
+	ctx := context.Background()
+
+	p.PublishPartial(ctx, "tenant-a", "int-1", models.TranscriptPartial{TenantID: "tenant-a"})
+	p.PublishFinal(ctx, "tenant-a", "int-1", models.TranscriptFinal{TenantID: "tenant-a"})
+	p.PublishPartial(ctx, "tenant-b", "int-2", models.TranscriptPartial{TenantID: "tenant-b"})
+	p.PublishFinal(ctx, "tenant-b", "int-2", models.TranscriptFinal{TenantID: "tenant-b"})
+
+	if len(dedicated.messages) != 2 {
+		t.Fatalf("dedicated topic got %d messages, want 2", len(dedicated.messages))
+	}
+	types := []string{headerMap(dedicated.messages[0])["eventType"], headerMap(dedicated.messages[1])["eventType"]}
+	if types[0] != cfg.TopicPartial || types[1] != cfg.TopicFinal {
+		t.Errorf("dedicated topic eventTypes = %v, want partial then final", types)
+	}
+	for name, w := range map[string]*fakeWriter{"partial": partial, "final": final} {
+		if len(w.messages) != 1 || string(w.messages[0].Key) != "int-2" {
+			t.Errorf("%s topic got %d messages, want only the unmapped tenant's", name, len(w.messages))
+		}
+	}
+
+	p.Close()
+	if !dedicated.closed {
+		t.Error("Close did not close the tenant writer")
+	}
+}
+
+func TestPublish_OversizedEventTruncated(t *testing.T) {
+	p, _, final := newTestPublisher(&Config{MaxMessageBytes: 512})
+	m := metrics.New(prometheus.NewRegistry())
+	p.SetMetrics(m)
+	long := strings.Repeat("é", 1000)
+	ev := models.TranscriptFinal{
+		EventType:    "interaction.transcript.final",
+		SegmentID:    "int-1-seg-1",
+		Text:         long,
+		Alternatives: []models.Alternative{{Text: long, Confidence: 0.9}},
+	}
+
+	if err := p.PublishFinal(context.Background(), "tenant-1", "int-1", ev); err != nil {
+		t.Fatalf("PublishFinal: %v", err)
+	}
+
+	msg := final.messages[0]
+	if len(msg.Value) > 512 {
+		t.Errorf("payload is %d bytes, want at most 512", len(msg.Value))
+	}
+	var got models.TranscriptFinal
+	if err := json.Unmarshal(msg.Value, &got); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if !utf8.ValidString(got.Text) || !strings.HasPrefix(long, got.Text) || got.Text == "" {
+		t.Errorf("text not truncated cleanly: %q", got.Text)
+	}
+	if len(got.Alternatives) != 0 {
+		t.Errorf("alternatives kept: %d", len(got.Alternatives))
+	}
+	if headerMap(msg)["truncated"] != "true" {
+		t.Error("missing truncated header")
+	}
+	if v := testutil.ToFloat64(m.KafkaOversizedMessages.WithLabelValues("truncated")); v != 1 {
+		t.Errorf("kafka_oversized_messages_total{action=truncated} = %v, want 1", v)
+	}
+}
+
+func TestPublish_OversizedEventRejected(t *testing.T) {
+	p, partial, _ := newTestPublisher(&Config{MaxMessageBytes: 16})
+	m := metrics.New(prometheus.NewRegistry())
+	p.SetMetrics(m)
+
+	err := p.PublishPartial(context.Background(), "tenant-1", "int-1", models.TranscriptPartial{Text: "hello"})
+	if !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("expected ErrMessageTooLarge, got %v", err)
+	}
+	if len(partial.messages) != 0 {
+		t.Errorf("wrote %d messages, want 0", len(partial.messages))
+	}
+	if v := testutil.ToFloat64(m.KafkaOversizedMessages.WithLabelValues("rejected")); v != 1 {
+		t.Errorf("kafka_oversized_messages_total{action=rejected} = %v, want 1", v)
+	}
+}
+
+func TestPublish_PartialLogSampling(t *testing.T) {
+	var buf bytes.Buffer
+	out := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(out) })
+
+	p, _, _ := newTestPublisher(&Config{PartialLogSampleRate: 5})
+	for i := 0; i < 10; i++ {
+		if err := p.PublishPartial(context.Background(), "tenant-1", "int-1", models.TranscriptPartial{Text: "hel"}); err != nil {
+			t.Fatalf("PublishPartial: %v", err)
+		}
+	}
+	if err := p.PublishFinal(context.Background(), "tenant-1", "int-1", models.TranscriptFinal{Text: "hello"}); err != nil {
+		t.Fatalf("PublishFinal: %v", err)
+	}
+
+	partials := strings.Count(buf.String(), "topic=interaction.transcript.partial")
+	finals := strings.Count(buf.String(), "topic=interaction.transcript.final")
+	if partials != 2 || finals != 1 {
+		t.Errorf("logged partials=%d finals=%d, want 2/1", partials, finals)
+	}
+}
@@ -0,0 +1,148 @@
+package events
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"slices"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// publishWorker writes the queued events of the keys hashed to it, one batch
+// at a time, in queue order.
+type publishWorker struct {
+	queue []queuedMessage // Guarded by Publisher.mu
+	room  chan struct{}   // Closed when the worker takes messages off the queue
+	wake  chan struct{}   // Signals the worker that messages were queued
+}
+
+// queuedMessage is a serialized event waiting for its worker.
+type queuedMessage struct {
+	writer  messageWriter
+	topic   string
+	msg     kafka.Message
+	partial bool
+}
+
+// startWorkers enables asynchronous publishing with bufferSize messages
+// split between n workers.
+func (p *Publisher) startWorkers(bufferSize, n int) {
+	n = max(n, 1)
+	p.bufferSize = max((bufferSize+n-1)/n, 1)
+	p.stop = make(chan struct{})
+	p.workers = make([]*publishWorker, n)
+	for i := range p.workers {
+		w := &publishWorker{room: make(chan struct{}), wake: make(chan struct{}, 1)}
+		p.workers[i] = w
+		p.workersDone.Add(1)
+		go p.runWorker(w)
+	}
+	log.Printf("[PUBLISHER] Publishing asynchronously: workers=%d bufferSize=%d", n, bufferSize)
+}
+
+// worker returns the worker for a key, the same one for every event of an
+// interaction.
+func (p *Publisher) worker(key string) *publishWorker {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return p.workers[h.Sum32()%uint32(len(p.workers))]
+}
+
+// enqueue queues a message on its key's worker. When the worker's buffer is
+// full the oldest queued partial is dropped to make room. A partial that
+// finds the buffer full of finals is dropped itself, while finals and segment
+// events wait for room until ctx is done.
+func (p *Publisher) enqueue(ctx context.Context, key string, m queuedMessage) error {
+	w := p.worker(key)
+
+	p.mu.Lock()
+	// Counted before waiting so Flush waits for it too
+	p.beginWriteLocked(1)
+	for len(w.queue) >= p.bufferSize {
+		if i := slices.IndexFunc(w.queue, func(q queuedMessage) bool { return q.partial }); i >= 0 {
+			w.queue = slices.Delete(w.queue, i, i+1)
+			p.endWriteLocked(1)
+			p.metrics.EventsDropped.WithLabelValues("partial").Inc()
+			continue
+		}
+		if m.partial {
+			p.endWriteLocked(1)
+			p.mu.Unlock()
+			p.metrics.EventsDropped.WithLabelValues("partial").Inc()
+			return nil
+		}
+
+		room := w.room
+		p.mu.Unlock()
+		select {
+		case <-room:
+		case <-ctx.Done():
+			p.mu.Lock()
+			p.endWriteLocked(1)
+			p.mu.Unlock()
+			log.Printf("[PUBLISHER] Buffer full, gave up queueing event for topic=%s: %v", m.topic, ctx.Err())
+			return ctx.Err()
+		}
+		p.mu.Lock()
+	}
+	w.queue = append(w.queue, m)
+	p.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// runWorker writes the worker's queued messages until Close.
+func (p *Publisher) runWorker(w *publishWorker) {
+	defer p.workersDone.Done()
+	for {
+		select {
+		case <-w.wake:
+			p.writeQueued(w)
+		case <-p.stop:
+			p.mu.Lock()
+			left := len(w.queue)
+			p.mu.Unlock()
+			if left > 0 {
+				log.Printf("[PUBLISHER] Closed with %d unflushed events", left)
+			}
+			return
+		}
+	}
+}
+
+// writeQueued takes every message off the worker's queue and writes them in
+// order, batching consecutive messages for the same writer.
+func (p *Publisher) writeQueued(w *publishWorker) {
+	p.mu.Lock()
+	batch := w.queue
+	w.queue = nil
+	close(w.room)
+	w.room = make(chan struct{})
+	p.mu.Unlock()
+
+	for start := 0; start < len(batch); {
+		end := start + 1
+		for end < len(batch) && batch[end].writer == batch[start].writer {
+			end++
+		}
+		msgs := make([]kafka.Message, 0, end-start)
+		for _, m := range batch[start:end] {
+			msgs = append(msgs, m.msg)
+		}
+		if err := batch[start].writer.WriteMessages(context.Background(), msgs...); err != nil {
+			log.Printf("[PUBLISHER] Failed to write %d messages to Kafka topic=%s: %v", len(msgs), batch[start].topic, err)
+		}
+		start = end
+	}
+
+	if len(batch) > 0 {
+		p.mu.Lock()
+		p.endWriteLocked(len(batch))
+		p.mu.Unlock()
+	}
+}
@@ -1,9 +1,14 @@
 // Package models defines the data structures for transcript events.
 package models
 
+// SchemaVersion is the version of the event shapes below, sent in every
+// event's schemaVersion field and header. Bump it when their fields change.
+const SchemaVersion = "1.1"
+
 // TranscriptPartial represents an interim/partial transcript result.
 type TranscriptPartial struct {
 	EventType     string `json:"eventType"`
+	SchemaVersion string `json:"schemaVersion"`
 	InteractionID string `json:"interactionId"`
 	TenantID      string `json:"tenantId"`
 	Timestamp     int64  `json:"timestamp"`
@@ -14,6 +19,7 @@ type TranscriptPartial struct {
 // TranscriptFinal represents a final transcript result with confidence score.
 type TranscriptFinal struct {
 	EventType     string  `json:"eventType"`
+	SchemaVersion string  `json:"schemaVersion"`
 	InteractionID string  `json:"interactionId"`
 	TenantID      string  `json:"tenantId"`
 	Timestamp     int64   `json:"timestamp"`
@@ -21,4 +27,28 @@ type TranscriptFinal struct {
 	Text          string  `json:"text"`
 	Confidence    float64 `json:"confidence"`
 	AudioOffsetMs int64   `json:"audioOffsetMs"`
+
+	// Alternatives holds the N-best hypotheses, best first. Text and
+	// Confidence always reflect the first one.
+	Alternatives []Alternative `json:"alternatives,omitempty"`
+}
+
+// SegmentClosed marks the normal end of a segment. It is not published for
+// dropped segments.
+type SegmentClosed struct {
+	EventType     string `json:"eventType"`
+	SchemaVersion string `json:"schemaVersion"`
+	InteractionID string `json:"interactionId"`
+	TenantID      string `json:"tenantId"`
+	Timestamp     int64  `json:"timestamp"`
+	SegmentID     string `json:"segmentId"`
+	AudioBytes    int64  `json:"audioBytes"`
+	PartialCount  int    `json:"partialCount"`
+	DurationMs    int64  `json:"durationMs"`
+}
+
+// Alternative is one recognition hypothesis of a final transcript.
+type Alternative struct {
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidence"`
 }
@@ -0,0 +1,196 @@
+// Package metrics defines the Prometheus metrics exported by the service.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// otherTenant is the tenant label used for tenants outside the allowlist.
+const otherTenant = "other"
+
+// Metrics holds the service's Prometheus collectors.
+// Use Default in production code; tests can create an isolated instance with
+// New(prometheus.NewRegistry()).
+type Metrics struct {
+	StreamsRateLimited *prometheus.CounterVec
+	SegmentsCompleted  prometheus.Counter
+	SegmentsDropped    *prometheus.CounterVec
+	InteractionsCapped prometheus.Counter
+	STTClientPoolSize  prometheus.Gauge
+	FramesRejected     *prometheus.CounterVec
+	FramesOversized    prometheus.Counter
+	PartialsCoalesced  prometheus.Counter
+	AudioFrameGaps     prometheus.Counter
+	FormatMismatches   *prometheus.CounterVec
+	FinalConfidence    prometheus.Histogram
+	FinalsLowQuality   prometheus.Counter
+	LatePartials       *prometheus.CounterVec
+	FinalHolds         *prometheus.CounterVec
+
+	ConfidenceFloorFinals *prometheus.CounterVec
+	SilenceFramesSkipped  prometheus.Counter
+
+	AudioBytesPerSecond prometheus.Gauge
+	AudioRateAnomalies  *prometheus.CounterVec
+
+	KafkaOversizedMessages *prometheus.CounterVec
+	EventsDropped          *prometheus.CounterVec
+	AuditWriteFailures     prometheus.Counter
+
+	STTAudioDroppedDuringRestart prometheus.Counter
+	STTRestartFailures           prometheus.Counter
+	STTFinalsWithoutAlternatives prometheus.Counter
+
+	STTProbeSuccess prometheus.Gauge
+	STTProbeLatency prometheus.Histogram
+
+	mu              sync.RWMutex
+	tenantAllowlist map[string]struct{}
+}
+
+// Default is the Metrics instance registered with the default Prometheus registry.
+var Default = New(prometheus.DefaultRegisterer)
+
+// New creates and registers the service metrics with the given registerer.
+func New(reg prometheus.Registerer) *Metrics {
+	f := promauto.With(reg)
+	return &Metrics{
+		StreamsRateLimited: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "streams_rate_limited_total",
+			Help: "Stream creation attempts rejected by the per-tenant rate limiter.",
+		}, []string{"tenant"}),
+		SegmentsCompleted: f.NewCounter(prometheus.CounterOpts{
+			Name: "segments_completed_total",
+			Help: "Segments that published a final transcript.",
+		}),
+		SegmentsDropped: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "segments_dropped_total",
+			Help: "Segments abandoned without publishing a final transcript, by reason.",
+		}, []string{"reason"}),
+		InteractionsCapped: f.NewCounter(prometheus.CounterOpts{
+			Name: "interactions_capped_total",
+			Help: "Streams closed for exceeding the maximum interaction duration.",
+		}),
+		STTClientPoolSize: f.NewGauge(prometheus.GaugeOpts{
+			Name: "stt_client_pool_size",
+			Help: "Live pooled Google Speech clients.",
+		}),
+		FramesRejected: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "frames_rejected_total",
+			Help: "Malformed audio frames rejected before reaching the STT provider, by reason.",
+		}, []string{"reason"}),
+		FramesOversized: f.NewCounter(prometheus.CounterOpts{
+			Name: "frames_oversized_total",
+			Help: "Audio frames rejected for exceeding the maximum frame size (also counted in frames_rejected_total).",
+		}),
+		PartialsCoalesced: f.NewCounter(prometheus.CounterOpts{
+			Name: "partials_coalesced_total",
+			Help: "Partials superseded within the debounce window and never published.",
+		}),
+		AudioFrameGaps: f.NewCounter(prometheus.CounterOpts{
+			Name: "audio_frame_gaps_total",
+			Help: "Audio frames received with an unexpected sequence number (lost, duplicated or reordered).",
+		}),
+		SilenceFramesSkipped: f.NewCounter(prometheus.CounterOpts{
+			Name: "audio_silence_frames_skipped_total",
+			Help: "Silent audio frames held back from the STT provider at the start of a segment; the last one is forwarded ahead of speech.",
+		}),
+		AudioBytesPerSecond: f.NewGauge(prometheus.GaugeOpts{
+			Name: "audio_bytes_per_second",
+			Help: "Audio bytes per second received across all active streams, each measured over its last 5s window.",
+		}),
+		AudioRateAnomalies: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "audio_rate_anomalies_total",
+			Help: "5s windows in which a stream's audio arrived more than 25% slower or faster than realtime, by direction (slow or fast).",
+		}, []string{"direction"}),
+		FormatMismatches: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "audio_format_mismatches_total",
+			Help: "Streams whose declared audio format differed from the STT config, by outcome (reconfigured, rejected).",
+		}, []string{"outcome"}),
+		FinalConfidence: f.NewHistogram(prometheus.HistogramOpts{
+			Name:    "stt_final_confidence",
+			Help:    "Confidence of published final transcripts (0 when the provider reports none).",
+			Buckets: prometheus.LinearBuckets(0.1, 0.1, 10),
+		}),
+		FinalsLowQuality: f.NewCounter(prometheus.CounterOpts{
+			Name: "stt_finals_low_confidence_total",
+			Help: "Published final transcripts with confidence below the configured quality threshold.",
+		}),
+		LatePartials: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "late_partials_total",
+			Help: "Partials received after their segment's final, by outcome (ignored within the grace window, or anomaly).",
+		}, []string{"outcome"}),
+		FinalHolds: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "final_holds_total",
+			Help: "Finals held for the confirmation window, by outcome (published, or continued when the speaker kept going).",
+		}, []string{"outcome"}),
+		ConfidenceFloorFinals: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "finals_below_confidence_floor_total",
+			Help: "Finals below the confidence floor, by outcome (deferred as a partial, or accepted once retries ran out or the segment ended).",
+		}, []string{"outcome"}),
+		AuditWriteFailures: f.NewCounter(prometheus.CounterOpts{
+			Name: "audit_write_failures_total",
+			Help: "Audit records (segment drops, STT errors) that could not be written to the audit sink.",
+		}),
+		KafkaOversizedMessages: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "kafka_oversized_messages_total",
+			Help: "Events larger than the maximum message size, by action (truncated or rejected).",
+		}, []string{"action"}),
+		EventsDropped: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "events_dropped_total",
+			Help: "Events dropped because the outbound publish buffer was full, by type.",
+		}, []string{"type"}),
+		STTAudioDroppedDuringRestart: f.NewCounter(prometheus.CounterOpts{
+			Name: "stt_audio_dropped_during_restart_total",
+			Help: "Audio frames dropped because no STT stream was open, e.g. while it was being restarted.",
+		}),
+		STTRestartFailures: f.NewCounter(prometheus.CounterOpts{
+			Name: "stt_restart_failures_total",
+			Help: "Failed attempts to open or reopen an STT recognition stream, including ones that were retried.",
+		}),
+		STTFinalsWithoutAlternatives: f.NewCounter(prometheus.CounterOpts{
+			Name: "stt_final_without_alternatives_total",
+			Help: "Final results the STT provider returned without any alternative, emitted as empty finals.",
+		}),
+		STTProbeSuccess: f.NewGauge(prometheus.GaugeOpts{
+			Name: "stt_synthetic_probe_success",
+			Help: "1 if the last synthetic STT probe got a final back, 0 otherwise.",
+		}),
+		STTProbeLatency: f.NewHistogram(prometheus.HistogramOpts{
+			Name:    "stt_synthetic_probe_latency_seconds",
+			Help:    "Time from the start of a successful synthetic STT probe to its final.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+}
+
+// SetTenantAllowlist restricts the tenant label to the given tenants; all
+// others are reported as "other" to bound label cardinality. An empty list
+// reports every tenant as-is.
+func (m *Metrics) SetTenantAllowlist(tenants []string) {
+	allow := make(map[string]struct{}, len(tenants))
+	for _, t := range tenants {
+		if t != "" {
+			allow[t] = struct{}{}
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tenantAllowlist = allow
+}
+
+// TenantLabel returns the label value to use for a tenant.
+func (m *Metrics) TenantLabel(tenantId string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.tenantAllowlist) == 0 {
+		return tenantId
+	}
+	if _, ok := m.tenantAllowlist[tenantId]; ok {
+		return tenantId
+	}
+	return otherTenant
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestTenantLabel(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	if got := m.TenantLabel("tenant-a"); got != "tenant-a" {
+		t.Errorf("no allowlist: got %q, want tenant-a", got)
+	}
+
+	m.SetTenantAllowlist([]string{"tenant-a"})
+	if got := m.TenantLabel("tenant-a"); got != "tenant-a" {
+		t.Errorf("allowlisted: got %q, want tenant-a", got)
+	}
+	if got := m.TenantLabel("tenant-b"); got != "other" {
+		t.Errorf("not allowlisted: got %q, want other", got)
+	}
+}
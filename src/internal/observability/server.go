@@ -0,0 +1,50 @@
+// Package observability provides the HTTP server for operational endpoints
+// (debug, metrics) that runs alongside the gRPC server.
+package observability
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Server is the HTTP server for operational endpoints.
+type Server struct {
+	mux    *http.ServeMux
+	server *http.Server
+}
+
+// NewServer creates an observability server listening on the given port.
+func NewServer(port string) *Server {
+	mux := http.NewServeMux()
+	return &Server{
+		mux: mux,
+		server: &http.Server{
+			Addr:              ":" + port,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+// Handle registers a handler for the given pattern.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start serves HTTP in a background goroutine.
+func (s *Server) Start() {
+	go func() {
+		log.Printf("Observability server started on %s", s.server.Addr)
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("observability server failed: %v", err)
+		}
+	}()
+}
+
+// Shutdown gracefully stops the server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
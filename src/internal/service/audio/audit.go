@@ -0,0 +1,44 @@
+package audio
+
+import (
+	"context"
+	"log"
+
+	"ai-speech-ingress-service/internal/audit"
+	"ai-speech-ingress-service/internal/service/segment"
+)
+
+// writeAudit records an event of the current segment with the audit logger,
+// if one is configured. Failures are logged and counted but don't affect the
+// stream.
+func (h *Handler) writeAudit(event, segmentId, reason string, state segment.State) {
+	if h.config.Audit == nil {
+		return
+	}
+
+	m := h.GetSegmentMetrics()
+	h.mu.RLock()
+	startedAt := h.segmentStartedAt
+	mt := h.metrics
+	h.mu.RUnlock()
+
+	rec := audit.Record{
+		Event:            event,
+		Timestamp:        h.config.Clock.Now().UnixMilli(),
+		InteractionID:    h.interactionId,
+		TenantID:         h.tenantId,
+		SegmentID:        segmentId,
+		Reason:           reason,
+		State:            state.String(),
+		SegmentStartedAt: startedAt.UnixMilli(),
+		AudioBytes:       m.AudioBytes,
+		AudioDurationMs:  m.AudioDurationMs,
+		PartialCount:     m.PartialCount,
+		DurationMs:       m.Duration.Milliseconds(),
+	}
+	if err := h.config.Audit.Log(context.Background(), rec); err != nil {
+		mt.AuditWriteFailures.Inc()
+		log.Printf("Failed to write audit record: event=%s interactionId=%s segmentId=%s err=%v",
+			event, h.interactionId, segmentId, err)
+	}
+}
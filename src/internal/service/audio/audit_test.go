@@ -0,0 +1,85 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"ai-speech-ingress-service/internal/audit"
+)
+
+// fakeAuditLogger captures audit records, failing every write if err is set.
+type fakeAuditLogger struct {
+	mu      sync.Mutex
+	records []audit.Record
+	err     error
+}
+
+func (l *fakeAuditLogger) Log(_ context.Context, rec audit.Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	l.records = append(l.records, rec)
+	return nil
+}
+
+func (l *fakeAuditLogger) Close() error { return nil }
+
+func TestHandler_AuditsDropsAndErrors(t *testing.T) {
+	clock := NewManualClock(time.UnixMilli(1700000000000))
+	logger := &fakeAuditLogger{}
+	cfg := DefaultConfig()
+	cfg.Audit = logger
+	cfg.Clock = clock
+	h, _, _ := newTestHandler(t, cfg)
+
+	h.OnPartial("hello")
+	clock.Advance(1500 * time.Millisecond)
+	h.DropSegment(DropClientCancel)
+	h.DropSegment(DropClientCancel) // Already dropped: not audited again
+	h.OnError(errors.New("stream reset"))
+
+	if len(logger.records) != 2 {
+		t.Fatalf("audited %d records, want 2", len(logger.records))
+	}
+	drop := logger.records[0]
+	want := audit.Record{
+		Event:            audit.EventSegmentDropped,
+		Timestamp:        1700000001500,
+		InteractionID:    "int-1",
+		TenantID:         "tenant-1",
+		SegmentID:        "int-1-seg-1",
+		Reason:           "client_cancel",
+		State:            "OPEN",
+		SegmentStartedAt: 1700000000000,
+		PartialCount:     1,
+		DurationMs:       1500,
+	}
+	if drop != want {
+		t.Errorf("drop record = %+v, want %+v", drop, want)
+	}
+	if e := logger.records[1]; e.Event != audit.EventSTTError || e.Reason != "stream reset" || e.State != "DROPPED" {
+		t.Errorf("error record = %+v", e)
+	}
+}
+
+func TestHandler_AuditFailureDoesNotBlockDrop(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Audit = &fakeAuditLogger{err: errors.New("disk full")}
+	h, _, m := newTestHandler(t, cfg)
+
+	h.DropSegment(DropEmptyFinal)
+
+	if !h.IsSegmentDropped() {
+		t.Error("segment not dropped after a failed audit write")
+	}
+	if got := testutil.ToFloat64(m.AuditWriteFailures); got != 1 {
+		t.Errorf("audit_write_failures_total = %v, want 1", got)
+	}
+}
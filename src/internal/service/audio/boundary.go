@@ -0,0 +1,109 @@
+package audio
+
+import (
+	"log"
+	"time"
+)
+
+// BoundaryEvent is a point in the stream at which a segment may end.
+type BoundaryEvent int
+
+const (
+	// BoundaryUtteranceEnd is the provider detecting the end of an utterance.
+	BoundaryUtteranceEnd BoundaryEvent = iota
+	// BoundaryFinal is a final transcript (or an empty final that dropped the
+	// segment).
+	BoundaryFinal
+)
+
+// BoundaryPolicy decides when the handler ends the current segment and opens
+// the next one.
+type BoundaryPolicy interface {
+	// Name is the policy's SEGMENT_BOUNDARY_POLICY value.
+	Name() string
+	// EndsSegment reports whether the event closes the current segment.
+	EndsSegment(ev BoundaryEvent) bool
+	// Interval is how often the segment is closed regardless of events;
+	// zero means segments are not time-bound.
+	Interval() time.Duration
+}
+
+// Boundary policy names.
+const (
+	BoundarySingleUtterance = "single-utterance"
+	BoundaryContinuous      = "continuous"
+	BoundaryFixedInterval   = "fixed-interval"
+)
+
+// SingleUtteranceBoundary ends a segment when the provider detects the end of
+// an utterance, so each segment is one utterance with at most one final.
+type SingleUtteranceBoundary struct{}
+
+func (SingleUtteranceBoundary) Name() string { return BoundarySingleUtterance }
+
+func (SingleUtteranceBoundary) EndsSegment(ev BoundaryEvent) bool {
+	return ev == BoundaryUtteranceEnd
+}
+
+func (SingleUtteranceBoundary) Interval() time.Duration { return 0 }
+
+// ContinuousBoundary ends a segment at each final, for providers that stream
+// several finals without utterance events. Utterance events are ignored so
+// they don't open empty segments after a final.
+type ContinuousBoundary struct{}
+
+func (ContinuousBoundary) Name() string { return BoundaryContinuous }
+
+func (ContinuousBoundary) EndsSegment(ev BoundaryEvent) bool {
+	return ev == BoundaryFinal
+}
+
+func (ContinuousBoundary) Interval() time.Duration { return 0 }
+
+// FixedIntervalBoundary closes each segment after Every, finalizing it from its
+// latest partial, for a steady caption cadence. Utterance ends still close the
+// segment early, and the next one gets a full interval.
+type FixedIntervalBoundary struct {
+	Every time.Duration
+}
+
+func (FixedIntervalBoundary) Name() string { return BoundaryFixedInterval }
+
+func (FixedIntervalBoundary) EndsSegment(ev BoundaryEvent) bool {
+	return ev == BoundaryUtteranceEnd
+}
+
+func (b FixedIntervalBoundary) Interval() time.Duration { return b.Every }
+
+// armInterval starts the interval timer for segmentId, replacing any previous
+// one. Callers must hold h.segmentMu.
+func (h *Handler) armInterval(segmentId string) {
+	if h.intervalTimer != nil {
+		h.intervalTimer.Stop()
+		h.intervalTimer = nil
+	}
+	if h.config.Boundary == nil || h.closed {
+		return
+	}
+	if every := h.config.Boundary.Interval(); every > 0 {
+		h.intervalTimer = time.AfterFunc(every, func() { h.onInterval(segmentId) })
+	}
+}
+
+// onInterval finalizes segmentId from its latest partial and opens the next
+// segment. It does nothing if a natural boundary already closed the segment.
+func (h *Handler) onInterval(segmentId string) {
+	if h.lifecycle.SegmentId() != segmentId {
+		return
+	}
+
+	h.flushPartial()
+	finalized := h.FinalizeFromPartial(0)
+
+	newSegmentId, ok := h.nextSegmentFrom(segmentId)
+	if !ok {
+		return
+	}
+	log.Printf("Segment interval elapsed: interactionId=%s oldSegment=%s newSegment=%s finalized=%v",
+		h.interactionId, segmentId, newSegmentId, finalized)
+}
@@ -0,0 +1,40 @@
+package audio
+
+// DropReason is why a segment was dropped without a final. The set is fixed
+// so the segments_dropped_total reason label stays bounded. The zero value
+// means the segment wasn't dropped.
+type DropReason int
+
+const (
+	// DropEmptyFinal is a final with no text, under DropEmptyFinals.
+	DropEmptyFinal DropReason = iota + 1
+	// DropInvalidFrame is a malformed frame under RejectDropSegment.
+	DropInvalidFrame
+	// DropSTTSendTimeout is the adapter not accepting audio within
+	// SendTimeout.
+	DropSTTSendTimeout
+	// DropClientDisconnect is the client going away mid-segment.
+	DropClientDisconnect
+	// DropClientCancel is the client cancelling the segment.
+	DropClientCancel
+)
+
+var dropReasonNames = map[DropReason]string{
+	DropEmptyFinal:       "empty_final",
+	DropInvalidFrame:     "invalid_frame",
+	DropSTTSendTimeout:   "stt_send_timeout",
+	DropClientDisconnect: "client_disconnect",
+	DropClientCancel:     "client_cancel",
+}
+
+// String returns the reason's label, as used in metrics, logs, audit records
+// and the stream ack, or "" for the zero value.
+func (r DropReason) String() string {
+	if r == 0 {
+		return ""
+	}
+	if name, ok := dropReasonNames[r]; ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,85 @@
+package audio
+
+import (
+	"log"
+	"strings"
+
+	"ai-speech-ingress-service/internal/service/stt"
+)
+
+// deferLowConfidence handles a final below ConfidenceFloor: while the
+// segment has deferrals left, the final is re-emitted as a partial and kept
+// aside, and the segment stays open for a better final. Returns whether the
+// final was deferred.
+func (h *Handler) deferLowConfidence(alternatives []stt.Alternative) bool {
+	if h.config.ConfidenceFloor <= 0 || len(alternatives) == 0 {
+		return false
+	}
+	best := alternatives[0]
+	if best.Confidence >= h.config.ConfidenceFloor || strings.TrimSpace(best.Text) == "" {
+		h.discardDeferredFinal()
+		return false
+	}
+
+	segmentId := h.lifecycle.SegmentId()
+	h.deferMu.Lock()
+	deferred := h.finalDeferrals < h.config.ConfidenceFloorRetries
+	if deferred {
+		h.finalDeferrals++
+		h.deferredFinal = alternatives
+		h.deferredSegmentId = segmentId
+	} else {
+		h.deferredFinal = nil
+	}
+	attempt := h.finalDeferrals
+	h.deferMu.Unlock()
+
+	h.mu.RLock()
+	mt := h.metrics
+	h.mu.RUnlock()
+	if !deferred {
+		mt.ConfidenceFloorFinals.WithLabelValues("accepted").Inc()
+		return false
+	}
+
+	mt.ConfidenceFloorFinals.WithLabelValues("deferred").Inc()
+	log.Printf("Low-confidence final deferred: interactionId=%s segmentId=%s confidence=%.2f floor=%.2f attempt=%d/%d",
+		h.interactionId, segmentId, best.Confidence, h.config.ConfidenceFloor, attempt, h.config.ConfidenceFloorRetries)
+	h.OnPartial(best.Text)
+	return true
+}
+
+// acceptDeferredFinal publishes the deferred low-confidence final, if any,
+// when its segment ends without a better one. Returns whether there was one.
+func (h *Handler) acceptDeferredFinal() bool {
+	h.deferMu.Lock()
+	alternatives, segmentId := h.deferredFinal, h.deferredSegmentId
+	h.deferredFinal = nil
+	h.deferMu.Unlock()
+	if alternatives == nil || segmentId != h.lifecycle.SegmentId() {
+		return false
+	}
+
+	h.mu.RLock()
+	mt := h.metrics
+	h.mu.RUnlock()
+	mt.ConfidenceFloorFinals.WithLabelValues("accepted").Inc()
+	h.emitFinal(segmentId, alternatives)
+	return true
+}
+
+// discardDeferredFinal forgets the deferred final, e.g. when a better final
+// arrived or the segment was dropped.
+func (h *Handler) discardDeferredFinal() {
+	h.deferMu.Lock()
+	defer h.deferMu.Unlock()
+	h.deferredFinal = nil
+}
+
+// resetDeferralsLocked clears the deferral state for a new segment. Callers
+// must hold h.deferMu.
+func (h *Handler) resetDeferralsLocked() {
+	h.deferredFinal = nil
+	h.deferredSegmentId = ""
+	h.finalDeferrals = 0
+}
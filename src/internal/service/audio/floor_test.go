@@ -0,0 +1,85 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"ai-speech-ingress-service/internal/service/segment"
+)
+
+func floorConfig(retries int) Config {
+	cfg := DefaultConfig()
+	cfg.ConfidenceFloor = 0.6
+	cfg.ConfidenceFloorRetries = retries
+	return cfg
+}
+
+func TestHandler_ConfidenceFloor_WaitsForBetterFinal(t *testing.T) {
+	h, pub, m := newTestHandler(t, floorConfig(2))
+
+	h.OnFinal("recognize speech", 0.3)
+	if h.GetSegmentState() != segment.StateOpen {
+		t.Fatalf("state = %s after a low-confidence final, want OPEN", h.GetSegmentState())
+	}
+	partials, finals := pub.counts()
+	if partials != 1 || finals != 0 || pub.partials[0].Text != "recognize speech" {
+		t.Fatalf("published %d partials / %d finals, want the final re-emitted as a partial", partials, finals)
+	}
+
+	h.OnFinal("wreck a nice beach", 0.9)
+	if _, finals := pub.counts(); finals != 1 || pub.finals[0].Text != "wreck a nice beach" {
+		t.Errorf("finals = %v, want the confident final", pub.finals)
+	}
+	if got := testutil.ToFloat64(m.ConfidenceFloorFinals.WithLabelValues("deferred")); got != 1 {
+		t.Errorf("finals_below_confidence_floor_total{deferred} = %v, want 1", got)
+	}
+
+	// Deferrals are counted per segment
+	h.OnEndOfUtterance()
+	h.OnFinal("hello", 0.2)
+	if _, finals := pub.counts(); finals != 1 {
+		t.Errorf("published %d finals, want the next segment's low final deferred", finals)
+	}
+}
+
+func TestHandler_ConfidenceFloor_AcceptsAfterRetries(t *testing.T) {
+	h, pub, m := newTestHandler(t, floorConfig(1))
+
+	h.OnFinal("hello", 0.3)
+	h.OnFinal("hello there", 0.4)
+
+	if _, finals := pub.counts(); finals != 1 || pub.finals[0].Text != "hello there" || pub.finals[0].Confidence != 0.4 {
+		t.Fatalf("finals = %v, want the second low final accepted", pub.finals)
+	}
+	if got := testutil.ToFloat64(m.ConfidenceFloorFinals.WithLabelValues("accepted")); got != 1 {
+		t.Errorf("finals_below_confidence_floor_total{accepted} = %v, want 1", got)
+	}
+}
+
+func TestHandler_ConfidenceFloor_PublishesDeferredAtUtteranceEnd(t *testing.T) {
+	h, pub, _ := newTestHandler(t, floorConfig(3))
+	oldSegmentId := h.GetSegmentId()
+
+	h.OnFinal("hello", 0.3)
+	h.OnEndOfUtterance()
+
+	if _, finals := pub.counts(); finals != 1 {
+		t.Fatalf("published %d finals, want the deferred final", finals)
+	}
+	if f := pub.finals[0]; f.Text != "hello" || f.Confidence != 0.3 || f.SegmentID != oldSegmentId {
+		t.Errorf("final = %+v, want the deferred low-confidence final on %s", f, oldSegmentId)
+	}
+}
+
+func TestHandler_ConfidenceFloor_DropDiscardsDeferred(t *testing.T) {
+	h, pub, _ := newTestHandler(t, floorConfig(1))
+
+	h.OnFinal("hello", 0.3)
+	h.CancelSegment(DropClientCancel)
+	h.Close()
+
+	if _, finals := pub.counts(); finals != 0 {
+		t.Errorf("published %d finals, want the deferred final dropped with its segment", finals)
+	}
+}
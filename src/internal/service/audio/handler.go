@@ -4,26 +4,194 @@ package audio
 
 import (
 	"context"
+	"errors"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
-	"ai-speech-ingress-service/internal/events"
+	"ai-speech-ingress-service/internal/audit"
 	"ai-speech-ingress-service/internal/models"
+	"ai-speech-ingress-service/internal/observability/metrics"
 	"ai-speech-ingress-service/internal/service/segment"
 	"ai-speech-ingress-service/internal/service/stt"
+	"ai-speech-ingress-service/internal/service/transform"
 )
 
+// Publisher publishes transcript events. *events.Publisher satisfies it.
+type Publisher interface {
+	PublishPartial(ctx context.Context, tenantId, key string, event any) error
+	PublishFinal(ctx context.Context, tenantId, key string, event any) error
+	PublishSegmentEvent(ctx context.Context, tenantId, key string, event any) error
+}
+
+// ErrSendTimeout is returned by SendAudio when the STT adapter does not accept
+// a frame within Config.SendTimeout.
+var ErrSendTimeout = errors.New("stt send timed out")
+
+// Config holds handler behavior settings.
+type Config struct {
+	// DropEmptyFinals drops the segment (reason "empty_final") instead of
+	// publishing a final whose text is empty or whitespace-only.
+	DropEmptyFinals bool
+	// LowConfidenceThreshold counts published finals with a lower confidence
+	// in stt_finals_low_confidence_total. Zero disables the counter.
+	LowConfidenceThreshold float64
+	// ConfidenceFloor re-emits finals with a lower confidence as partials and
+	// keeps the segment open for a better final, up to ConfidenceFloorRetries
+	// times per segment. A deferred final is published if the segment ends
+	// without a better one. Zero disables the floor.
+	ConfidenceFloor        float64
+	ConfidenceFloorRetries int
+
+	// Normalizer rewrites final text (e.g. inverse text normalization) before
+	// Transforms run. Nil disables normalization.
+	Normalizer transform.TextTransform
+
+	// Transforms run on final text before publishing (e.g. PII redaction).
+	Transforms transform.Chain
+	// TransformPartials also runs partial text through Transforms.
+	TransformPartials bool
+
+	// PublishPartials publishes partials to Kafka. When false, partials are
+	// still counted and delivered to transcript callbacks.
+	PublishPartials bool
+	// PartialDebounce coalesces partials, publishing only the latest partial
+	// per window. Zero publishes every partial immediately.
+	PartialDebounce time.Duration
+	// PartialMinChars skips partials shorter than this many characters.
+	PartialMinChars int
+	// PartialMinDelta skips partials that grew by fewer than this many
+	// characters since the last published partial of the segment.
+	PartialMinDelta int
+	// FinalHold holds each final this long before publishing it. A partial
+	// arriving meanwhile means the speaker continued: the final is not
+	// published and its text is prefixed to the segment's next final. Zero
+	// publishes finals immediately.
+	FinalHold time.Duration
+	// LatePartialGrace quietly ignores partials that arrive this soon after
+	// the segment's final (e.g. stragglers of a restarted STT stream). Later
+	// ones are logged as anomalies. Both are counted in late_partials_total.
+	LatePartialGrace time.Duration
+
+	// Audio format of incoming frames, used to validate frame lengths and to
+	// derive audio duration from byte counts.
+	Encoding     string // e.g. "LINEAR16"
+	SampleRateHz int
+	Channels     int
+	// MaxFrameBytes rejects frames larger than this many bytes. Zero disables
+	// the check.
+	MaxFrameBytes int
+	// MaxFrameDuration rejects frames holding more than this much audio. It
+	// is converted to bytes for the format above, so the limit means the same
+	// for LINEAR16 and MULAW (8 kHz mono: 16000 and 8000 bytes per second).
+	// Ignored for compressed encodings; zero disables the check.
+	MaxFrameDuration time.Duration
+	// SilenceTrim skips LINEAR16 frames at the start of each segment until
+	// one reaches SilenceThreshold RMS amplitude (0-32768 scale), so leading
+	// silence isn't sent to the STT provider. The segment's audio is then
+	// forwarded in full, starting with the last skipped frame.
+	SilenceTrim      bool
+	SilenceThreshold float64
+	// FrameRejection controls what happens to the segment when a malformed
+	// frame is rejected.
+	FrameRejection FrameRejectionPolicy
+
+	// TimestampSource selects how event timestamps are derived.
+	TimestampSource TimestampSource
+
+	// SendTimeout bounds how long SendAudio waits for the STT adapter to
+	// accept a frame; 0 waits indefinitely.
+	SendTimeout time.Duration
+
+	// Boundary decides when segments end. Nil behaves like
+	// SingleUtteranceBoundary.
+	Boundary BoundaryPolicy
+
+	// VAD detects utterance ends from audio energy for adapters that don't
+	// report them (see stt.UtteranceDetector), ending the utterance as
+	// OnEndOfUtterance would. Nil disables it.
+	VAD VADFactory
+
+	// PublishSegmentClosed publishes an interaction.segment.closed event when
+	// a segment ends normally (not dropped).
+	PublishSegmentClosed bool
+
+	// Audit receives a record of every dropped segment and STT error, for
+	// compliance retention. Nil disables auditing.
+	Audit audit.Logger
+
+	// Clock supplies the current time. Nil uses SystemClock. Timers such as
+	// the partial debounce and final hold still run on real time.
+	Clock Clock
+}
+
+// TimestampSource selects the clock behind event timestamps.
+type TimestampSource string
+
+const (
+	// TimestampWallclock stamps events with the time they are published.
+	TimestampWallclock TimestampSource = "wallclock"
+	// TimestampAudio stamps events with the stream start time plus the audio
+	// offset of the latest frame, so timestamps follow the audio timeline
+	// rather than STT and publishing latency.
+	TimestampAudio TimestampSource = "audio"
+)
+
+// FrameRejectionPolicy selects how malformed audio frames are handled.
+type FrameRejectionPolicy string
+
+const (
+	// RejectDropFrame discards the malformed frame and keeps the segment.
+	RejectDropFrame FrameRejectionPolicy = "drop-frame"
+	// RejectDropSegment discards the frame and drops the current segment
+	// (reason "invalid_frame"), reported like any other drop through the
+	// SegmentDropCallback.
+	RejectDropSegment FrameRejectionPolicy = "drop-segment"
+)
+
+// Frame rejection reasons, used as the frames_rejected_total label.
+const (
+	frameMisaligned       = "misaligned_length"
+	frameOffsetRegression = "offset_regression"
+	frameOversized        = "oversized"
+)
+
+// DefaultConfig returns the default handler config.
+func DefaultConfig() Config {
+	return Config{
+		DropEmptyFinals: true,
+		PublishPartials: true,
+		Encoding:        "LINEAR16",
+		SampleRateHz:    8000,
+		Channels:        1,
+		FrameRejection:  RejectDropFrame,
+		TimestampSource: TimestampWallclock,
+		Boundary:        SingleUtteranceBoundary{},
+		Clock:           SystemClock{},
+	}
+}
+
 // SegmentTransitionCallback is called when an utterance ends and a new segment begins.
 // The callback receives the new segmentId.
 type SegmentTransitionCallback func(newSegmentId string)
 
+// SegmentDropCallback is called when a segment is dropped, with the dropped
+// segment's ID.
+type SegmentDropCallback func(segmentId string, reason DropReason)
+
+// TranscriptCallback receives each transcript event (models.TranscriptPartial
+// or models.TranscriptFinal) after it is handed to the publisher.
+type TranscriptCallback func(event any)
+
 // Handler manages an audio transcription session.
 // It implements stt.Callback to receive transcripts and publish events.
 // Uses an explicit segment state machine to enforce lifecycle rules.
 type Handler struct {
 	adapter           stt.Adapter
-	publisher         *events.Publisher
+	publisher         Publisher
+	config            Config
+	metrics           *metrics.Metrics
 	segmentGen        *segment.Generator
 	interactionId     string
 	tenantId          string
@@ -35,23 +203,155 @@ type Handler struct {
 	// Segment transition handling
 	mu                  sync.RWMutex
 	onSegmentTransition SegmentTransitionCallback
+	onTranscript        TranscriptCallback
+	onSegmentDrop       SegmentDropCallback
 	utteranceCount      int
+
+	// Per-segment counters (reset on segment transition)
+	streamStartedAt      time.Time
+	segmentStartedAt     time.Time
+	audioBytes           int64
+	partialCount         int
+	dropReason           DropReason
+	lastPublishedPartial string // Last partial that passed the min chars/delta filter
+	lastPartialText      string // Raw text of the most recent partial
+	finalEmittedAt       time.Time
+
+	// Stream totals (never reset)
+	segmentsCreated   int
+	segmentsCompleted int
+	segmentsDropped   int
+	totalAudioBytes   int64
+	totalPartials     int
+
+	// Segment transitions; segmentMu serializes them so a timed boundary can't
+	// close a segment twice (see boundary.go)
+	segmentMu     sync.Mutex
+	intervalTimer *time.Timer
+	closed        bool
+
+	// Final hold (see hold.go)
+	holdMu        sync.Mutex
+	heldFinal     []stt.Alternative
+	heldSegmentId string
+	holdTimer     *time.Timer
+	carriedText   string // Text of premature finals, prefixed to the segment's final
+
+	// Low-confidence final deferral (see floor.go)
+	deferMu           sync.Mutex
+	deferredFinal     []stt.Alternative
+	deferredSegmentId string
+	finalDeferrals    int // Finals deferred in the current segment
+
+	// Leading silence trimming (see silence.go), guarded by mu
+	speechDetected bool
+	silencePreroll []byte
+
+	// Voice activity detection (see vad.go), guarded by mu; nil unless the
+	// provider lacks utterance events
+	vad VoiceActivityDetector
+
+	// Byte rate measurement (see rate.go), guarded by mu
+	rateWindowStart time.Time
+	rateWindowBytes int64
+	bytesPerSecond  float64 // Rate of the last complete window
+	rateStopped     bool
+
+	// Adapter sends with a timeout (see sender.go)
+	sendMu     sync.Mutex
+	sendQueue  chan sendRequest
+	senderDone chan struct{}
+	sendTimer  *time.Timer
+	sendClosed bool
+	// Cancels the adapter's context, aborting a stuck send; guarded by mu
+	cancelAdapter context.CancelFunc
+
+	// Partial coalescing (see partials.go)
+	flushMu        sync.Mutex
+	pendingPartial *models.TranscriptPartial
+	partialTimer   *time.Timer
+}
+
+// SegmentMetrics is a snapshot of the current segment's counters.
+type SegmentMetrics struct {
+	AudioBytes      int64
+	AudioDurationMs int64 // Derived from AudioBytes; 0 for compressed encodings
+	PartialCount    int
+	Duration        time.Duration
+}
+
+// InteractionSummary totals a stream's counters across all of its segments.
+type InteractionSummary struct {
+	SegmentsCreated   int
+	SegmentsCompleted int
+	SegmentsDropped   int
+	AudioBytes        int64
+	AudioDurationMs   int64 // Derived from AudioBytes; 0 for compressed encodings
+	Partials          int   // Partials received, including ones not published
+	Utterances        int
+	Duration          time.Duration
+}
+
+// AudioDurationMs converts a byte count to milliseconds of audio for the
+// configured format. Returns 0 when the format has no fixed sample width.
+func (c Config) AudioDurationMs(bytes int64) int64 {
+	channels := c.Channels
+	if channels <= 0 {
+		channels = 1
+	}
+	bytesPerSecond := int64(c.SampleRateHz * channels * sampleWidth(c.Encoding))
+	if bytesPerSecond <= 0 {
+		return 0
+	}
+	return bytes * 1000 / bytesPerSecond
+}
+
+// AudioBytes converts a duration to the byte count of that much audio in the
+// configured format, rounded down to whole samples. Returns 0 when the format
+// has no fixed sample width.
+func (c Config) AudioBytes(d time.Duration) int64 {
+	frameWidth := int64(max(c.Channels, 1) * sampleWidth(c.Encoding))
+	samples := int64(c.SampleRateHz) * d.Milliseconds() / 1000
+	return samples * frameWidth
 }
 
-// NewHandler creates a new audio handler for a transcription session.
+// NewHandler creates a new audio handler for a transcription session
+// using the default config.
 func NewHandler(
 	adapter stt.Adapter,
-	publisher *events.Publisher,
+	publisher Publisher,
 	segmentGen *segment.Generator,
 	interactionId, tenantId, segmentId string,
 ) *Handler {
+	return NewHandlerWithConfig(adapter, publisher, segmentGen, interactionId, tenantId, segmentId, DefaultConfig())
+}
+
+// NewHandlerWithConfig creates a new audio handler for a transcription session.
+func NewHandlerWithConfig(
+	adapter stt.Adapter,
+	publisher Publisher,
+	segmentGen *segment.Generator,
+	interactionId, tenantId, segmentId string,
+	cfg Config,
+) *Handler {
+	if cfg.Clock == nil {
+		cfg.Clock = SystemClock{}
+	}
+	now := cfg.Clock.Now()
 	return &Handler{
-		adapter:       adapter,
-		publisher:     publisher,
-		segmentGen:    segmentGen,
-		interactionId: interactionId,
-		tenantId:      tenantId,
-		lifecycle:     segment.NewLifecycle(segmentId),
+		adapter:          adapter,
+		publisher:        publisher,
+		config:           cfg,
+		metrics:          metrics.Default,
+		segmentGen:       segmentGen,
+		interactionId:    interactionId,
+		tenantId:         tenantId,
+		lifecycle:        segment.NewLifecycle(segmentId),
+		streamStartedAt:  now,
+		segmentStartedAt: now,
+		segmentsCreated:  1,
+		vad:              newVAD(adapter, cfg),
+		rateWindowStart:  now,
 	}
 }
 
@@ -63,22 +363,147 @@ func (h *Handler) SetSegmentTransitionCallback(cb SegmentTransitionCallback) {
 	h.onSegmentTransition = cb
 }
 
+// SetTranscriptCallback sets a callback that receives every published
+// transcript, e.g. to stream transcripts back to a gRPC client.
+func (h *Handler) SetTranscriptCallback(cb TranscriptCallback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onTranscript = cb
+}
+
+// SetSegmentDropCallback sets a callback for segments dropped without a final,
+// whichever path dropped them, e.g. to discard their recordings.
+func (h *Handler) SetSegmentDropCallback(cb SegmentDropCallback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onSegmentDrop = cb
+}
+
+// SetMetrics overrides the metrics instance (defaults to metrics.Default).
+func (h *Handler) SetMetrics(m *metrics.Metrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.metrics = m
+}
+
 // Start begins the STT session with this handler as the callback receiver.
+// The adapter runs under its own context derived from ctx, so a send stuck
+// past SendTimeout can be aborted before ctx ends.
 func (h *Handler) Start(ctx context.Context) error {
-	return h.adapter.Start(ctx, h)
+	ctx, cancel := context.WithCancel(ctx)
+	if err := h.adapter.Start(ctx, h); err != nil {
+		cancel()
+		return err
+	}
+	h.mu.Lock()
+	h.cancelAdapter = cancel
+	h.mu.Unlock()
+	h.segmentMu.Lock()
+	h.armInterval(h.lifecycle.SegmentId())
+	h.segmentMu.Unlock()
+	return nil
 }
 
-// SendAudio forwards audio bytes to the STT adapter.
+// SendAudio validates a frame and forwards its audio bytes to the STT adapter.
+// Malformed frames are rejected (not forwarded) according to the configured
+// FrameRejectionPolicy; rejection is not reported as an error.
 func (h *Handler) SendAudio(ctx context.Context, audio []byte, audioOffsetMs int64) error {
 	h.mu.Lock()
-	h.lastAudioOffsetMs = audioOffsetMs
+	reason := h.validateFrame(audio, audioOffsetMs)
+	var skip, rateMeasured bool
+	var preroll []byte
+	var rate, rateDelta float64
+	if reason == "" {
+		h.lastAudioOffsetMs = audioOffsetMs
+		h.audioBytes += int64(len(audio))
+		h.totalAudioBytes += int64(len(audio))
+		rate, rateDelta, rateMeasured = h.observeRateLocked(len(audio))
+		skip, preroll = h.leadingSilenceLocked(audio)
+	}
+	utteranceEnded := reason == "" && !skip && h.vad != nil && h.vad.Observe(audio)
+	mt := h.metrics
 	h.mu.Unlock()
-	return h.adapter.SendAudio(ctx, audio)
+
+	if rateMeasured {
+		h.recordRate(rate, rateDelta)
+	}
+
+	if reason != "" {
+		mt.FramesRejected.WithLabelValues(reason).Inc()
+		if reason == frameOversized {
+			mt.FramesOversized.Inc()
+		}
+		log.Printf("Frame rejected: interactionId=%s segmentId=%s reason=%s bytes=%d audioOffsetMs=%d",
+			h.interactionId, h.lifecycle.SegmentId(), reason, len(audio), audioOffsetMs)
+		if h.config.FrameRejection == RejectDropSegment {
+			h.DropSegment(DropInvalidFrame)
+		}
+		return nil
+	}
+	if skip {
+		mt.SilenceFramesSkipped.Inc()
+		return nil
+	}
+	if preroll != nil {
+		if err := h.sendToAdapter(ctx, preroll); err != nil {
+			return err
+		}
+	}
+	if err := h.sendToAdapter(ctx, audio); err != nil {
+		return err
+	}
+	if utteranceEnded {
+		log.Printf("Voice activity detected utterance end: interactionId=%s segmentId=%s audioOffsetMs=%d",
+			h.interactionId, h.lifecycle.SegmentId(), audioOffsetMs)
+		h.OnEndOfUtterance()
+	}
+	return nil
+}
+
+// validateFrame returns the rejection reason for a frame, or "" if it is valid.
+// Callers must hold h.mu.
+func (h *Handler) validateFrame(audio []byte, audioOffsetMs int64) string {
+	if h.config.MaxFrameBytes > 0 && len(audio) > h.config.MaxFrameBytes {
+		return frameOversized
+	}
+	if limit := h.config.AudioBytes(h.config.MaxFrameDuration); limit > 0 && int64(len(audio)) > limit {
+		return frameOversized
+	}
+	if width := sampleWidth(h.config.Encoding) * max(h.config.Channels, 1); width > 1 && len(audio)%width != 0 {
+		return frameMisaligned
+	}
+	if audioOffsetMs < h.lastAudioOffsetMs {
+		return frameOffsetRegression
+	}
+	return ""
+}
+
+// sampleWidth returns the bytes per sample for fixed-width encodings, or 0
+// when frames cannot be checked (e.g. compressed formats).
+func sampleWidth(encoding string) int {
+	switch strings.ToUpper(encoding) {
+	case "LINEAR16":
+		return 2
+	case "MULAW", "ALAW":
+		return 1
+	default:
+		return 0
+	}
 }
 
 // Close ends the STT session and closes the current segment.
 func (h *Handler) Close() error {
-	h.lifecycle.Close()
+	h.segmentMu.Lock()
+	h.closed = true
+	h.armInterval("")
+	h.segmentMu.Unlock()
+
+	h.flushPartial()
+	h.releaseHeldFinal()
+	h.acceptDeferredFinal()
+	h.closeSegment(h.lifecycle.SegmentId())
+	h.stopRate()
+	h.stopSender()
 	return h.adapter.Close()
 }
 
@@ -99,6 +524,111 @@ func (h *Handler) GetUtteranceCount() int {
 	return h.utteranceCount
 }
 
+// IsSegmentDropped returns true if the current segment was dropped.
+func (h *Handler) IsSegmentDropped() bool {
+	return h.lifecycle.IsDropped()
+}
+
+// DropSegment abandons the current segment without publishing a final.
+// No-op if the segment already emitted its final or was closed/dropped.
+func (h *Handler) DropSegment(reason DropReason) {
+	h.dropSegmentFor(h.lifecycle.SegmentId(), reason)
+}
+
+// dropSegmentFor is DropSegment for a specific segment. It is a no-op if the
+// handler has moved on to another segment since segmentId was read.
+func (h *Handler) dropSegmentFor(segmentId string, reason DropReason) {
+	state := h.lifecycle.State()
+	if err := h.lifecycle.DropFor(segmentId); err != nil {
+		log.Printf("DropSegment ignored: segmentId=%s state=%s reason=%s err=%v",
+			segmentId, h.lifecycle.State(), reason, err)
+		return
+	}
+
+	// A coalesced partial or held final of a dropped segment is discarded
+	h.takePendingPartial()
+	h.discardHeldFinal()
+	h.discardDeferredFinal()
+
+	m := h.GetSegmentMetrics()
+	h.mu.Lock()
+	h.dropReason = reason
+	h.segmentsDropped++
+	mt := h.metrics
+	onDrop := h.onSegmentDrop
+	h.mu.Unlock()
+	mt.SegmentsDropped.WithLabelValues(reason.String()).Inc()
+
+	log.Printf("Segment dropped: interactionId=%s segmentId=%s reason=%s audioBytes=%d audioMs=%d partials=%d duration=%s",
+		h.interactionId, segmentId, reason, m.AudioBytes, m.AudioDurationMs, m.PartialCount, m.Duration)
+	h.writeAudit(audit.EventSegmentDropped, segmentId, reason.String(), state)
+	if onDrop != nil {
+		onDrop(segmentId, reason)
+	}
+}
+
+// GetDropReason returns why the current segment was dropped, or the zero
+// DropReason if it wasn't.
+func (h *Handler) GetDropReason() DropReason {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.dropReason
+}
+
+// GetInteractionId returns the interaction ID this handler serves.
+func (h *Handler) GetInteractionId() string {
+	return h.interactionId
+}
+
+// GetTenantId returns the tenant ID this handler serves.
+func (h *Handler) GetTenantId() string {
+	return h.tenantId
+}
+
+// GetStreamDuration returns how long the stream has been running.
+func (h *Handler) GetStreamDuration() time.Duration {
+	return h.since(h.startedAt())
+}
+
+// since returns the time elapsed since t on the handler's clock.
+func (h *Handler) since(t time.Time) time.Duration {
+	return h.config.Clock.Now().Sub(t)
+}
+
+func (h *Handler) startedAt() time.Time {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.streamStartedAt
+}
+
+// GetSegmentMetrics returns a snapshot of the current segment's counters.
+func (h *Handler) GetSegmentMetrics() SegmentMetrics {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return SegmentMetrics{
+		AudioBytes:      h.audioBytes,
+		AudioDurationMs: h.config.AudioDurationMs(h.audioBytes),
+		PartialCount:    h.partialCount,
+		Duration:        h.since(h.segmentStartedAt),
+	}
+}
+
+// Summary returns the stream's totals across all segments so far.
+func (h *Handler) Summary() InteractionSummary {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return InteractionSummary{
+		SegmentsCreated:   h.segmentsCreated,
+		SegmentsCompleted: h.segmentsCompleted,
+		SegmentsDropped:   h.segmentsDropped,
+		AudioBytes:        h.totalAudioBytes,
+		AudioDurationMs:   h.config.AudioDurationMs(h.totalAudioBytes),
+		Partials:          h.totalPartials,
+		Utterances:        h.utteranceCount,
+		Duration:          h.since(h.streamStartedAt),
+	}
+}
+
 // --- stt.Callback implementation ---
 
 // OnPartial is called when an interim transcript is received.
@@ -106,47 +636,199 @@ func (h *Handler) GetUtteranceCount() int {
 func (h *Handler) OnPartial(text string) {
 	// Validate state transition
 	if err := h.lifecycle.EmitPartial(); err != nil {
+		if errors.Is(err, segment.ErrCannotEmitPartialAfterFinal) && h.latePartialInGrace() {
+			return
+		}
 		log.Printf("OnPartial ignored: segmentId=%s state=%s err=%v",
 			h.lifecycle.SegmentId(), h.lifecycle.State(), err)
 		return
 	}
+	h.continueHeldFinal()
+
+	h.mu.RLock()
+	audioOffsetMs := h.lastAudioOffsetMs
+	h.mu.RUnlock()
 
 	ev := models.TranscriptPartial{
 		EventType:     "interaction.transcript.partial",
+		SchemaVersion: models.SchemaVersion,
 		InteractionID: h.interactionId,
 		TenantID:      h.tenantId,
 		SegmentID:     h.lifecycle.SegmentId(),
 		Text:          text,
-		Timestamp:     time.Now().UnixMilli(),
+		Timestamp:     h.eventTimestamp(audioOffsetMs),
 	}
-	h.publishPartial(ev)
+	if h.config.TransformPartials {
+		ev.Text = h.config.Transforms.Transform(text)
+	}
+
+	h.mu.Lock()
+	h.partialCount++
+	h.totalPartials++
+	h.lastPartialText = text
+	accepted := h.acceptPartial(text)
+	h.mu.Unlock()
+	if !accepted {
+		return
+	}
+
+	h.queuePartial(ev)
+}
+
+// latePartialInGrace counts a partial that arrived after the segment's final
+// and reports whether it is within LatePartialGrace and can be ignored quietly.
+func (h *Handler) latePartialInGrace() bool {
+	h.mu.RLock()
+	sinceFinal := h.since(h.finalEmittedAt)
+	mt := h.metrics
+	h.mu.RUnlock()
+
+	if sinceFinal <= h.config.LatePartialGrace {
+		mt.LatePartials.WithLabelValues("ignored").Inc()
+		return true
+	}
+	mt.LatePartials.WithLabelValues("anomaly").Inc()
+	return false
+}
+
+// FinalizeFromPartial publishes the segment's most recent partial as its final,
+// for when the stream ends before the provider sends one. Returns false if no
+// partial was received or the segment is no longer open.
+func (h *Handler) FinalizeFromPartial(confidence float64) bool {
+	if h.releaseHeldFinal() || h.acceptDeferredFinal() {
+		return h.lifecycle.State() == segment.StateFinalEmitted
+	}
+
+	h.mu.RLock()
+	text := h.lastPartialText
+	h.mu.RUnlock()
+	if text == "" || h.lifecycle.State() != segment.StateOpen {
+		return false
+	}
+
+	h.emitFinal(h.lifecycle.SegmentId(), []stt.Alternative{{Text: text, Confidence: confidence}})
+	return h.lifecycle.State() == segment.StateFinalEmitted
 }
 
 // OnFinal is called when a final transcript is received.
 // Only emits once per segment, transitions to FINAL_EMITTED state.
 func (h *Handler) OnFinal(text string, confidence float64) {
+	h.OnFinalAlternatives([]stt.Alternative{{Text: text, Confidence: confidence}})
+}
+
+// OnFinalAlternatives is called with the N-best hypotheses of a final
+// transcript, best first. The published Text/Confidence reflect the best one.
+func (h *Handler) OnFinalAlternatives(alternatives []stt.Alternative) {
+	if h.deferLowConfidence(alternatives) {
+		return
+	}
+	if h.config.FinalHold > 0 {
+		h.holdFinal(alternatives)
+		return
+	}
+	// The final belongs to the segment open when it arrived; a concurrent
+	// drop or segment transition must not redirect it to the next segment
+	h.emitFinal(h.lifecycle.SegmentId(), alternatives)
+}
+
+// emitFinal publishes alternatives as the final of segmentId.
+func (h *Handler) emitFinal(segmentId string, alternatives []stt.Alternative) {
+	alternatives = h.withCarriedText(alternatives)
+	var text string
+	var confidence float64
+	if len(alternatives) > 0 {
+		text, confidence = alternatives[0].Text, alternatives[0].Confidence
+	}
+
+	// An empty final carries no information; treat it as a drop rather than
+	// publishing a meaningless event
+	if h.config.DropEmptyFinals && strings.TrimSpace(text) == "" {
+		h.dropSegmentFor(segmentId, DropEmptyFinal)
+		h.boundary(BoundaryFinal, segmentId)
+		return
+	}
+
 	// Validate state transition - this also transitions to FINAL_EMITTED
-	if err := h.lifecycle.EmitFinal(); err != nil {
+	if err := h.lifecycle.EmitFinalFor(segmentId); err != nil {
 		log.Printf("OnFinal ignored: segmentId=%s state=%s err=%v",
-			h.lifecycle.SegmentId(), h.lifecycle.State(), err)
+			segmentId, h.lifecycle.State(), err)
 		return
 	}
 
-	h.mu.RLock()
+	h.mu.Lock()
 	audioOffsetMs := h.lastAudioOffsetMs
-	h.mu.RUnlock()
+	h.segmentsCompleted++
+	h.finalEmittedAt = h.config.Clock.Now()
+	mt := h.metrics
+	h.mu.Unlock()
+	mt.SegmentsCompleted.Inc()
+	mt.FinalConfidence.Observe(confidence)
+	if confidence < h.config.LowConfidenceThreshold {
+		mt.FinalsLowQuality.Inc()
+	}
+
+	// The final must follow the latest partial
+	h.flushPartial()
+
+	m := h.GetSegmentMetrics()
+	log.Printf("Segment final: interactionId=%s segmentId=%s audioBytes=%d audioMs=%d partials=%d duration=%s",
+		h.interactionId, segmentId, m.AudioBytes, m.AudioDurationMs, m.PartialCount, m.Duration)
+
+	// Alternatives go through the same normalization and redaction as the text
+	alts := make([]models.Alternative, len(alternatives))
+	for i, alt := range alternatives {
+		alts[i] = models.Alternative{Text: h.finalText(alt.Text), Confidence: alt.Confidence}
+	}
 
 	ev := models.TranscriptFinal{
 		EventType:     "interaction.transcript.final",
+		SchemaVersion: models.SchemaVersion,
 		InteractionID: h.interactionId,
 		TenantID:      h.tenantId,
-		SegmentID:     h.lifecycle.SegmentId(),
-		Text:          text,
+		SegmentID:     segmentId,
+		Text:          h.finalText(text),
 		Confidence:    confidence,
+		Alternatives:  alts,
 		AudioOffsetMs: audioOffsetMs,
-		Timestamp:     time.Now().UnixMilli(),
+		Timestamp:     h.eventTimestamp(audioOffsetMs),
 	}
 	h.publishFinal(ev)
+	h.boundary(BoundaryFinal, segmentId)
+}
+
+// boundary ends segmentId and opens the next segment if the boundary policy
+// ends segments at ev and no other boundary has already moved past it.
+func (h *Handler) boundary(ev BoundaryEvent, segmentId string) (string, bool) {
+	policy := h.config.Boundary
+	if policy == nil {
+		policy = SingleUtteranceBoundary{}
+	}
+	if !policy.EndsSegment(ev) {
+		return "", false
+	}
+	newSegmentId, ok := h.nextSegmentFrom(segmentId)
+	if ok && ev == BoundaryFinal {
+		log.Printf("Segment boundary: interactionId=%s oldSegment=%s newSegment=%s policy=%s",
+			h.interactionId, segmentId, newSegmentId, policy.Name())
+	}
+	return newSegmentId, ok
+}
+
+// eventTimestamp returns the Unix millisecond timestamp for an event at the
+// given audio offset, according to the configured TimestampSource.
+func (h *Handler) eventTimestamp(audioOffsetMs int64) int64 {
+	if h.config.TimestampSource == TimestampAudio {
+		return h.streamStartedAt.UnixMilli() + audioOffsetMs
+	}
+	return h.config.Clock.Now().UnixMilli()
+}
+
+// finalText applies the normalizer and transforms to final text.
+func (h *Handler) finalText(text string) string {
+	if h.config.Normalizer != nil {
+		text = h.config.Normalizer.Transform(text)
+	}
+	return h.config.Transforms.Transform(text)
 }
 
 // OnEndOfUtterance is called when the STT provider detects end of speech.
@@ -154,14 +836,107 @@ func (h *Handler) OnFinal(text string, confidence float64) {
 // The handler closes the current segment and creates a new one.
 func (h *Handler) OnEndOfUtterance() {
 	oldSegmentId := h.lifecycle.SegmentId()
+
+	h.flushPartial()
+	h.releaseHeldFinal()
+	h.acceptDeferredFinal()
 	oldState := h.lifecycle.State()
 
+	h.mu.Lock()
+	h.utteranceCount++
+	utterance := h.utteranceCount
+	h.mu.Unlock()
+
+	newSegmentId, ok := h.boundary(BoundaryUtteranceEnd, oldSegmentId)
+	if !ok {
+		log.Printf("End of utterance: interactionId=%s segment=%s (state=%s) kept open utterance=#%d",
+			h.interactionId, oldSegmentId, oldState, utterance)
+		return
+	}
+
+	log.Printf("End of utterance: interactionId=%s oldSegment=%s (state=%s) newSegment=%s utterance=#%d",
+		h.interactionId, oldSegmentId, oldState, newSegmentId, utterance)
+}
+
+// CancelSegment drops the current segment with the given reason and starts a
+// new one without ending the stream. Unlike an end of utterance, no final is
+// published for the cancelled segment.
+func (h *Handler) CancelSegment(reason DropReason) {
+	oldSegmentId := h.lifecycle.SegmentId()
+	h.DropSegment(reason)
+	newSegmentId := h.nextSegment()
+
+	log.Printf("Segment cancelled: interactionId=%s oldSegment=%s newSegment=%s reason=%s",
+		h.interactionId, oldSegmentId, newSegmentId, reason)
+}
+
+// FinalizeCurrentSegment publishes the current segment's latest partial as
+// its final and starts a new segment without ending the stream, for when the
+// client detects a boundary the provider missed. Unlike CancelSegment, the
+// segment's transcript is kept. Returns whether a final was published; a
+// segment with no partial is closed without one.
+func (h *Handler) FinalizeCurrentSegment() bool {
+	oldSegmentId := h.lifecycle.SegmentId()
+
+	h.flushPartial()
+	finalized := h.FinalizeFromPartial(0)
+
+	newSegmentId, ok := h.nextSegmentFrom(oldSegmentId)
+	if !ok {
+		// A provider boundary closed the segment first
+		return finalized
+	}
+	log.Printf("Segment finalized by client: interactionId=%s oldSegment=%s newSegment=%s finalized=%v",
+		h.interactionId, oldSegmentId, newSegmentId, finalized)
+	return finalized
+}
+
+// nextSegment closes the current segment, resets per-segment state under a new
+// segment ID and notifies the transition callback. Returns the new segment ID.
+func (h *Handler) nextSegment() string {
+	h.segmentMu.Lock()
+	defer h.segmentMu.Unlock()
+	return h.advanceSegment()
+}
+
+// nextSegmentFrom is nextSegment if segmentId is still current. Returns false
+// if another boundary already moved past it.
+func (h *Handler) nextSegmentFrom(segmentId string) (string, bool) {
+	h.segmentMu.Lock()
+	defer h.segmentMu.Unlock()
+	if h.lifecycle.SegmentId() != segmentId {
+		return "", false
+	}
+	return h.advanceSegment(), true
+}
+
+// advanceSegment implements nextSegment. Callers must hold h.segmentMu.
+func (h *Handler) advanceSegment() string {
+	oldSegmentId := h.lifecycle.SegmentId()
+
 	// Close current segment
-	h.lifecycle.Close()
+	h.closeSegment(oldSegmentId)
 
-	// Generate new segment ID and reset lifecycle
+	// Generate new segment ID and reset per-segment counters
 	h.mu.Lock()
-	h.utteranceCount++
+	h.segmentStartedAt = h.config.Clock.Now()
+	h.audioBytes = 0
+	h.partialCount = 0
+	h.dropReason = 0
+	h.lastPublishedPartial = ""
+	h.lastPartialText = ""
+	h.speechDetected = false
+	h.silencePreroll = nil
+	if h.vad != nil {
+		h.vad.Reset()
+	}
+	h.segmentsCreated++
+	h.holdMu.Lock()
+	h.carriedText = ""
+	h.holdMu.Unlock()
+	h.deferMu.Lock()
+	h.resetDeferralsLocked()
+	h.deferMu.Unlock()
 	var newSegmentId string
 	if h.segmentGen != nil {
 		newSegmentId = h.segmentGen.Next(h.interactionId)
@@ -173,32 +948,75 @@ func (h *Handler) OnEndOfUtterance() {
 
 	// Reset lifecycle for new segment
 	h.lifecycle.Reset(newSegmentId)
-
-	log.Printf("End of utterance: interactionId=%s oldSegment=%s (state=%s) newSegment=%s utterance=#%d",
-		h.interactionId, oldSegmentId, oldState, newSegmentId, h.utteranceCount)
+	h.armInterval(newSegmentId)
 
 	// Notify server of segment transition if callback is set
 	if cb != nil {
 		cb(newSegmentId)
 	}
+	return newSegmentId
+}
+
+// closeSegment closes the current segment and, if this closed it, publishes
+// the segment-closed event with the segment's metrics. Dropped segments stay
+// dropped and publish nothing.
+func (h *Handler) closeSegment(segmentId string) {
+	if !h.lifecycle.Close() || !h.config.PublishSegmentClosed {
+		return
+	}
+
+	m := h.GetSegmentMetrics()
+	h.mu.RLock()
+	audioOffsetMs := h.lastAudioOffsetMs
+	h.mu.RUnlock()
+
+	ev := models.SegmentClosed{
+		EventType:     "interaction.segment.closed",
+		SchemaVersion: models.SchemaVersion,
+		InteractionID: h.interactionId,
+		TenantID:      h.tenantId,
+		SegmentID:     segmentId,
+		AudioBytes:    m.AudioBytes,
+		PartialCount:  m.PartialCount,
+		DurationMs:    m.Duration.Milliseconds(),
+		Timestamp:     h.eventTimestamp(audioOffsetMs),
+	}
+	if err := h.publisher.PublishSegmentEvent(context.Background(), h.tenantId, h.interactionId, ev); err != nil {
+		log.Printf("Failed to publish segment closed: segmentId=%s err=%v", segmentId, err)
+	}
 }
 
 // OnError is called when an STT error occurs.
 func (h *Handler) OnError(err error) {
+	segmentId, state := h.lifecycle.SegmentId(), h.lifecycle.State()
 	log.Printf("STT error: interactionId=%s segmentId=%s state=%s err=%v",
-		h.interactionId, h.lifecycle.SegmentId(), h.lifecycle.State(), err)
+		h.interactionId, segmentId, state, err)
+	h.writeAudit(audit.EventSTTError, segmentId, err.Error(), state)
 }
 
 func (h *Handler) publishPartial(ev models.TranscriptPartial) {
-	ctx := context.Background()
-	if err := h.publisher.PublishPartial(ctx, h.interactionId, ev); err != nil {
-		log.Printf("Failed to publish partial: segmentId=%s err=%v", ev.SegmentID, err)
+	if h.config.PublishPartials {
+		ctx := context.Background()
+		if err := h.publisher.PublishPartial(ctx, h.tenantId, h.interactionId, ev); err != nil {
+			log.Printf("Failed to publish partial: segmentId=%s err=%v", ev.SegmentID, err)
+		}
 	}
+	h.notifyTranscript(ev)
 }
 
 func (h *Handler) publishFinal(ev models.TranscriptFinal) {
 	ctx := context.Background()
-	if err := h.publisher.PublishFinal(ctx, h.interactionId, ev); err != nil {
+	if err := h.publisher.PublishFinal(ctx, h.tenantId, h.interactionId, ev); err != nil {
 		log.Printf("Failed to publish final: segmentId=%s err=%v", ev.SegmentID, err)
 	}
+	h.notifyTranscript(ev)
+}
+
+func (h *Handler) notifyTranscript(event any) {
+	h.mu.RLock()
+	cb := h.onTranscript
+	h.mu.RUnlock()
+	if cb != nil {
+		cb(event)
+	}
 }
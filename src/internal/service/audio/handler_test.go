@@ -0,0 +1,1121 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+
+	"ai-speech-ingress-service/internal/models"
+	"ai-speech-ingress-service/internal/observability/metrics"
+	"ai-speech-ingress-service/internal/service/segment"
+	"ai-speech-ingress-service/internal/service/stt"
+	"ai-speech-ingress-service/internal/service/stt/mock"
+	"ai-speech-ingress-service/internal/service/transform"
+)
+
+// fakePublisher captures published events.
+type fakePublisher struct {
+	mu       sync.Mutex
+	partials []models.TranscriptPartial
+	finals   []models.TranscriptFinal
+	closed   []models.SegmentClosed
+}
+
+func (p *fakePublisher) PublishPartial(_ context.Context, _, _ string, event any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.partials = append(p.partials, event.(models.TranscriptPartial))
+	return nil
+}
+
+func (p *fakePublisher) PublishFinal(_ context.Context, _, _ string, event any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finals = append(p.finals, event.(models.TranscriptFinal))
+	return nil
+}
+
+func (p *fakePublisher) PublishSegmentEvent(_ context.Context, _, _ string, event any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = append(p.closed, event.(models.SegmentClosed))
+	return nil
+}
+
+func (p *fakePublisher) counts() (partials, finals int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.partials), len(p.finals)
+}
+
+// fakeAdapter records forwarded audio; tests drive the callbacks directly.
+type fakeAdapter struct {
+	mu   sync.Mutex
+	sent int
+}
+
+func (a *fakeAdapter) Start(context.Context, stt.Callback) error { return nil }
+func (a *fakeAdapter) Close() error                              { return nil }
+
+func (a *fakeAdapter) SendAudio(context.Context, []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sent++
+	return nil
+}
+
+func (a *fakeAdapter) sentFrames() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sent
+}
+
+func newTestHandler(t *testing.T, cfg Config) (*Handler, *fakePublisher, *metrics.Metrics) {
+	t.Helper()
+	pub := &fakePublisher{}
+	m := metrics.New(prometheus.NewRegistry())
+	gen := segment.New()
+	h := NewHandlerWithConfig(&fakeAdapter{}, pub, gen, "int-1", "tenant-1", gen.Next("int-1"), cfg)
+	h.SetMetrics(m)
+	return h, pub, m
+}
+
+func TestHandler_OnFinal_Publishes(t *testing.T) {
+	h, pub, m := newTestHandler(t, DefaultConfig())
+
+	h.OnPartial("hello")
+	h.OnFinal("hello world", 0.9)
+
+	partials, finals := pub.counts()
+	if partials != 1 || finals != 1 {
+		t.Errorf("published partials=%d finals=%d, want 1/1", partials, finals)
+	}
+	if got := testutil.ToFloat64(m.SegmentsCompleted); got != 1 {
+		t.Errorf("segments_completed_total = %v, want 1", got)
+	}
+	if pub.partials[0].SchemaVersion != models.SchemaVersion || pub.finals[0].SchemaVersion != models.SchemaVersion {
+		t.Errorf("schema versions = %q, %q, want %s", pub.partials[0].SchemaVersion, pub.finals[0].SchemaVersion, models.SchemaVersion)
+	}
+}
+
+func TestHandler_OnFinal_ObservesConfidence(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LowConfidenceThreshold = 0.6
+	h, _, m := newTestHandler(t, cfg)
+
+	h.OnFinal("hello world", 0.45)
+
+	var got dto.Metric
+	if err := m.FinalConfidence.Write(&got); err != nil {
+		t.Fatal(err)
+	}
+	if n, sum := got.GetHistogram().GetSampleCount(), got.GetHistogram().GetSampleSum(); n != 1 || sum != 0.45 {
+		t.Errorf("stt_final_confidence count=%d sum=%v, want 1/0.45", n, sum)
+	}
+	if got := testutil.ToFloat64(m.FinalsLowQuality); got != 1 {
+		t.Errorf("stt_finals_low_confidence_total = %v, want 1", got)
+	}
+}
+
+func TestHandler_Summary_TotalsAcrossSegments(t *testing.T) {
+	h, _, _ := newTestHandler(t, DefaultConfig())
+	ctx := context.Background()
+
+	h.SendAudio(ctx, make([]byte, 1600), 0)
+	h.OnPartial("hello")
+	h.OnFinal("hello", 0.9)
+	h.OnEndOfUtterance()
+
+	h.SendAudio(ctx, make([]byte, 800), 100)
+	h.OnPartial("goodbye")
+	h.OnPartial("goodbye now")
+	h.DropSegment(DropClientCancel)
+
+	sum := h.Summary()
+	want := InteractionSummary{
+		SegmentsCreated:   2,
+		SegmentsCompleted: 1,
+		SegmentsDropped:   1,
+		AudioBytes:        2400,
+		AudioDurationMs:   150,
+		Partials:          3,
+		Utterances:        1,
+	}
+	sum.Duration = 0
+	if sum != want {
+		t.Errorf("Summary = %+v, want %+v", sum, want)
+	}
+}
+
+func TestHandler_MockMultipleUtterances(t *testing.T) {
+	adapter := mock.NewWithScript([]mock.SimulatedUtterance{
+		{Partials: []string{"hello"}, Final: "hello there", Confidence: 0.9},
+		{Partials: []string{"bye"}, Final: "bye now", Confidence: 0.8},
+	})
+	adapter.SetSynchronous(true)
+	pub := &fakePublisher{}
+	gen := segment.New()
+	h := NewHandlerWithConfig(adapter, pub, gen, "int-1", "tenant-1", gen.Next("int-1"), DefaultConfig())
+	h.SetMetrics(metrics.New(prometheus.NewRegistry()))
+	ctx := context.Background()
+	if err := h.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	for i := range 4 {
+		h.SendAudio(ctx, make([]byte, 320), int64(i*20))
+	}
+	h.Close()
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	if len(pub.partials) != 2 || len(pub.finals) != 2 {
+		t.Fatalf("published partials=%d finals=%d, want 2/2", len(pub.partials), len(pub.finals))
+	}
+	first, second := pub.finals[0], pub.finals[1]
+	if first.Text != "hello there" || second.Text != "bye now" {
+		t.Errorf("finals = %q, %q", first.Text, second.Text)
+	}
+	if first.SegmentID == second.SegmentID {
+		t.Errorf("both finals published for segment %s", first.SegmentID)
+	}
+	if pub.partials[1].SegmentID != second.SegmentID {
+		t.Errorf("second partial segment = %s, want %s", pub.partials[1].SegmentID, second.SegmentID)
+	}
+	if got := h.GetUtteranceCount(); got != 2 {
+		t.Errorf("utterances = %d, want 2", got)
+	}
+}
+
+func TestHandler_EventTimestampSource(t *testing.T) {
+	for _, source := range []TimestampSource{TimestampWallclock, TimestampAudio} {
+		t.Run(string(source), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.TimestampSource = source
+			h, pub, _ := newTestHandler(t, cfg)
+			ctx := context.Background()
+
+			h.SendAudio(ctx, make([]byte, 320), 60_000)
+			h.OnPartial("hello")
+			h.OnFinal("hello world", 0.9)
+
+			want := time.Now().UnixMilli()
+			if source == TimestampAudio {
+				want = h.streamStartedAt.UnixMilli() + 60_000
+			}
+			pub.mu.Lock()
+			defer pub.mu.Unlock()
+			for _, got := range []int64{pub.partials[0].Timestamp, pub.finals[0].Timestamp} {
+				if got < want-1000 || got > want+1000 {
+					t.Errorf("timestamp = %d, want about %d", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_OnFinal_EmptyTextDropsSegment(t *testing.T) {
+	for _, text := range []string{"", "   ", "\t\n"} {
+		h, pub, m := newTestHandler(t, DefaultConfig())
+
+		h.OnFinal(text, 0.5)
+
+		if _, finals := pub.counts(); finals != 0 {
+			t.Errorf("text %q: expected no final published, got %d", text, finals)
+		}
+		if !h.IsSegmentDropped() {
+			t.Errorf("text %q: expected segment dropped, state=%s", text, h.GetSegmentState())
+		}
+		if got := testutil.ToFloat64(m.SegmentsDropped.WithLabelValues("empty_final")); got != 1 {
+			t.Errorf("text %q: segments_dropped_total{empty_final} = %v, want 1", text, got)
+		}
+		if got := testutil.ToFloat64(m.SegmentsCompleted); got != 0 {
+			t.Errorf("text %q: segments_completed_total = %v, want 0", text, got)
+		}
+	}
+}
+
+func TestHandler_SegmentDropCallback(t *testing.T) {
+	h, _, _ := newTestHandler(t, DefaultConfig())
+	var dropped []string
+	h.SetSegmentDropCallback(func(segmentId string, reason DropReason) {
+		dropped = append(dropped, segmentId+"/"+reason.String())
+	})
+	segmentId := h.GetSegmentId()
+
+	h.OnFinal("", 0.5)
+	h.DropSegment(DropClientCancel) // Already dropped
+
+	if want := segmentId + "/empty_final"; len(dropped) != 1 || dropped[0] != want {
+		t.Errorf("drop callback got %q, want [%s]", dropped, want)
+	}
+}
+
+func TestHandler_EmptyFinal_ReplacedSegmentNotDropped(t *testing.T) {
+	h, _, m := newTestHandler(t, DefaultConfig())
+	old := h.GetSegmentId()
+	h.CancelSegment(DropClientCancel)
+
+	// A held or deferred final of the cancelled segment, emitted late
+	h.emitFinal(old, []stt.Alternative{{Text: ""}})
+
+	if h.IsSegmentDropped() {
+		t.Errorf("late empty final of %s dropped the current segment %s", old, h.GetSegmentId())
+	}
+	if got := testutil.ToFloat64(m.SegmentsDropped.WithLabelValues("empty_final")); got != 0 {
+		t.Errorf("segments_dropped_total{empty_final} = %v, want 0", got)
+	}
+}
+
+func TestHandler_OnFinal_EmptyTextPublishedWhenDisabled(t *testing.T) {
+	h, pub, _ := newTestHandler(t, Config{DropEmptyFinals: false})
+
+	h.OnFinal("", 0.5)
+
+	if _, finals := pub.counts(); finals != 1 {
+		t.Errorf("expected empty final to be published, got %d", finals)
+	}
+	if h.IsSegmentDropped() {
+		t.Error("expected segment not dropped")
+	}
+}
+
+func TestHandler_DropSegment_NextSegmentStillWorks(t *testing.T) {
+	h, pub, _ := newTestHandler(t, DefaultConfig())
+
+	h.OnFinal(" ", 0.5)
+	h.OnEndOfUtterance()
+	h.OnFinal("next utterance", 0.9)
+
+	if _, finals := pub.counts(); finals != 1 {
+		t.Errorf("expected final for next segment, got %d", finals)
+	}
+	if h.GetSegmentId() == "int-1-seg-1" {
+		t.Error("expected a new segment after end of utterance")
+	}
+}
+
+func TestHandler_ContinuousBoundary(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Boundary = ContinuousBoundary{}
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnFinal("first sentence", 0.9)
+	if got := h.GetSegmentId(); got != "int-1-seg-2" {
+		t.Fatalf("expected final to open segment 2, got %s", got)
+	}
+	h.OnEndOfUtterance()
+	if got := h.GetSegmentId(); got != "int-1-seg-2" {
+		t.Fatalf("expected end of utterance to keep segment 2 open, got %s", got)
+	}
+	h.OnFinal("second sentence", 0.9)
+
+	if _, finals := pub.counts(); finals != 2 {
+		t.Errorf("expected 2 finals, got %d", finals)
+	}
+	if got := h.GetSegmentId(); got != "int-1-seg-3" {
+		t.Errorf("expected segment 3 after second final, got %s", got)
+	}
+}
+
+func TestHandler_ContinuousBoundary_EmptyFinalOpensNextSegment(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Boundary = ContinuousBoundary{}
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnFinal(" ", 0.5)
+	h.OnFinal("next sentence", 0.9)
+
+	if _, finals := pub.counts(); finals != 1 {
+		t.Errorf("expected final for the segment after the dropped one, got %d", finals)
+	}
+}
+
+func TestHandler_FixedIntervalBoundary(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Boundary = FixedIntervalBoundary{Every: 20 * time.Millisecond}
+	h, pub, _ := newTestHandler(t, cfg)
+	if err := h.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.OnPartial("steady cadence")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for h.GetSegmentId() == "int-1-seg-1" {
+		if time.Now().After(deadline) {
+			t.Fatal("interval did not close the segment")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	if len(pub.finals) != 1 {
+		t.Fatalf("expected 1 final from the interval, got %d", len(pub.finals))
+	}
+	if got := pub.finals[0]; got.SegmentID != "int-1-seg-1" || got.Text != "steady cadence" {
+		t.Errorf("expected final from the latest partial of seg-1, got %+v", got)
+	}
+}
+
+func TestHandler_FixedIntervalAfterNaturalBoundary(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Boundary = FixedIntervalBoundary{Every: time.Hour}
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnPartial("hello")
+	h.OnFinal("hello world", 0.9)
+	h.OnEndOfUtterance()
+	// The timer for seg-1 fires after the utterance end already closed it
+	h.onInterval("int-1-seg-1")
+
+	if _, finals := pub.counts(); finals != 1 {
+		t.Errorf("expected only the natural final, got %d", finals)
+	}
+	if got := h.GetSegmentId(); got != "int-1-seg-2" {
+		t.Errorf("expected stale interval to leave seg-2 open, got %s", got)
+	}
+}
+
+func TestHandler_FinalHold_PublishesAfterWindow(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FinalHold = 20 * time.Millisecond
+	h, pub, m := newTestHandler(t, cfg)
+
+	h.OnFinal("hello world", 0.9)
+	if _, finals := pub.counts(); finals != 0 {
+		t.Fatalf("expected final held, got %d published", finals)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for h.GetSegmentState() != segment.StateFinalEmitted {
+		if time.Now().After(deadline) {
+			t.Fatal("held final was not published")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := testutil.ToFloat64(m.FinalHolds.WithLabelValues("published")); got != 1 {
+		t.Errorf("expected published hold counted, got %v", got)
+	}
+}
+
+func TestHandler_FinalHold_ContinuationCarriesText(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FinalHold = time.Hour
+	h, pub, m := newTestHandler(t, cfg)
+
+	h.OnFinal("I would like to", 0.8)
+	h.OnPartial("book a")
+	if h.GetSegmentState() != segment.StateOpen {
+		t.Fatalf("expected segment still open, got %s", h.GetSegmentState())
+	}
+	h.OnFinal("book a flight", 0.9)
+	// End of utterance publishes the held final without waiting
+	h.OnEndOfUtterance()
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	if len(pub.finals) != 1 {
+		t.Fatalf("expected 1 final, got %d", len(pub.finals))
+	}
+	if got := pub.finals[0]; got.SegmentID != "int-1-seg-1" || got.Text != "I would like to book a flight" {
+		t.Errorf("expected merged final for seg-1, got %+v", got)
+	}
+	if got := testutil.ToFloat64(m.FinalHolds.WithLabelValues("continued")); got != 1 {
+		t.Errorf("expected continued hold counted, got %v", got)
+	}
+}
+
+func TestHandler_FinalRacesDrop(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		h, pub, _ := newTestHandler(t, DefaultConfig())
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); h.OnFinal("hello", 0.9) }()
+		go func() { defer wg.Done(); h.DropSegment(DropClientDisconnect) }()
+		wg.Wait()
+
+		_, finals := pub.counts()
+		if dropped := h.IsSegmentDropped(); (finals == 1) == dropped {
+			t.Fatalf("iteration %d: finals=%d dropped=%v, want exactly one outcome", i, finals, dropped)
+		}
+	}
+}
+
+func TestHandler_FinalRacesCancel(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		h, pub, m := newTestHandler(t, DefaultConfig())
+		original := h.GetSegmentId()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); h.OnFinal("hello", 0.9) }()
+		go func() { defer wg.Done(); h.CancelSegment(DropClientCancel) }()
+		wg.Wait()
+
+		// The final either wins for the original segment (and the cancel's drop
+		// is ignored) or lands on the replacement segment after the drop
+		dropped := testutil.ToFloat64(m.SegmentsDropped.WithLabelValues(DropClientCancel.String()))
+		pub.mu.Lock()
+		for _, f := range pub.finals {
+			if f.SegmentID == original && dropped != 0 {
+				t.Errorf("iteration %d: segment %s both finalized and dropped", i, original)
+			}
+		}
+		pub.mu.Unlock()
+	}
+}
+
+func TestHandler_SegmentClosedEvent(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PublishSegmentClosed = true
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnPartial("hello")
+	h.OnFinal("hello world", 0.9)
+	h.OnEndOfUtterance()
+	h.DropSegment(DropClientCancel)
+	h.Close()
+	h.Close()
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	if len(pub.closed) != 1 {
+		t.Fatalf("published %d closed events, want 1 (dropped segment publishes none)", len(pub.closed))
+	}
+	ev := pub.closed[0]
+	if ev.EventType != "interaction.segment.closed" || ev.SchemaVersion != models.SchemaVersion || ev.SegmentID != "int-1-seg-1" || ev.PartialCount != 1 {
+		t.Errorf("closed event = %+v", ev)
+	}
+}
+
+func TestHandler_SegmentClosedEventDisabled(t *testing.T) {
+	h, pub, _ := newTestHandler(t, DefaultConfig())
+
+	h.OnFinal("hello", 0.9)
+	h.Close()
+
+	if len(pub.closed) != 0 {
+		t.Errorf("published %d closed events with the event disabled", len(pub.closed))
+	}
+}
+
+func TestHandler_PartialsDisabled(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PublishPartials = false
+	h, pub, _ := newTestHandler(t, cfg)
+	var delivered int
+	h.SetTranscriptCallback(func(ev any) {
+		if _, ok := ev.(models.TranscriptPartial); ok {
+			delivered++
+		}
+	})
+
+	h.OnPartial("hello")
+	h.OnFinal("hello world", 0.9)
+
+	partials, finals := pub.counts()
+	if partials != 0 || finals != 1 {
+		t.Errorf("published partials=%d finals=%d, want 0/1", partials, finals)
+	}
+	if delivered != 1 {
+		t.Errorf("transcript callback got %d partials, want 1", delivered)
+	}
+	if m := h.GetSegmentMetrics(); m.PartialCount != 1 {
+		t.Errorf("partial count = %d, want 1", m.PartialCount)
+	}
+}
+
+func TestHandler_LatePartialGrace(t *testing.T) {
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	cfg := DefaultConfig()
+	cfg.LatePartialGrace = time.Second
+	cfg.Clock = clock
+	h, pub, m := newTestHandler(t, cfg)
+
+	h.OnFinal("hello world", 0.9)
+	h.OnPartial("hello wor")
+
+	if partials, _ := pub.counts(); partials != 0 {
+		t.Errorf("published %d partials after the final, want 0", partials)
+	}
+	if v := testutil.ToFloat64(m.LatePartials.WithLabelValues("ignored")); v != 1 {
+		t.Errorf("late_partials_total{outcome=ignored} = %v, want 1", v)
+	}
+
+	clock.Advance(time.Second + time.Millisecond)
+	h.OnPartial("hello wor")
+	if v := testutil.ToFloat64(m.LatePartials.WithLabelValues("anomaly")); v != 1 {
+		t.Errorf("late_partials_total{outcome=anomaly} = %v, want 1", v)
+	}
+}
+
+func TestHandler_TransformsAppliedBeforePublish(t *testing.T) {
+	redactor, _ := transform.NewRegexRedactor(transform.DefaultRedactionPatterns)
+	cfg := DefaultConfig()
+	cfg.Transforms = transform.Chain{redactor}
+
+	h, pub, _ := newTestHandler(t, cfg)
+	h.OnPartial("my ssn is 123-45-6789")
+	h.OnFinal("my ssn is 123-45-6789", 0.9)
+
+	if pub.partials[0].Text != "my ssn is 123-45-6789" {
+		t.Errorf("partial should pass through untransformed by default, got %q", pub.partials[0].Text)
+	}
+	if pub.finals[0].Text != "my ssn is [REDACTED]" {
+		t.Errorf("final not redacted: %q", pub.finals[0].Text)
+	}
+}
+
+func TestHandler_TransformPartials(t *testing.T) {
+	redactor, _ := transform.NewRegexRedactor(transform.DefaultRedactionPatterns)
+	cfg := DefaultConfig()
+	cfg.Transforms = transform.Chain{redactor}
+	cfg.TransformPartials = true
+
+	h, pub, _ := newTestHandler(t, cfg)
+	h.OnPartial("card 4111 1111 1111 1111")
+
+	if pub.partials[0].Text != "card [REDACTED]" {
+		t.Errorf("partial not redacted: %q", pub.partials[0].Text)
+	}
+}
+
+func TestHandler_NormalizerRunsBeforeTransforms(t *testing.T) {
+	redactor, _ := transform.NewRegexRedactor([]string{`\b\d{4}\b`})
+	cfg := DefaultConfig()
+	cfg.Normalizer = transform.NumberNormalizer{}
+	cfg.Transforms = transform.Chain{redactor}
+
+	h, pub, _ := newTestHandler(t, cfg)
+	h.OnPartial("pin four one one one")
+	h.OnFinal("pin four one one one at three thirty pm", 0.9)
+
+	if pub.partials[0].Text != "pin four one one one" {
+		t.Errorf("partials should not be normalized, got %q", pub.partials[0].Text)
+	}
+	if want := "pin [REDACTED] at 3:30 PM"; pub.finals[0].Text != want {
+		t.Errorf("final = %q, want %q", pub.finals[0].Text, want)
+	}
+}
+
+func TestHandler_SendAudio_RejectsPartialStereoFrame(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Channels = 2
+	h, _, m := newTestHandler(t, cfg)
+	ctx := context.Background()
+
+	h.SendAudio(ctx, make([]byte, 322), 0)
+	h.SendAudio(ctx, make([]byte, 320), 20)
+
+	if got := testutil.ToFloat64(m.FramesRejected.WithLabelValues("misaligned_length")); got != 1 {
+		t.Errorf("frames_rejected_total{misaligned_length} = %v, want 1", got)
+	}
+	if got := h.GetSegmentMetrics().AudioDurationMs; got != 10 {
+		t.Errorf("audioMs = %d, want 10", got)
+	}
+}
+
+func TestHandler_SendAudio_RejectsOddLengthLinear16(t *testing.T) {
+	h, _, m := newTestHandler(t, DefaultConfig())
+	ctx := context.Background()
+
+	if err := h.SendAudio(ctx, make([]byte, 321), 0); err != nil {
+		t.Fatalf("SendAudio: %v", err)
+	}
+	if err := h.SendAudio(ctx, make([]byte, 320), 20); err != nil {
+		t.Fatalf("SendAudio: %v", err)
+	}
+
+	if got := h.adapter.(*fakeAdapter).sentFrames(); got != 1 {
+		t.Errorf("forwarded %d frames, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.FramesRejected.WithLabelValues("misaligned_length")); got != 1 {
+		t.Errorf("frames_rejected_total{misaligned_length} = %v, want 1", got)
+	}
+	if got := h.GetSegmentMetrics().AudioBytes; got != 320 {
+		t.Errorf("audioBytes = %d, want 320", got)
+	}
+	if h.IsSegmentDropped() {
+		t.Error("drop-frame policy should keep the segment")
+	}
+}
+
+func TestHandler_SendAudio_RejectsOversizedFrame(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxFrameBytes = 640
+	h, _, m := newTestHandler(t, cfg)
+	ctx := context.Background()
+
+	if err := h.SendAudio(ctx, make([]byte, 4<<20), 0); err != nil {
+		t.Fatalf("SendAudio: %v", err)
+	}
+	if err := h.SendAudio(ctx, make([]byte, 640), 20); err != nil {
+		t.Fatalf("SendAudio: %v", err)
+	}
+
+	if got := h.adapter.(*fakeAdapter).sentFrames(); got != 1 {
+		t.Errorf("forwarded %d frames, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.FramesOversized); got != 1 {
+		t.Errorf("frames_oversized_total = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.FramesRejected.WithLabelValues("oversized")); got != 1 {
+		t.Errorf("frames_rejected_total{oversized} = %v, want 1", got)
+	}
+	if got := h.GetSegmentMetrics().AudioBytes; got != 640 {
+		t.Errorf("audioBytes = %d, want 640", got)
+	}
+}
+
+// blockingAdapter's sends never return until unblock is closed, like a
+// stalled provider stream.
+type blockingAdapter struct {
+	fakeAdapter
+	unblock chan struct{}
+}
+
+func (a *blockingAdapter) SendAudio(context.Context, []byte) error {
+	<-a.unblock
+	return nil
+}
+
+func TestHandler_SendAudio_TimesOutOnStalledAdapter(t *testing.T) {
+	adapter := &blockingAdapter{unblock: make(chan struct{})}
+	defer close(adapter.unblock)
+	gen := segment.New()
+	h := NewHandlerWithConfig(adapter, &fakePublisher{}, gen, "int-1", "tenant-1", gen.Next("int-1"),
+		Config{SendTimeout: 20 * time.Millisecond})
+	h.SetMetrics(metrics.New(prometheus.NewRegistry()))
+
+	start := time.Now()
+	err := h.SendAudio(context.Background(), make([]byte, 320), 0)
+	if !errors.Is(err, ErrSendTimeout) {
+		t.Fatalf("expected ErrSendTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("send took %s, expected it to fail fast", elapsed)
+	}
+	if !h.IsSegmentDropped() || h.GetDropReason() != DropSTTSendTimeout {
+		t.Errorf("expected segment dropped with stt_send_timeout, got dropped=%v reason=%s",
+			h.IsSegmentDropped(), h.GetDropReason())
+	}
+}
+
+func TestHandler_SendAudio_OddLengthAllowedForMulaw(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Encoding = "MULAW"
+	h, _, _ := newTestHandler(t, cfg)
+
+	h.SendAudio(context.Background(), make([]byte, 161), 0)
+	if got := h.adapter.(*fakeAdapter).sentFrames(); got != 1 {
+		t.Errorf("forwarded %d frames, want 1", got)
+	}
+}
+
+func TestHandler_SendAudio_RejectsRegressingOffset(t *testing.T) {
+	h, _, m := newTestHandler(t, DefaultConfig())
+	ctx := context.Background()
+
+	h.SendAudio(ctx, make([]byte, 320), 100)
+	h.SendAudio(ctx, make([]byte, 320), 80)
+	h.SendAudio(ctx, make([]byte, 320), 100)
+
+	if got := h.adapter.(*fakeAdapter).sentFrames(); got != 2 {
+		t.Errorf("forwarded %d frames, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.FramesRejected.WithLabelValues("offset_regression")); got != 1 {
+		t.Errorf("frames_rejected_total{offset_regression} = %v, want 1", got)
+	}
+}
+
+func TestHandler_SendAudio_DropSegmentPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FrameRejection = RejectDropSegment
+	h, pub, m := newTestHandler(t, cfg)
+	var dropped []DropReason
+	h.SetSegmentDropCallback(func(_ string, reason DropReason) { dropped = append(dropped, reason) })
+
+	h.SendAudio(context.Background(), make([]byte, 3), 0)
+	if !h.IsSegmentDropped() {
+		t.Fatal("expected segment to be dropped")
+	}
+	if got := testutil.ToFloat64(m.SegmentsDropped.WithLabelValues("invalid_frame")); got != 1 {
+		t.Errorf("segments_dropped_total{invalid_frame} = %v, want 1", got)
+	}
+	if len(dropped) != 1 || dropped[0] != DropInvalidFrame {
+		t.Errorf("drop callback got %v, want [invalid_frame]", dropped)
+	}
+
+	h.OnFinal("hello", 0.9)
+	if _, finals := pub.counts(); finals != 0 {
+		t.Errorf("published %d finals for dropped segment", finals)
+	}
+}
+
+func TestConfig_AudioDurationMs(t *testing.T) {
+	cfg := DefaultConfig() // 8kHz 16-bit mono: 16000 bytes/s
+
+	tests := []struct {
+		bytes int64
+		want  int64
+	}{
+		{0, 0},
+		{320, 20},
+		{16000, 1000},
+		{24000, 1500},
+	}
+	for _, tt := range tests {
+		if got := cfg.AudioDurationMs(tt.bytes); got != tt.want {
+			t.Errorf("AudioDurationMs(%d) = %d, want %d", tt.bytes, got, tt.want)
+		}
+	}
+
+	cfg.Encoding = "MULAW" // 8kHz 8-bit mono: 8000 bytes/s
+	if got := cfg.AudioDurationMs(16000); got != 2000 {
+		t.Errorf("MULAW AudioDurationMs = %d, want 2000", got)
+	}
+
+	cfg.Encoding = "OGG_OPUS"
+	if got := cfg.AudioDurationMs(16000); got != 0 {
+		t.Errorf("compressed AudioDurationMs = %d, want 0", got)
+	}
+}
+
+func TestConfig_AudioBytes(t *testing.T) {
+	tests := []struct {
+		encoding string
+		rate     int
+		channels int
+		d        time.Duration
+		want     int64
+	}{
+		{"LINEAR16", 8000, 1, time.Second, 16000},
+		{"MULAW", 8000, 1, time.Second, 8000},
+		{"mulaw", 8000, 1, 20 * time.Millisecond, 160},
+		{"LINEAR16", 16000, 2, 100 * time.Millisecond, 6400},
+		{"LINEAR16", 8000, 1, 0, 0},
+		{"OGG_OPUS", 16000, 1, time.Second, 0},
+	}
+	for _, tt := range tests {
+		cfg := Config{Encoding: tt.encoding, SampleRateHz: tt.rate, Channels: tt.channels}
+		got := cfg.AudioBytes(tt.d)
+		if got != tt.want {
+			t.Errorf("%s/%d/%dch AudioBytes(%s) = %d, want %d", tt.encoding, tt.rate, tt.channels, tt.d, got, tt.want)
+		}
+		if got > 0 && cfg.AudioDurationMs(got) != tt.d.Milliseconds() {
+			t.Errorf("%s: AudioDurationMs(%d) = %d, want %d", tt.encoding, got, cfg.AudioDurationMs(got), tt.d.Milliseconds())
+		}
+	}
+}
+
+func TestHandler_GetSegmentMetrics_AudioDuration(t *testing.T) {
+	h, _, _ := newTestHandler(t, DefaultConfig())
+	h.SendAudio(context.Background(), make([]byte, 8000), 0)
+
+	if got := h.GetSegmentMetrics().AudioDurationMs; got != 500 {
+		t.Errorf("AudioDurationMs = %d, want 500", got)
+	}
+}
+
+func TestHandler_GetSegmentMetrics_MulawAudioDuration(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Encoding = "MULAW"
+	h, _, _ := newTestHandler(t, cfg)
+	for i := range 5 {
+		h.SendAudio(context.Background(), make([]byte, 160), int64(i*20)) // 20ms RTP packets
+	}
+
+	m := h.GetSegmentMetrics()
+	if m.AudioBytes != 800 || m.AudioDurationMs != 100 {
+		t.Errorf("audioBytes=%d audioMs=%d, want 800 bytes of 100ms", m.AudioBytes, m.AudioDurationMs)
+	}
+	if got := h.Summary().AudioDurationMs; got != 100 {
+		t.Errorf("summary AudioDurationMs = %d, want 100", got)
+	}
+}
+
+func TestHandler_SendAudio_MaxFrameDuration(t *testing.T) {
+	tests := []struct {
+		encoding string
+		ok, over int // Frame sizes at and above 100ms of audio
+	}{
+		{"LINEAR16", 1600, 1602},
+		{"MULAW", 800, 801},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.Encoding = tt.encoding
+		cfg.MaxFrameDuration = 100 * time.Millisecond
+		h, _, m := newTestHandler(t, cfg)
+		ctx := context.Background()
+
+		h.SendAudio(ctx, make([]byte, tt.ok), 0)
+		h.SendAudio(ctx, make([]byte, tt.over), 100)
+
+		if got := h.adapter.(*fakeAdapter).sentFrames(); got != 1 {
+			t.Errorf("%s: forwarded %d frames, want 1", tt.encoding, got)
+		}
+		if got := testutil.ToFloat64(m.FramesOversized); got != 1 {
+			t.Errorf("%s: frames_oversized_total = %v, want 1", tt.encoding, got)
+		}
+	}
+}
+
+func TestHandler_PartialDebounce_FinalFlushesLatestPartial(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PartialDebounce = time.Hour
+	h, pub, m := newTestHandler(t, cfg)
+
+	h.OnPartial("I")
+	h.OnPartial("I want")
+	h.OnPartial("I want to")
+	if partials, _ := pub.counts(); partials != 0 {
+		t.Fatalf("published %d partials inside the debounce window", partials)
+	}
+	if got := testutil.ToFloat64(m.PartialsCoalesced); got != 2 {
+		t.Errorf("partials_coalesced_total = %v, want 2", got)
+	}
+
+	h.OnFinal("I want to cancel", 0.9)
+	partials, finals := pub.counts()
+	if partials != 1 || finals != 1 {
+		t.Fatalf("published partials=%d finals=%d, want 1/1", partials, finals)
+	}
+	if got := pub.partials[0].Text; got != "I want to" {
+		t.Errorf("flushed partial = %q, want latest", got)
+	}
+}
+
+func TestHandler_PartialDebounce_PublishesAfterWindow(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PartialDebounce = 10 * time.Millisecond
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnPartial("hello")
+	h.OnPartial("hello there")
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if partials, _ := pub.counts(); partials > 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	partials, _ := pub.counts()
+	if partials != 1 || pub.partials[0].Text != "hello there" {
+		t.Errorf("published %d partials (%v), want latest only", partials, pub.partials)
+	}
+}
+
+func TestHandler_PartialDebounce_DropDiscardsPending(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PartialDebounce = time.Hour
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnPartial("hello")
+	h.DropSegment(DropClientCancel)
+	h.Close()
+
+	if partials, _ := pub.counts(); partials != 0 {
+		t.Errorf("published %d partials for dropped segment", partials)
+	}
+}
+
+func TestHandler_PartialMinCharsAndDelta(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PartialMinChars = 3
+	cfg.PartialMinDelta = 4
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnPartial("I")              // too short
+	h.OnPartial("I want")         // published
+	h.OnPartial("I want t")       // grew by 2
+	h.OnPartial("I want to")      // grew by 3
+	h.OnPartial("I want to stop") // grew by 8
+	h.OnFinal("I want to stop", 0.9)
+
+	partials, finals := pub.counts()
+	if partials != 2 || finals != 1 {
+		t.Fatalf("published partials=%d finals=%d, want 2/1", partials, finals)
+	}
+	if pub.partials[0].Text != "I want" || pub.partials[1].Text != "I want to stop" {
+		t.Errorf("unexpected published partials: %v", pub.partials)
+	}
+	if got := h.GetSegmentMetrics().PartialCount; got != 5 {
+		t.Errorf("PartialCount = %d, want 5 (filtered partials still count as received)", got)
+	}
+}
+
+func TestHandler_PartialMinDelta_ResetsPerSegment(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PartialMinDelta = 5
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnPartial("hello world")
+	h.OnEndOfUtterance()
+	h.OnPartial("hi there")
+
+	if partials, _ := pub.counts(); partials != 2 {
+		t.Errorf("published %d partials, want 2", partials)
+	}
+}
+
+func TestHandler_OnFinalAlternatives(t *testing.T) {
+	cfg := DefaultConfig()
+	redactor, _ := transform.NewRegexRedactor([]string{`\d{4}`})
+	cfg.Transforms = transform.Chain{redactor}
+	h, pub, _ := newTestHandler(t, cfg)
+
+	h.OnFinalAlternatives([]stt.Alternative{
+		{Text: "pin is 1234", Confidence: 0.8},
+		{Text: "pin is 1235", Confidence: 0.3},
+	})
+
+	_, finals := pub.counts()
+	if finals != 1 {
+		t.Fatalf("published %d finals, want 1", finals)
+	}
+	ev := pub.finals[0]
+	if ev.Text != "pin is [REDACTED]" || ev.Confidence != 0.8 {
+		t.Errorf("top-level text/confidence = %q/%v, want best alternative", ev.Text, ev.Confidence)
+	}
+	if len(ev.Alternatives) != 2 || ev.Alternatives[1].Text != "pin is [REDACTED]" || ev.Alternatives[1].Confidence != 0.3 {
+		t.Errorf("alternatives = %v, want both redacted in order", ev.Alternatives)
+	}
+}
+
+func TestHandler_OnFinal_SingleAlternative(t *testing.T) {
+	h, pub, _ := newTestHandler(t, DefaultConfig())
+	h.OnFinal("hello", 0.9)
+
+	if alts := pub.finals[0].Alternatives; len(alts) != 1 || alts[0].Text != "hello" || alts[0].Confidence != 0.9 {
+		t.Errorf("alternatives = %v, want the single final", alts)
+	}
+}
+
+func TestHandler_TranscriptCallback(t *testing.T) {
+	h, _, _ := newTestHandler(t, DefaultConfig())
+	var got []any
+	h.SetTranscriptCallback(func(event any) { got = append(got, event) })
+
+	h.OnPartial("hello")
+	h.OnFinal("hello world", 0.9)
+
+	if len(got) != 2 {
+		t.Fatalf("callback received %d events, want 2", len(got))
+	}
+	if _, ok := got[0].(models.TranscriptPartial); !ok {
+		t.Errorf("first event = %T, want TranscriptPartial", got[0])
+	}
+	if final, ok := got[1].(models.TranscriptFinal); !ok || final.Text != "hello world" {
+		t.Errorf("second event = %#v, want the final", got[1])
+	}
+}
+
+func TestHandler_FinalizeFromPartial(t *testing.T) {
+	h, pub, _ := newTestHandler(t, DefaultConfig())
+
+	h.OnPartial("hello")
+	h.OnPartial("hello wor")
+
+	if !h.FinalizeFromPartial(0.4) {
+		t.Fatal("FinalizeFromPartial returned false with a partial seen")
+	}
+	if h.GetSegmentState() != segment.StateFinalEmitted {
+		t.Errorf("state = %s, want FINAL_EMITTED", h.GetSegmentState())
+	}
+	if h.FinalizeFromPartial(0.4) {
+		t.Error("second FinalizeFromPartial should not emit another final")
+	}
+
+	if _, finals := pub.counts(); finals != 1 {
+		t.Fatalf("published %d finals, want 1", finals)
+	}
+	if f := pub.finals[0]; f.Text != "hello wor" || f.Confidence != 0.4 {
+		t.Errorf("final = %q (%v), want the latest partial", f.Text, f.Confidence)
+	}
+}
+
+func TestHandler_FinalizeFromPartial_NoPartial(t *testing.T) {
+	h, pub, _ := newTestHandler(t, DefaultConfig())
+
+	if h.FinalizeFromPartial(0) {
+		t.Error("FinalizeFromPartial returned true without a partial")
+	}
+	if _, finals := pub.counts(); finals != 0 {
+		t.Errorf("published %d finals, want 0", finals)
+	}
+	if h.GetSegmentState() != segment.StateOpen {
+		t.Errorf("state = %s, want OPEN", h.GetSegmentState())
+	}
+}
+
+func TestHandler_FinalizeFromPartial_ResetsPerSegment(t *testing.T) {
+	h, pub, _ := newTestHandler(t, DefaultConfig())
+
+	h.OnPartial("first")
+	h.OnFinal("first utterance", 0.9)
+	h.OnEndOfUtterance()
+
+	if h.FinalizeFromPartial(0) {
+		t.Error("partial from the previous segment should not be finalized")
+	}
+	if _, finals := pub.counts(); finals != 1 {
+		t.Errorf("published %d finals, want 1", finals)
+	}
+}
+
+func TestHandler_FinalizeCurrentSegment(t *testing.T) {
+	h, pub, _ := newTestHandler(t, DefaultConfig())
+	oldSegmentId := h.GetSegmentId()
+
+	h.OnPartial("hello wor")
+	if !h.FinalizeCurrentSegment() {
+		t.Fatal("FinalizeCurrentSegment returned false with a partial seen")
+	}
+
+	if h.GetSegmentId() == oldSegmentId || h.GetSegmentState() != segment.StateOpen {
+		t.Errorf("segment = %s (%s), want a new OPEN segment", h.GetSegmentId(), h.GetSegmentState())
+	}
+	if _, finals := pub.counts(); finals != 1 {
+		t.Fatalf("published %d finals, want 1", finals)
+	}
+	if f := pub.finals[0]; f.Text != "hello wor" || f.SegmentID != oldSegmentId {
+		t.Errorf("final = %q on %s, want the latest partial on %s", f.Text, f.SegmentID, oldSegmentId)
+	}
+
+	// A segment without partials is closed and replaced without a final
+	if h.FinalizeCurrentSegment() {
+		t.Error("FinalizeCurrentSegment published a final for a segment without partials")
+	}
+	if got := h.Summary().SegmentsCreated; got != 3 {
+		t.Errorf("segments created = %d, want 3", got)
+	}
+}
+
+func TestDropReason_String(t *testing.T) {
+	seen := map[string]bool{}
+	for r := DropEmptyFinal; r <= DropClientCancel; r++ {
+		name := r.String()
+		if name == "" || name == "unknown" || seen[name] {
+			t.Errorf("DropReason(%d) = %q, want a distinct label", r, name)
+		}
+		seen[name] = true
+	}
+	if got := DropReason(0).String(); got != "" {
+		t.Errorf("zero DropReason = %q, want empty", got)
+	}
+	if got := DropReason(99).String(); got != "unknown" {
+		t.Errorf("DropReason(99) = %q, want unknown", got)
+	}
+}
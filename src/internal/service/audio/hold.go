@@ -0,0 +1,121 @@
+package audio
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"ai-speech-ingress-service/internal/service/stt"
+)
+
+// holdFinal holds a final for FinalHold instead of publishing it, so a
+// provider that finalizes mid-sentence doesn't split the segment. A final
+// already held is treated as premature and carried into this one.
+func (h *Handler) holdFinal(alternatives []stt.Alternative) {
+	segmentId := h.lifecycle.SegmentId()
+
+	h.holdMu.Lock()
+	defer h.holdMu.Unlock()
+	if h.heldFinal != nil {
+		h.carryHeldFinalLocked()
+	}
+	h.heldFinal = alternatives
+	h.heldSegmentId = segmentId
+	h.holdTimer = time.AfterFunc(h.config.FinalHold, func() { h.releaseHeldFinal() })
+}
+
+// releaseHeldFinal publishes the held final, if any, ahead of its window.
+// Returns whether there was one.
+func (h *Handler) releaseHeldFinal() bool {
+	h.holdMu.Lock()
+	alternatives, segmentId := h.heldFinal, h.heldSegmentId
+	h.clearHeldFinalLocked()
+	h.holdMu.Unlock()
+	if alternatives == nil {
+		return false
+	}
+
+	h.mu.RLock()
+	mt := h.metrics
+	h.mu.RUnlock()
+	mt.FinalHolds.WithLabelValues("published").Inc()
+	h.emitFinal(segmentId, alternatives)
+	return true
+}
+
+// continueHeldFinal is called when a partial arrives during the hold: the
+// speaker kept going, so the held final was premature. Its text is carried
+// into the segment's eventual final and the segment stays open.
+func (h *Handler) continueHeldFinal() {
+	h.holdMu.Lock()
+	segmentId := h.heldSegmentId
+	held := h.heldFinal != nil
+	if held {
+		h.carryHeldFinalLocked()
+	}
+	h.holdMu.Unlock()
+	if !held {
+		return
+	}
+
+	h.mu.RLock()
+	mt := h.metrics
+	h.mu.RUnlock()
+	mt.FinalHolds.WithLabelValues("continued").Inc()
+	log.Printf("Premature final held back: interactionId=%s segmentId=%s", h.interactionId, segmentId)
+}
+
+// discardHeldFinal drops the held final without publishing it, along with
+// any carried text.
+func (h *Handler) discardHeldFinal() {
+	h.holdMu.Lock()
+	defer h.holdMu.Unlock()
+	h.clearHeldFinalLocked()
+	h.carriedText = ""
+}
+
+// withCarriedText prefixes the text of premature finals to alternatives.
+func (h *Handler) withCarriedText(alternatives []stt.Alternative) []stt.Alternative {
+	h.holdMu.Lock()
+	carried := h.carriedText
+	h.holdMu.Unlock()
+	if carried == "" {
+		return alternatives
+	}
+
+	out := make([]stt.Alternative, len(alternatives))
+	for i, alt := range alternatives {
+		out[i] = stt.Alternative{Text: joinText(carried, alt.Text), Confidence: alt.Confidence}
+	}
+	if len(out) == 0 {
+		out = []stt.Alternative{{Text: carried}}
+	}
+	return out
+}
+
+// carryHeldFinalLocked moves the held final's text into carriedText. Callers
+// must hold h.holdMu.
+func (h *Handler) carryHeldFinalLocked() {
+	if len(h.heldFinal) > 0 {
+		h.carriedText = joinText(h.carriedText, h.heldFinal[0].Text)
+	}
+	h.clearHeldFinalLocked()
+}
+
+// clearHeldFinalLocked forgets the held final. Callers must hold h.holdMu.
+func (h *Handler) clearHeldFinalLocked() {
+	if h.holdTimer != nil {
+		h.holdTimer.Stop()
+		h.holdTimer = nil
+	}
+	h.heldFinal = nil
+	h.heldSegmentId = ""
+}
+
+func joinText(a, b string) string {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + " " + b
+}
@@ -0,0 +1,66 @@
+package audio
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"ai-speech-ingress-service/internal/models"
+)
+
+// acceptPartial applies the PartialMinChars/PartialMinDelta filter to the raw
+// partial text, recording it as the last published partial if it passes.
+// Callers must hold h.mu.
+func (h *Handler) acceptPartial(text string) bool {
+	n := utf8.RuneCountInString(text)
+	if n < h.config.PartialMinChars {
+		return false
+	}
+	if h.config.PartialMinDelta > 0 && n-utf8.RuneCountInString(h.lastPublishedPartial) < h.config.PartialMinDelta {
+		return false
+	}
+	h.lastPublishedPartial = text
+	return true
+}
+
+// queuePartial publishes ev immediately, or holds it for the debounce window
+// when coalescing is enabled. A partial already pending in the window is
+// replaced by ev, so only the latest partial per window is published.
+func (h *Handler) queuePartial(ev models.TranscriptPartial) {
+	if h.config.PartialDebounce <= 0 {
+		h.publishPartial(ev)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.pendingPartial != nil {
+		h.metrics.PartialsCoalesced.Inc()
+	} else {
+		h.partialTimer = time.AfterFunc(h.config.PartialDebounce, h.flushPartial)
+	}
+	h.pendingPartial = &ev
+}
+
+// flushPartial publishes the pending partial, if any. Holding flushMu across
+// the publish guarantees a final published after flushPartial returns is never
+// overtaken by the partial it supersedes.
+func (h *Handler) flushPartial() {
+	h.flushMu.Lock()
+	defer h.flushMu.Unlock()
+	if ev := h.takePendingPartial(); ev != nil {
+		h.publishPartial(*ev)
+	}
+}
+
+// takePendingPartial clears and returns the pending partial, stopping its timer.
+func (h *Handler) takePendingPartial() *models.TranscriptPartial {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ev := h.pendingPartial
+	h.pendingPartial = nil
+	if h.partialTimer != nil {
+		h.partialTimer.Stop()
+		h.partialTimer = nil
+	}
+	return ev
+}
@@ -0,0 +1,84 @@
+package audio
+
+import (
+	"log"
+	"time"
+)
+
+// rateWindow is how long the handler accumulates received bytes before it
+// measures the stream's byte rate.
+const rateWindow = 5 * time.Second
+
+// rateTolerance is how far, as a fraction of the realtime rate, a window's
+// byte rate may stray before it is counted as a slow or fast sender.
+const rateTolerance = 0.25
+
+// observeRateLocked counts n received bytes towards the current window. Once
+// the window has run for rateWindow it returns the window's byte rate, and
+// the change from the previously reported rate, and starts the next window.
+// Callers must hold h.mu.
+func (h *Handler) observeRateLocked(n int) (rate, delta float64, measured bool) {
+	if h.rateStopped {
+		return 0, 0, false
+	}
+	h.rateWindowBytes += int64(n)
+	elapsed := h.config.Clock.Now().Sub(h.rateWindowStart)
+	if elapsed < rateWindow {
+		return 0, 0, false
+	}
+
+	rate = float64(h.rateWindowBytes) / elapsed.Seconds()
+	delta = rate - h.bytesPerSecond
+	h.bytesPerSecond = rate
+	h.rateWindowStart = h.rateWindowStart.Add(elapsed)
+	h.rateWindowBytes = 0
+	return rate, delta, true
+}
+
+// recordRate reports a measured window: the stream's change in rate goes
+// into the throughput gauge shared by all streams, and rates off realtime by
+// more than rateTolerance are counted as anomalies. Compressed encodings have
+// no realtime rate and are never flagged.
+func (h *Handler) recordRate(rate, delta float64) {
+	h.mu.RLock()
+	mt := h.metrics
+	h.mu.RUnlock()
+	mt.AudioBytesPerSecond.Add(delta)
+
+	expected := float64(h.config.AudioBytes(time.Second))
+	if expected <= 0 {
+		return
+	}
+	direction := ""
+	switch {
+	case rate < expected*(1-rateTolerance):
+		direction = "slow"
+	case rate > expected*(1+rateTolerance):
+		direction = "fast"
+	default:
+		return
+	}
+	mt.AudioRateAnomalies.WithLabelValues(direction).Inc()
+	log.Printf("Audio rate anomaly: interactionId=%s direction=%s bytesPerSecond=%.0f expected=%.0f",
+		h.interactionId, direction, rate, expected)
+}
+
+// stopRate removes the stream from the throughput gauge. Later audio is no
+// longer measured.
+func (h *Handler) stopRate() {
+	h.mu.Lock()
+	reported := h.bytesPerSecond
+	h.rateStopped = true
+	h.bytesPerSecond = 0
+	mt := h.metrics
+	h.mu.Unlock()
+	mt.AudioBytesPerSecond.Sub(reported)
+}
+
+// BytesPerSecond returns the stream's byte rate over its last complete
+// window, or 0 before the first one.
+func (h *Handler) BytesPerSecond() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.bytesPerSecond
+}
@@ -0,0 +1,80 @@
+package audio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// sendPaced sends n 20ms frames (320 bytes of 8kHz LINEAR16), advancing
+// clock by interval before each.
+func sendPaced(h *Handler, clock *ManualClock, n int, interval time.Duration) {
+	for range n {
+		clock.Advance(interval)
+		h.SendAudio(context.Background(), make([]byte, 320), 0)
+	}
+}
+
+func TestHandler_ByteRate(t *testing.T) {
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	cfg := DefaultConfig()
+	cfg.Clock = clock
+	h, _, m := newTestHandler(t, cfg)
+	anomalies := func(direction string) float64 {
+		return testutil.ToFloat64(m.AudioRateAnomalies.WithLabelValues(direction))
+	}
+
+	// Realtime: 16000 bytes/s, no rate until the first window completes
+	sendPaced(h, clock, 249, 20*time.Millisecond)
+	if got := h.BytesPerSecond(); got != 0 {
+		t.Fatalf("bytesPerSecond = %v before the first window", got)
+	}
+	sendPaced(h, clock, 1, 20*time.Millisecond)
+	if got := h.BytesPerSecond(); got != 16000 {
+		t.Errorf("bytesPerSecond = %v, want 16000", got)
+	}
+	if got := testutil.ToFloat64(m.AudioBytesPerSecond); got != 16000 {
+		t.Errorf("audio_bytes_per_second = %v, want 16000", got)
+	}
+	if anomalies("slow")+anomalies("fast") != 0 {
+		t.Error("realtime audio flagged as an anomaly")
+	}
+
+	// Half speed
+	sendPaced(h, clock, 125, 40*time.Millisecond)
+	if got := h.BytesPerSecond(); got != 8000 || anomalies("slow") != 1 {
+		t.Errorf("bytesPerSecond = %v and %v slow windows, want 8000 and 1", got, anomalies("slow"))
+	}
+
+	// Double speed
+	sendPaced(h, clock, 500, 10*time.Millisecond)
+	if got := h.BytesPerSecond(); got != 32000 || anomalies("fast") != 1 {
+		t.Errorf("bytesPerSecond = %v and %v fast windows, want 32000 and 1", got, anomalies("fast"))
+	}
+	if got := testutil.ToFloat64(m.AudioBytesPerSecond); got != 32000 {
+		t.Errorf("audio_bytes_per_second = %v, want the stream's latest rate", got)
+	}
+
+	h.Close()
+	if got := testutil.ToFloat64(m.AudioBytesPerSecond); got != 0 {
+		t.Errorf("audio_bytes_per_second = %v after Close, want 0", got)
+	}
+}
+
+func TestHandler_ByteRate_CompressedNotFlagged(t *testing.T) {
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	cfg := DefaultConfig()
+	cfg.Clock = clock
+	cfg.Encoding = "OGG_OPUS"
+	h, _, m := newTestHandler(t, cfg)
+
+	sendPaced(h, clock, 250, 100*time.Millisecond)
+	if h.BytesPerSecond() == 0 {
+		t.Error("expected the rate to be measured")
+	}
+	if got := testutil.CollectAndCount(m.AudioRateAnomalies); got != 0 {
+		t.Errorf("compressed audio flagged as %d anomalies", got)
+	}
+}
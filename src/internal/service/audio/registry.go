@@ -0,0 +1,110 @@
+package audio
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// StreamStatus describes an active stream for debugging.
+type StreamStatus struct {
+	InteractionID   string  `json:"interactionId"`
+	TenantID        string  `json:"tenantId"`
+	SegmentID       string  `json:"segmentId"`
+	State           string  `json:"state"`
+	AudioBytes      int64   `json:"audioBytes"`
+	AudioDurationMs int64   `json:"audioDurationMs"`
+	PartialCount    int     `json:"partialCount"`
+	DurationMs      int64   `json:"durationMs"`
+	BytesPerSecond  float64 `json:"bytesPerSecond"`
+}
+
+// Registry tracks the handlers of all active streams.
+// Thread-safe for concurrent access.
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[*Handler]struct{}
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[*Handler]struct{})}
+}
+
+// Register adds a handler to the registry.
+func (r *Registry) Register(h *Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[h] = struct{}{}
+}
+
+// Unregister removes a handler from the registry.
+func (r *Registry) Unregister(h *Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.handlers, h)
+}
+
+// Len returns the number of active streams.
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.handlers)
+}
+
+// Snapshot returns the status of all active streams, ordered by interactionId.
+func (r *Registry) Snapshot() []StreamStatus {
+	r.mu.RLock()
+	handlers := make([]*Handler, 0, len(r.handlers))
+	for h := range r.handlers {
+		handlers = append(handlers, h)
+	}
+	r.mu.RUnlock()
+
+	out := make([]StreamStatus, 0, len(handlers))
+	for _, h := range handlers {
+		out = append(out, statusOf(h))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].InteractionID < out[j].InteractionID })
+	return out
+}
+
+// Lookup returns the status of the interaction's active stream and the number
+// of its active streams. An interaction can have several streams open (e.g. a
+// reconnect racing the old stream); the most recently started one is reported.
+func (r *Registry) Lookup(interactionId string) (StreamStatus, int, bool) {
+	r.mu.RLock()
+	var latest *Handler
+	var latestStart time.Time
+	count := 0
+	for h := range r.handlers {
+		if h.GetInteractionId() != interactionId {
+			continue
+		}
+		count++
+		if start := h.startedAt(); latest == nil || start.After(latestStart) {
+			latest, latestStart = h, start
+		}
+	}
+	r.mu.RUnlock()
+
+	if latest == nil {
+		return StreamStatus{}, 0, false
+	}
+	return statusOf(latest), count, true
+}
+
+func statusOf(h *Handler) StreamStatus {
+	m := h.GetSegmentMetrics()
+	return StreamStatus{
+		InteractionID:   h.GetInteractionId(),
+		TenantID:        h.GetTenantId(),
+		SegmentID:       h.GetSegmentId(),
+		State:           h.GetSegmentState().String(),
+		AudioBytes:      m.AudioBytes,
+		AudioDurationMs: m.AudioDurationMs,
+		PartialCount:    m.PartialCount,
+		DurationMs:      h.GetStreamDuration().Milliseconds(),
+		BytesPerSecond:  h.BytesPerSecond(),
+	}
+}
@@ -0,0 +1,49 @@
+package audio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegistry_RegisterSnapshotUnregister(t *testing.T) {
+	r := NewRegistry()
+	h := NewHandler(nil, nil, nil, "int-1", "tenant-1", "int-1-seg-1")
+
+	r.Register(h)
+	if r.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", r.Len())
+	}
+
+	snap := r.Snapshot()
+	if len(snap) != 1 {
+		t.Fatalf("snapshot len = %d, want 1", len(snap))
+	}
+	got := snap[0]
+	if got.InteractionID != "int-1" || got.TenantID != "tenant-1" || got.SegmentID != "int-1-seg-1" || got.State != "OPEN" {
+		t.Errorf("unexpected status: %+v", got)
+	}
+
+	r.Unregister(h)
+	if r.Len() != 0 {
+		t.Errorf("Len after unregister = %d, want 0", r.Len())
+	}
+}
+
+func TestRegistry_LookupMostRecentStream(t *testing.T) {
+	r := NewRegistry()
+	older := NewHandler(nil, nil, nil, "int-1", "tenant-1", "int-1-seg-1")
+	newer := NewHandler(nil, nil, nil, "int-1", "tenant-1", "int-1-seg-2")
+	newer.streamStartedAt = older.streamStartedAt.Add(time.Second)
+	r.Register(older)
+	r.Register(newer)
+	r.Register(NewHandler(nil, nil, nil, "int-2", "tenant-1", "int-2-seg-1"))
+
+	got, streams, ok := r.Lookup("int-1")
+	if !ok || streams != 2 || got.SegmentID != "int-1-seg-2" {
+		t.Errorf("Lookup = %+v, %d, %v; want int-1-seg-2 of 2 streams", got, streams, ok)
+	}
+
+	if _, _, ok := r.Lookup("int-3"); ok {
+		t.Error("Lookup of unknown interaction succeeded")
+	}
+}
@@ -0,0 +1,120 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// errSenderStopped is returned for audio sent after the handler was closed.
+var errSenderStopped = errors.New("audio handler closed")
+
+// sendRequest is a frame handed to the sender goroutine.
+type sendRequest struct {
+	ctx   context.Context
+	audio []byte
+	done  chan error
+}
+
+// sendToAdapter forwards audio to the adapter, giving up after SendTimeout.
+// Adapters can't always honour ctx (gRPC stream sends don't), so with a
+// timeout the sends run one at a time on a long-lived sender goroutine. A
+// timed-out send drops the segment and cancels the adapter's context, which
+// aborts a send stuck on a gRPC stream.
+func (h *Handler) sendToAdapter(ctx context.Context, audio []byte) error {
+	if h.config.SendTimeout <= 0 {
+		return h.adapter.SendAudio(ctx, audio)
+	}
+
+	h.sendMu.Lock()
+	defer h.sendMu.Unlock()
+	if h.sendClosed {
+		return errSenderStopped
+	}
+	if h.sendQueue == nil {
+		h.sendQueue = make(chan sendRequest)
+		h.senderDone = make(chan struct{})
+		h.sendTimer = time.NewTimer(h.config.SendTimeout)
+		go h.runSender(h.sendQueue, h.senderDone)
+	} else {
+		h.sendTimer.Reset(h.config.SendTimeout)
+	}
+	defer h.sendTimer.Stop()
+
+	req := sendRequest{ctx: ctx, audio: audio, done: make(chan error, 1)}
+	select {
+	case h.sendQueue <- req:
+	case <-h.sendTimer.C:
+		return h.sendTimedOut()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-req.done:
+		return err
+	case <-h.sendTimer.C:
+		return h.sendTimedOut()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *Handler) sendTimedOut() error {
+	log.Printf("STT send timed out: interactionId=%s segmentId=%s timeout=%s",
+		h.interactionId, h.lifecycle.SegmentId(), h.config.SendTimeout)
+	h.DropSegment(DropSTTSendTimeout)
+	h.abortAdapter()
+	return ErrSendTimeout
+}
+
+// abortAdapter cancels the adapter's context. The stream can't recover from
+// a stuck send, so its recognition session is given up.
+func (h *Handler) abortAdapter() {
+	h.mu.RLock()
+	cancel := h.cancelAdapter
+	h.mu.RUnlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// runSender sends queued frames to the adapter until the queue is closed.
+func (h *Handler) runSender(queue <-chan sendRequest, done chan<- struct{}) {
+	defer close(done)
+	for req := range queue {
+		req.done <- h.adapter.SendAudio(req.ctx, req.audio)
+	}
+}
+
+// stopSender stops the sender goroutine, waiting for a send still in flight
+// so the adapter isn't closed while it sends. A send that is still stuck
+// after SendTimeout is aborted through the adapter's context; if the adapter
+// ignores that too, it is abandoned after another SendTimeout so the stream
+// can end.
+func (h *Handler) stopSender() {
+	h.sendMu.Lock()
+	h.sendClosed = true
+	queue, done := h.sendQueue, h.senderDone
+	h.sendMu.Unlock()
+	if queue == nil {
+		return
+	}
+	close(queue)
+
+	timer := time.NewTimer(h.config.SendTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+	log.Printf("STT send still in flight at close, aborting: interactionId=%s", h.interactionId)
+	h.abortAdapter()
+	timer.Reset(h.config.SendTimeout)
+	select {
+	case <-done:
+	case <-timer.C:
+		log.Printf("STT send ignored the abort, closing anyway: interactionId=%s", h.interactionId)
+	}
+}
@@ -0,0 +1,147 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"ai-speech-ingress-service/internal/observability/metrics"
+	"ai-speech-ingress-service/internal/service/segment"
+	"ai-speech-ingress-service/internal/service/stt"
+)
+
+// stallingAdapter's sends block until unblock is closed, or, if honourCtx is
+// set, until the context it was started with ends, like a gRPC stream. It
+// records how many sends ran at once and whether Close came while one was in
+// flight.
+type stallingAdapter struct {
+	fakeAdapter
+	unblock   chan struct{}
+	honourCtx bool
+	ctx       context.Context
+
+	mu             sync.Mutex
+	inflight       int
+	maxInflight    int
+	closedInflight bool
+}
+
+func (a *stallingAdapter) Start(ctx context.Context, _ stt.Callback) error {
+	a.ctx = ctx
+	return nil
+}
+
+func (a *stallingAdapter) SendAudio(context.Context, []byte) error {
+	a.mu.Lock()
+	a.inflight++
+	a.maxInflight = max(a.maxInflight, a.inflight)
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.inflight--
+		a.mu.Unlock()
+	}()
+
+	if !a.honourCtx {
+		<-a.unblock
+		return nil
+	}
+	select {
+	case <-a.unblock:
+		return nil
+	case <-a.ctx.Done():
+		return a.ctx.Err()
+	}
+}
+
+func (a *stallingAdapter) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closedInflight = a.inflight > 0
+	return nil
+}
+
+func newStallingHandler(t *testing.T, adapter *stallingAdapter, timeout time.Duration) *Handler {
+	t.Helper()
+	gen := segment.New()
+	h := NewHandlerWithConfig(adapter, &fakePublisher{}, gen, "int-1", "tenant-1", gen.Next("int-1"),
+		Config{SendTimeout: timeout})
+	h.SetMetrics(metrics.New(prometheus.NewRegistry()))
+	if err := h.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	return h
+}
+
+// closeWithin fails the test if h.Close takes longer than limit.
+func closeWithin(t *testing.T, h *Handler, limit time.Duration) {
+	t.Helper()
+	closed := make(chan struct{})
+	go func() {
+		h.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(limit):
+		t.Fatal("Close blocked on the stalled send")
+	}
+}
+
+func TestHandler_SendAudio_TimeoutAbortsStalledSend(t *testing.T) {
+	adapter := &stallingAdapter{unblock: make(chan struct{}), honourCtx: true}
+	defer close(adapter.unblock)
+	h := newStallingHandler(t, adapter, 200*time.Millisecond)
+
+	if err := h.SendAudio(context.Background(), make([]byte, 320), 0); !errors.Is(err, ErrSendTimeout) {
+		t.Fatalf("expected ErrSendTimeout, got %v", err)
+	}
+	// The next frame isn't sent alongside the stuck one
+	h.SendAudio(context.Background(), make([]byte, 320), 20)
+
+	// The timeout aborted the stuck send, so Close needn't wait out SendTimeout
+	closeWithin(t, h, 100*time.Millisecond)
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if adapter.maxInflight != 1 {
+		t.Errorf("%d sends ran at once, want 1", adapter.maxInflight)
+	}
+	if adapter.closedInflight {
+		t.Error("adapter closed while a send was in flight")
+	}
+}
+
+func TestHandler_Close_BoundedWhenSendIgnoresAbort(t *testing.T) {
+	adapter := &stallingAdapter{unblock: make(chan struct{})}
+	defer close(adapter.unblock)
+	h := newStallingHandler(t, adapter, 20*time.Millisecond)
+
+	if err := h.SendAudio(context.Background(), make([]byte, 320), 0); !errors.Is(err, ErrSendTimeout) {
+		t.Fatalf("expected ErrSendTimeout, got %v", err)
+	}
+	// Waits out SendTimeout twice, then gives up on the send
+	closeWithin(t, h, time.Second)
+}
+
+func TestHandler_SendAudio_AfterClose(t *testing.T) {
+	gen := segment.New()
+	h := NewHandlerWithConfig(&fakeAdapter{}, &fakePublisher{}, gen, "int-1", "tenant-1", gen.Next("int-1"),
+		Config{SendTimeout: time.Second})
+	h.SetMetrics(metrics.New(prometheus.NewRegistry()))
+
+	if err := h.SendAudio(context.Background(), make([]byte, 320), 0); err != nil {
+		t.Fatalf("SendAudio: %v", err)
+	}
+	h.Close()
+	if err := h.SendAudio(context.Background(), make([]byte, 320), 20); err == nil {
+		t.Error("expected an error for audio sent after Close")
+	}
+	if got := h.adapter.(*fakeAdapter).sentFrames(); got != 1 {
+		t.Errorf("forwarded %d frames, want 1", got)
+	}
+}
@@ -0,0 +1,40 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+)
+
+// leadingSilenceLocked reports whether a valid frame should be skipped as
+// silence that precedes the segment's first speech. On the frame where speech
+// starts it also returns the last skipped frame, to forward first so the onset
+// isn't clipped. Only LINEAR16 is measured; other encodings are never skipped.
+// Callers must hold h.mu.
+func (h *Handler) leadingSilenceLocked(audio []byte) (skip bool, preroll []byte) {
+	if !h.config.SilenceTrim || h.speechDetected || !strings.EqualFold(h.config.Encoding, "LINEAR16") {
+		return false, nil
+	}
+	if rms16(audio) < h.config.SilenceThreshold {
+		h.silencePreroll = append(h.silencePreroll[:0], audio...)
+		return true, nil
+	}
+	h.speechDetected = true
+	preroll, h.silencePreroll = h.silencePreroll, nil
+	return false, preroll
+}
+
+// rms16 returns the root mean square amplitude of little-endian 16-bit PCM,
+// on the 0-32768 sample scale.
+func rms16(audio []byte) float64 {
+	n := len(audio) / 2
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		v := float64(int16(binary.LittleEndian.Uint16(audio[2*i:])))
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(n))
+}
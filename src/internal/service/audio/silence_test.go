@@ -0,0 +1,134 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// pcm returns a 20ms 8kHz LINEAR16 frame alternating between +amplitude and
+// -amplitude, so its RMS is amplitude.
+func pcm(amplitude int16) []byte {
+	frame := make([]byte, 320)
+	for i := 0; i < len(frame)/2; i++ {
+		v := amplitude
+		if i%2 == 1 {
+			v = -amplitude
+		}
+		binary.LittleEndian.PutUint16(frame[2*i:], uint16(v))
+	}
+	return frame
+}
+
+// capturingAdapter records the audio of every forwarded frame.
+type capturingAdapter struct {
+	fakeAdapter
+	mu     sync.Mutex
+	frames [][]byte
+}
+
+func (a *capturingAdapter) SendAudio(_ context.Context, audio []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.frames = append(a.frames, audio)
+	return nil
+}
+
+func newSilenceTestHandler(t *testing.T, cfg Config) (*Handler, *capturingAdapter, func() float64) {
+	t.Helper()
+	h, _, m := newTestHandler(t, cfg)
+	adapter := &capturingAdapter{}
+	h.adapter = adapter
+	return h, adapter, func() float64 { return testutil.ToFloat64(m.SilenceFramesSkipped) }
+}
+
+func silenceTrimConfig() Config {
+	cfg := DefaultConfig()
+	cfg.SilenceTrim = true
+	cfg.SilenceThreshold = 500
+	return cfg
+}
+
+func TestRMS16(t *testing.T) {
+	tests := []struct {
+		audio []byte
+		want  float64
+	}{
+		{nil, 0},
+		{make([]byte, 320), 0},
+		{pcm(1000), 1000},
+		{pcm(-32768), 32768},
+	}
+	for _, tt := range tests {
+		if got := rms16(tt.audio); got != tt.want {
+			t.Errorf("rms16 = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestHandler_SilenceTrim_SkipsLeadingSilence(t *testing.T) {
+	h, adapter, skipped := newSilenceTestHandler(t, silenceTrimConfig())
+	ctx := context.Background()
+
+	h.SendAudio(ctx, pcm(0), 0)
+	h.SendAudio(ctx, pcm(100), 20)
+	lastQuiet := pcm(499)
+	h.SendAudio(ctx, lastQuiet, 40)
+	if len(adapter.frames) != 0 {
+		t.Fatalf("forwarded %d silent frames", len(adapter.frames))
+	}
+
+	speech := pcm(8000)
+	h.SendAudio(ctx, speech, 60)
+	// Once speech starts, silence is forwarded like any other audio
+	h.SendAudio(ctx, pcm(0), 80)
+
+	if len(adapter.frames) != 3 {
+		t.Fatalf("forwarded %d frames, want pre-roll, speech and trailing silence", len(adapter.frames))
+	}
+	if !bytes.Equal(adapter.frames[0], lastQuiet) || !bytes.Equal(adapter.frames[1], speech) {
+		t.Error("expected the last silent frame forwarded ahead of the speech")
+	}
+	if got := skipped(); got != 3 {
+		t.Errorf("audio_silence_frames_skipped_total = %v, want 3", got)
+	}
+	if got := h.GetSegmentMetrics().AudioBytes; got != 5*320 {
+		t.Errorf("audioBytes = %d, want all received audio counted", got)
+	}
+}
+
+func TestHandler_SilenceTrim_ResetsPerSegment(t *testing.T) {
+	h, adapter, skipped := newSilenceTestHandler(t, silenceTrimConfig())
+	ctx := context.Background()
+
+	h.SendAudio(ctx, pcm(8000), 0)
+	h.OnFinal("hello", 0.9)
+	h.OnEndOfUtterance()
+
+	h.SendAudio(ctx, pcm(0), 20)
+	h.SendAudio(ctx, pcm(0), 40)
+	if len(adapter.frames) != 1 || skipped() != 2 {
+		t.Errorf("forwarded %d frames and skipped %v, want the new segment's leading silence skipped",
+			len(adapter.frames), skipped())
+	}
+}
+
+func TestHandler_SilenceTrim_Bypassed(t *testing.T) {
+	disabled := silenceTrimConfig()
+	disabled.SilenceTrim = false
+	mulaw := silenceTrimConfig()
+	mulaw.Encoding = "MULAW"
+
+	for name, cfg := range map[string]Config{"disabled": disabled, "mulaw": mulaw} {
+		h, adapter, skipped := newSilenceTestHandler(t, cfg)
+		h.SendAudio(context.Background(), make([]byte, 160), 0)
+
+		if len(adapter.frames) != 1 || skipped() != 0 {
+			t.Errorf("%s: forwarded %d frames and skipped %v, want silence forwarded", name, len(adapter.frames), skipped())
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package audio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"ai-speech-ingress-service/internal/observability/metrics"
+	"ai-speech-ingress-service/internal/service/segment"
+	"ai-speech-ingress-service/internal/service/stt/mock"
+)
+
+// TestHandler_ConcurrentStress drives many handlers at once, each with audio,
+// partials and finals arriving from separate goroutines alongside the mock
+// adapter's own delayed callbacks. Run with -race to catch unsynchronized
+// state across segment transitions.
+func TestHandler_ConcurrentStress(t *testing.T) {
+	const numHandlers = 32
+	const framesPerHandler = 10
+
+	pub := &fakePublisher{}
+	m := metrics.New(prometheus.NewRegistry())
+	gen := segment.New()
+	ctx := context.Background()
+
+	handlers := make([]*Handler, numHandlers)
+	var wg sync.WaitGroup
+	for i := range handlers {
+		interactionId := fmt.Sprintf("int-%d", i)
+		h := NewHandlerWithConfig(mock.New(), pub, gen, interactionId, "tenant-1", gen.Next(interactionId), DefaultConfig())
+		h.SetMetrics(m)
+		if err := h.Start(ctx); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		handlers[i] = h
+
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := range framesPerHandler {
+				h.SendAudio(ctx, make([]byte, 320), int64(j*20))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := range framesPerHandler {
+				h.OnPartial(fmt.Sprintf("partial %d", j))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			h.OnFinal("final", 0.9)
+			h.OnEndOfUtterance()
+		}()
+	}
+	wg.Wait()
+
+	// Close returns once the mock's scheduled callbacks are delivered or
+	// cancelled
+	for _, h := range handlers {
+		h.Close()
+	}
+
+	var completed int
+	for _, h := range handlers {
+		sum := h.Summary()
+		if sum.SegmentsCompleted < 1 {
+			t.Errorf("%s: no segment completed", h.GetInteractionId())
+		}
+		if sum.SegmentsCompleted+sum.SegmentsDropped > sum.SegmentsCreated {
+			t.Errorf("%s: completed=%d dropped=%d exceed created=%d",
+				h.GetInteractionId(), sum.SegmentsCompleted, sum.SegmentsDropped, sum.SegmentsCreated)
+		}
+		if sum.AudioBytes != framesPerHandler*320 {
+			t.Errorf("%s: audioBytes = %d, want %d", h.GetInteractionId(), sum.AudioBytes, framesPerHandler*320)
+		}
+		completed += sum.SegmentsCompleted
+	}
+
+	if _, finals := pub.counts(); finals != completed {
+		t.Errorf("published finals = %d, handlers completed %d", finals, completed)
+	}
+	if got := testutil.ToFloat64(m.SegmentsCompleted); got != float64(completed) {
+		t.Errorf("segments_completed_total = %v, want %d", got, completed)
+	}
+}
+
+// BenchmarkHandler_Throughput measures frames per second through a handler,
+// with a partial per frame and a final and segment transition every 50 frames.
+func BenchmarkHandler_Throughput(b *testing.B) {
+	out := log.Writer()
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(out) })
+
+	m := metrics.New(prometheus.NewRegistry())
+	gen := segment.New()
+	frame := make([]byte, 320)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		h := NewHandlerWithConfig(&fakeAdapter{}, nopPublisher{}, gen, "int-bench", "tenant-1", gen.Next("int-bench"), DefaultConfig())
+		h.SetMetrics(m)
+		var offset int64
+		for n := 1; pb.Next(); n++ {
+			h.SendAudio(ctx, frame, offset)
+			offset += 20
+			h.OnPartial("the quick brown fox")
+			if n%50 == 0 {
+				h.OnFinal("the quick brown fox jumps", 0.9)
+				h.OnEndOfUtterance()
+			}
+		}
+	})
+}
+
+// nopPublisher discards events so benchmarks don't measure their retention.
+type nopPublisher struct{}
+
+func (nopPublisher) PublishPartial(context.Context, string, string, any) error      { return nil }
+func (nopPublisher) PublishFinal(context.Context, string, string, any) error        { return nil }
+func (nopPublisher) PublishSegmentEvent(context.Context, string, string, any) error { return nil }
@@ -0,0 +1,82 @@
+package audio
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"ai-speech-ingress-service/internal/service/stt"
+)
+
+// VoiceActivityDetector finds utterance ends in the audio itself, for
+// providers that don't report them.
+type VoiceActivityDetector interface {
+	// Observe consumes the next forwarded frame and reports whether it ends
+	// an utterance.
+	Observe(audio []byte) bool
+	// Reset forgets any speech heard, at the start of a segment.
+	Reset()
+}
+
+// VADFactory creates a stream's detector from its handler config, or returns
+// nil if it can't handle the audio format.
+type VADFactory func(cfg Config) VoiceActivityDetector
+
+// EnergyVAD detects an utterance end once speech (a frame with RMS amplitude
+// of at least threshold) is followed by silence lasting at least silence.
+// Only LINEAR16 audio is supported.
+func EnergyVAD(threshold float64, silence time.Duration) VADFactory {
+	return func(cfg Config) VoiceActivityDetector {
+		if !strings.EqualFold(cfg.Encoding, "LINEAR16") {
+			return nil
+		}
+		return &energyVAD{format: cfg, threshold: threshold, silence: silence.Milliseconds()}
+	}
+}
+
+type energyVAD struct {
+	format    Config
+	threshold float64
+	silence   int64 // ms
+
+	speaking bool
+	silentMs int64
+}
+
+func (v *energyVAD) Observe(audio []byte) bool {
+	if rms16(audio) >= v.threshold {
+		v.speaking = true
+		v.silentMs = 0
+		return false
+	}
+	if !v.speaking {
+		return false
+	}
+	v.silentMs += v.format.AudioDurationMs(int64(len(audio)))
+	if v.silentMs < v.silence {
+		return false
+	}
+	v.Reset()
+	return true
+}
+
+func (v *energyVAD) Reset() {
+	v.speaking = false
+	v.silentMs = 0
+}
+
+// newVAD creates the handler's detector, unless the adapter reports utterance
+// ends itself.
+func newVAD(adapter stt.Adapter, cfg Config) VoiceActivityDetector {
+	if cfg.VAD == nil {
+		return nil
+	}
+	if d, ok := adapter.(stt.UtteranceDetector); ok && d.DetectsUtterances() {
+		return nil
+	}
+	vad := cfg.VAD(cfg)
+	if vad == nil {
+		log.Printf("Voice activity detection unavailable for %s audio; relying on the provider for utterance ends", cfg.Encoding)
+	}
+	return vad
+}
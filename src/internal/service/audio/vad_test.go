@@ -0,0 +1,130 @@
+package audio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"ai-speech-ingress-service/internal/observability/metrics"
+	"ai-speech-ingress-service/internal/service/segment"
+)
+
+// detectingAdapter reports its own utterance ends.
+type detectingAdapter struct {
+	fakeAdapter
+}
+
+func (*detectingAdapter) DetectsUtterances() bool { return true }
+
+func newVADTestHandler(t *testing.T, cfg Config) (*Handler, *fakePublisher) {
+	t.Helper()
+	pub := &fakePublisher{}
+	gen := segment.New()
+	h := NewHandlerWithConfig(&fakeAdapter{}, pub, gen, "int-1", "tenant-1", gen.Next("int-1"), cfg)
+	h.SetMetrics(metrics.New(prometheus.NewRegistry()))
+	return h, pub
+}
+
+// sendPattern sends 20ms frames: n frames of speech, then n of silence, and
+// so on for each count in pattern.
+func sendPattern(h *Handler, offsetMs *int64, pattern ...int) {
+	for i, n := range pattern {
+		amplitude := int16(8000)
+		if i%2 == 1 {
+			amplitude = 0
+		}
+		for range n {
+			h.SendAudio(context.Background(), pcm(amplitude), *offsetMs)
+			*offsetMs += 20
+		}
+	}
+}
+
+func vadConfig() Config {
+	cfg := DefaultConfig()
+	cfg.VAD = EnergyVAD(500, 200*time.Millisecond)
+	return cfg
+}
+
+func TestEnergyVAD(t *testing.T) {
+	vad := EnergyVAD(500, 100*time.Millisecond)(DefaultConfig())
+
+	var ends []int
+	frames := []int16{0, 0, 8000, 8000, 0, 0, 0, 0, 0, 0, 0, 8000, 0, 0, 0, 0, 0}
+	for i, amplitude := range frames {
+		if vad.Observe(pcm(amplitude)) {
+			ends = append(ends, i)
+		}
+	}
+	// Leading silence is not an utterance end; each end needs 100ms (five
+	// frames) of silence after speech
+	if len(ends) != 2 || ends[0] != 8 || ends[1] != 16 {
+		t.Errorf("utterance ends at frames %v, want [8 16]", ends)
+	}
+
+	if EnergyVAD(500, time.Second)(Config{Encoding: "MULAW", SampleRateHz: 8000}) != nil {
+		t.Error("expected no energy detector for MULAW")
+	}
+}
+
+func TestHandler_VAD_SegmentsOnSilence(t *testing.T) {
+	h, _ := newVADTestHandler(t, vadConfig())
+	first := h.GetSegmentId()
+	var offset int64
+
+	// Speech, a 100ms pause (too short), more speech, then 200ms of silence
+	sendPattern(h, &offset, 10, 5, 10, 10)
+	second := h.GetSegmentId()
+	if second == first {
+		t.Fatal("expected a new segment after 200ms of silence")
+	}
+	if got := h.Summary().Utterances; got != 1 {
+		t.Errorf("utterances = %d, want 1", got)
+	}
+
+	// A second utterance ends the same way
+	sendPattern(h, &offset, 5, 10)
+	if h.GetSegmentId() == second || h.Summary().Utterances != 2 {
+		t.Errorf("segment=%s utterances=%d, want a third segment after the second utterance",
+			h.GetSegmentId(), h.Summary().Utterances)
+	}
+
+	// Silence alone doesn't open empty segments
+	third := h.GetSegmentId()
+	sendPattern(h, &offset, 0, 50)
+	if h.GetSegmentId() != third {
+		t.Error("expected silence without speech to keep the segment")
+	}
+}
+
+func TestHandler_VAD_PublishesFinalInItsSegment(t *testing.T) {
+	h, pub := newVADTestHandler(t, vadConfig())
+	first := h.GetSegmentId()
+	var offset int64
+
+	sendPattern(h, &offset, 10)
+	h.OnFinal("hello", 0.9)
+	sendPattern(h, &offset, 0, 10)
+
+	if len(pub.finals) != 1 || pub.finals[0].SegmentID != first {
+		t.Fatalf("finals = %v, want one in %s", pub.finals, first)
+	}
+	if h.GetSegmentId() == first {
+		t.Error("expected the pause to open a new segment")
+	}
+}
+
+func TestHandler_VAD_DisabledWhenProviderDetectsUtterances(t *testing.T) {
+	gen := segment.New()
+	h := NewHandlerWithConfig(&detectingAdapter{}, &fakePublisher{}, gen, "int-1", "tenant-1", gen.Next("int-1"), vadConfig())
+	h.SetMetrics(metrics.New(prometheus.NewRegistry()))
+	first := h.GetSegmentId()
+	var offset int64
+
+	sendPattern(h, &offset, 10, 20)
+	if h.GetSegmentId() != first {
+		t.Error("VAD ended a segment for a provider with its own utterance events")
+	}
+}
@@ -0,0 +1,223 @@
+// Package recording writes raw stream audio to disk for post-mortem debugging.
+package recording
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// wavHeaderSize is the size of a canonical RIFF/WAVE header.
+const wavHeaderSize = 44
+
+// WAV format tags.
+const (
+	wavFormatPCM   = 1
+	wavFormatMuLaw = 7
+)
+
+// Recorder writes the audio of each segment of one stream to
+// <dir>/<interactionId>/<segmentId>.pcm.
+//
+// For LINEAR16 and MULAW audio the file is prefixed with a WAV header
+// (patched with the final sizes on close) so it can be played directly.
+// Other encodings are written as-is.
+type Recorder struct {
+	mu            sync.Mutex
+	dir           string
+	sampleRateHz  int
+	encoding      string
+	keepDropped   bool
+	segmentId     string
+	file          *os.File
+	bytesRecorded uint32
+	dropped       string // Last discarded segment; its later audio isn't recorded
+}
+
+// New creates a recorder for one interaction. Files are created lazily on the
+// first Write.
+func New(dir, interactionId string, sampleRateHz int, encoding string, keepDropped bool) *Recorder {
+	return &Recorder{
+		dir:          filepath.Join(dir, filepath.Base(interactionId)),
+		sampleRateHz: sampleRateHz,
+		encoding:     strings.ToUpper(encoding),
+		keepDropped:  keepDropped,
+	}
+}
+
+// Write appends audio to the file of the given segment. When the segment ID
+// changes, the previous segment's file is finalized and a new one is opened.
+func (r *Recorder) Write(segmentId string, audio []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if segmentId == r.dropped {
+		return nil
+	}
+	if segmentId != r.segmentId {
+		if err := r.finalizeLocked(); err != nil {
+			log.Printf("[RECORDER] Failed to finalize segment=%s: %v", r.segmentId, err)
+		}
+		if err := r.openLocked(segmentId); err != nil {
+			return err
+		}
+	}
+
+	n, err := r.file.Write(audio)
+	r.bytesRecorded += uint32(n)
+	return err
+}
+
+// Discard deletes the current segment's recording, unless dropped segments are
+// configured to be kept, in which case it is finalized like a normal segment.
+func (r *Recorder) Discard() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.discardLocked()
+}
+
+// DiscardSegment discards the recording of a dropped segment like Discard,
+// also when the recorder has already moved on to a later segment.
+func (r *Recorder) DiscardSegment(segmentId string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file != nil && segmentId == r.segmentId {
+		r.discardLocked()
+		return
+	}
+	r.dropped = segmentId
+	if r.keepDropped {
+		return
+	}
+	name := r.path(segmentId)
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("[RECORDER] Failed to remove dropped segment recording %s: %v", name, err)
+	}
+}
+
+func (r *Recorder) discardLocked() {
+	if r.file == nil {
+		return
+	}
+	r.dropped = r.segmentId
+	if r.keepDropped {
+		if err := r.finalizeLocked(); err != nil {
+			log.Printf("[RECORDER] Failed to finalize dropped segment=%s: %v", r.segmentId, err)
+		}
+		return
+	}
+
+	name := r.file.Name()
+	_ = r.file.Close()
+	if err := os.Remove(name); err != nil {
+		log.Printf("[RECORDER] Failed to remove dropped segment recording %s: %v", name, err)
+	}
+	r.file = nil
+	r.segmentId = ""
+}
+
+// Close finalizes and flushes the current segment's recording.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.finalizeLocked()
+}
+
+func (r *Recorder) openLocked(segmentId string) error {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return fmt.Errorf("create recording dir: %w", err)
+	}
+
+	f, err := os.Create(r.path(segmentId))
+	if err != nil {
+		return fmt.Errorf("create recording file: %w", err)
+	}
+
+	if r.hasWAVHeader() {
+		// Placeholder header; sizes are patched in finalizeLocked
+		if err := WriteWAVHeader(f, r.sampleRateHz, r.encoding, 0); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	r.file = f
+	r.segmentId = segmentId
+	r.bytesRecorded = 0
+	return nil
+}
+
+func (r *Recorder) finalizeLocked() error {
+	if r.file == nil {
+		return nil
+	}
+	f := r.file
+	r.file = nil
+
+	if r.hasWAVHeader() {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := WriteWAVHeader(f, r.sampleRateHz, r.encoding, r.bytesRecorded); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// path is the file a segment is recorded to.
+func (r *Recorder) path(segmentId string) string {
+	return filepath.Join(r.dir, filepath.Base(segmentId)+".pcm")
+}
+
+func (r *Recorder) hasWAVHeader() bool {
+	return r.encoding == "LINEAR16" || r.encoding == "MULAW"
+}
+
+// WriteWAVHeader writes a 44-byte RIFF/WAVE header for mono audio of the
+// given encoding ("LINEAR16" or "MULAW") and data length in bytes.
+func WriteWAVHeader(w io.Writer, sampleRateHz int, encoding string, dataLen uint32) error {
+	format := uint16(wavFormatPCM)
+	bitsPerSample := uint16(16)
+	if strings.ToUpper(encoding) == "MULAW" {
+		format = wavFormatMuLaw
+		bitsPerSample = 8
+	}
+
+	const channels = 1
+	blockAlign := uint16(channels) * bitsPerSample / 8
+	byteRate := uint32(sampleRateHz) * uint32(blockAlign)
+
+	var h [wavHeaderSize]byte
+	copy(h[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(h[4:8], 36+dataLen)
+	copy(h[8:12], "WAVE")
+	copy(h[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(h[16:20], 16)
+	binary.LittleEndian.PutUint16(h[20:22], format)
+	binary.LittleEndian.PutUint16(h[22:24], channels)
+	binary.LittleEndian.PutUint32(h[24:28], uint32(sampleRateHz))
+	binary.LittleEndian.PutUint32(h[28:32], byteRate)
+	binary.LittleEndian.PutUint16(h[32:34], blockAlign)
+	binary.LittleEndian.PutUint16(h[34:36], bitsPerSample)
+	copy(h[36:40], "data")
+	binary.LittleEndian.PutUint32(h[40:44], dataLen)
+
+	_, err := w.Write(h[:])
+	return err
+}
@@ -0,0 +1,119 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteWAVHeader_LINEAR16(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteWAVHeader(&buf, 8000, "LINEAR16", 1600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h := buf.Bytes()
+
+	if len(h) != wavHeaderSize {
+		t.Fatalf("header size = %d, want %d", len(h), wavHeaderSize)
+	}
+	if string(h[0:4]) != "RIFF" || string(h[8:12]) != "WAVE" || string(h[36:40]) != "data" {
+		t.Errorf("bad chunk IDs: %q", h)
+	}
+	if got := binary.LittleEndian.Uint16(h[20:22]); got != wavFormatPCM {
+		t.Errorf("format = %d, want PCM", got)
+	}
+	if got := binary.LittleEndian.Uint32(h[28:32]); got != 16000 {
+		t.Errorf("byte rate = %d, want 16000", got)
+	}
+	if got := binary.LittleEndian.Uint32(h[40:44]); got != 1600 {
+		t.Errorf("data len = %d, want 1600", got)
+	}
+}
+
+func TestWriteWAVHeader_MULAW(t *testing.T) {
+	var buf bytes.Buffer
+	_ = WriteWAVHeader(&buf, 8000, "mulaw", 0)
+	h := buf.Bytes()
+
+	if got := binary.LittleEndian.Uint16(h[20:22]); got != wavFormatMuLaw {
+		t.Errorf("format = %d, want mu-law", got)
+	}
+	if got := binary.LittleEndian.Uint16(h[34:36]); got != 8 {
+		t.Errorf("bits per sample = %d, want 8", got)
+	}
+}
+
+func TestRecorder_WritesOneFilePerSegment(t *testing.T) {
+	dir := t.TempDir()
+	r := New(dir, "int-1", 8000, "LINEAR16", false)
+
+	_ = r.Write("seg-1", []byte{1, 2})
+	_ = r.Write("seg-1", []byte{3, 4})
+	_ = r.Write("seg-2", []byte{5, 6})
+	if err := r.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	seg1, err := os.ReadFile(filepath.Join(dir, "int-1", "seg-1.pcm"))
+	if err != nil {
+		t.Fatalf("read seg-1: %v", err)
+	}
+	if !bytes.Equal(seg1[wavHeaderSize:], []byte{1, 2, 3, 4}) {
+		t.Errorf("seg-1 audio = %v", seg1[wavHeaderSize:])
+	}
+	if got := binary.LittleEndian.Uint32(seg1[40:44]); got != 4 {
+		t.Errorf("seg-1 header data len = %d, want 4", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "int-1", "seg-2.pcm")); err != nil {
+		t.Errorf("seg-2 not recorded: %v", err)
+	}
+}
+
+func TestRecorder_DiscardRemovesDroppedSegment(t *testing.T) {
+	dir := t.TempDir()
+	r := New(dir, "int-1", 8000, "LINEAR16", false)
+
+	_ = r.Write("seg-1", []byte{1, 2})
+	r.Discard()
+	_ = r.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, "int-1", "seg-1.pcm")); !os.IsNotExist(err) {
+		t.Errorf("expected dropped recording to be removed, stat err=%v", err)
+	}
+}
+
+func TestRecorder_DiscardKeepsWhenConfigured(t *testing.T) {
+	dir := t.TempDir()
+	r := New(dir, "int-1", 8000, "LINEAR16", true)
+
+	_ = r.Write("seg-1", []byte{1, 2})
+	r.Discard()
+	_ = r.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, "int-1", "seg-1.pcm")); err != nil {
+		t.Errorf("expected dropped recording to be kept: %v", err)
+	}
+}
+
+func TestRecorder_DiscardSegment(t *testing.T) {
+	dir := t.TempDir()
+	r := New(dir, "int-1", 8000, "LINEAR16", false)
+
+	_ = r.Write("seg-1", []byte{1, 2})
+	_ = r.Write("seg-2", []byte{3, 4})
+	// Dropped after the recorder moved on to the next segment
+	r.DiscardSegment("seg-1")
+	r.DiscardSegment("seg-2")
+	// Audio still sent for the dropped segment
+	_ = r.Write("seg-2", []byte{5, 6})
+	_ = r.Close()
+
+	for _, seg := range []string{"seg-1", "seg-2"} {
+		if _, err := os.Stat(filepath.Join(dir, "int-1", seg+".pcm")); !os.IsNotExist(err) {
+			t.Errorf("expected dropped recording %s to be removed, stat err=%v", seg, err)
+		}
+	}
+}
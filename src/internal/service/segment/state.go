@@ -17,6 +17,8 @@ const (
 	StateFinalEmitted
 	// StateClosed - Segment is closed, ignore all events.
 	StateClosed
+	// StateDropped - Segment was abandoned without a final, ignore all events.
+	StateDropped
 )
 
 // String returns the string representation of the state.
@@ -28,6 +30,8 @@ func (s State) String() string {
 		return "FINAL_EMITTED"
 	case StateClosed:
 		return "CLOSED"
+	case StateDropped:
+		return "DROPPED"
 	default:
 		return fmt.Sprintf("UNKNOWN(%d)", s)
 	}
@@ -38,6 +42,8 @@ var (
 	ErrSegmentClosed               = errors.New("segment is closed")
 	ErrFinalAlreadyEmitted         = errors.New("final already emitted for this segment")
 	ErrCannotEmitPartialAfterFinal = errors.New("cannot emit partial after final")
+	ErrSegmentDropped              = errors.New("segment was dropped")
+	ErrSegmentReplaced             = errors.New("segment was replaced by a newer segment")
 )
 
 // Lifecycle manages the state machine for a single segment.
@@ -49,12 +55,15 @@ var (
 //	  │         │
 //	  │         └── EmitFinal() ──→ only once
 //	  │
-//	  └── EmitPartial() ──→ multiple times
+//	  ├── EmitPartial() ──→ multiple times
+//	  │
+//	  └── Drop() ──→ DROPPED
 //
 // Rules:
-//   - OPEN: Can emit partials (multiple), can emit final (once → transitions to FINAL_EMITTED)
-//   - FINAL_EMITTED: Cannot emit partials, cannot emit final again, can close
+//   - OPEN: Can emit partials (multiple), can emit final (once → transitions to FINAL_EMITTED), can drop
+//   - FINAL_EMITTED: Cannot emit partials, cannot emit final again, cannot drop, can close
 //   - CLOSED: All operations are no-ops or return errors
+//   - DROPPED: Terminal like CLOSED, but records that no final was emitted
 type Lifecycle struct {
 	mu        sync.RWMutex
 	segmentId string
@@ -118,6 +127,8 @@ func (l *Lifecycle) EmitPartial() error {
 		return ErrCannotEmitPartialAfterFinal
 	case StateClosed:
 		return ErrSegmentClosed
+	case StateDropped:
+		return ErrSegmentDropped
 	default:
 		return fmt.Errorf("unexpected state: %v", l.state)
 	}
@@ -128,7 +139,22 @@ func (l *Lifecycle) EmitPartial() error {
 func (l *Lifecycle) EmitFinal() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	return l.emitFinalLocked()
+}
 
+// EmitFinalFor is EmitFinal for a specific segment. It fails with
+// ErrSegmentReplaced if the lifecycle was reset to another segment since
+// segmentId was read, so a final is never attributed to the wrong segment.
+func (l *Lifecycle) EmitFinalFor(segmentId string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.segmentId != segmentId {
+		return ErrSegmentReplaced
+	}
+	return l.emitFinalLocked()
+}
+
+func (l *Lifecycle) emitFinalLocked() error {
 	switch l.state {
 	case StateOpen:
 		// Transition to FINAL_EMITTED
@@ -138,17 +164,66 @@ func (l *Lifecycle) EmitFinal() error {
 		return ErrFinalAlreadyEmitted
 	case StateClosed:
 		return ErrSegmentClosed
+	case StateDropped:
+		return ErrSegmentDropped
+	default:
+		return fmt.Errorf("unexpected state: %v", l.state)
+	}
+}
+
+// Drop validates and transitions an OPEN segment to DROPPED state.
+// Returns nil if allowed (and transitions state), error if not allowed.
+func (l *Lifecycle) Drop() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dropLocked()
+}
+
+// DropFor is Drop for a specific segment. It fails with ErrSegmentReplaced if
+// the lifecycle was reset to another segment since segmentId was read.
+func (l *Lifecycle) DropFor(segmentId string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.segmentId != segmentId {
+		return ErrSegmentReplaced
+	}
+	return l.dropLocked()
+}
+
+func (l *Lifecycle) dropLocked() error {
+	switch l.state {
+	case StateOpen:
+		l.state = StateDropped
+		return nil
+	case StateFinalEmitted:
+		return ErrFinalAlreadyEmitted
+	case StateClosed:
+		return ErrSegmentClosed
+	case StateDropped:
+		return ErrSegmentDropped
 	default:
 		return fmt.Errorf("unexpected state: %v", l.state)
 	}
 }
 
+// IsDropped returns true if the segment was dropped.
+func (l *Lifecycle) IsDropped() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.state == StateDropped
+}
+
 // Close transitions the segment to CLOSED state.
-// Can be called from any state. Idempotent.
-func (l *Lifecycle) Close() {
+// Can be called from any state except DROPPED, which is terminal. Idempotent.
+// Returns true if this call closed the segment.
+func (l *Lifecycle) Close() bool {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if l.state == StateDropped || l.state == StateClosed {
+		return false
+	}
 	l.state = StateClosed
+	return true
 }
 
 // Reset resets the lifecycle to OPEN state with a new segment ID.
@@ -180,6 +180,7 @@ func TestState_String(t *testing.T) {
 		{StateOpen, "OPEN"},
 		{StateFinalEmitted, "FINAL_EMITTED"},
 		{StateClosed, "CLOSED"},
+		{StateDropped, "DROPPED"},
 		{State(99), "UNKNOWN(99)"},
 	}
 
@@ -189,3 +190,70 @@ func TestState_String(t *testing.T) {
 		}
 	}
 }
+
+func TestLifecycle_Drop_FromOpen(t *testing.T) {
+	lc := NewLifecycle("seg-1")
+
+	if err := lc.Drop(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !lc.IsDropped() {
+		t.Errorf("expected StateDropped, got %v", lc.State())
+	}
+
+	if err := lc.EmitPartial(); err != ErrSegmentDropped {
+		t.Errorf("EmitPartial: expected ErrSegmentDropped, got %v", err)
+	}
+	if err := lc.EmitFinal(); err != ErrSegmentDropped {
+		t.Errorf("EmitFinal: expected ErrSegmentDropped, got %v", err)
+	}
+	if err := lc.Drop(); err != ErrSegmentDropped {
+		t.Errorf("second Drop: expected ErrSegmentDropped, got %v", err)
+	}
+}
+
+func TestLifecycle_Drop_FailsAfterFinal(t *testing.T) {
+	lc := NewLifecycle("seg-1")
+	lc.EmitFinal()
+
+	if err := lc.Drop(); err != ErrFinalAlreadyEmitted {
+		t.Errorf("expected ErrFinalAlreadyEmitted, got %v", err)
+	}
+	if lc.State() != StateFinalEmitted {
+		t.Errorf("expected StateFinalEmitted, got %v", lc.State())
+	}
+}
+
+func TestLifecycle_Close_KeepsDropped(t *testing.T) {
+	lc := NewLifecycle("seg-1")
+	lc.Drop()
+	lc.Close()
+
+	if !lc.IsDropped() {
+		t.Errorf("expected DROPPED to be terminal, got %v", lc.State())
+	}
+
+	lc.Reset("seg-2")
+	if lc.State() != StateOpen {
+		t.Errorf("expected StateOpen after reset, got %v", lc.State())
+	}
+}
+
+func TestLifecycle_TransitionsForReplacedSegment(t *testing.T) {
+	lc := NewLifecycle("seg-1")
+	lc.Reset("seg-2")
+
+	if err := lc.EmitFinalFor("seg-1"); err != ErrSegmentReplaced {
+		t.Errorf("EmitFinalFor: expected ErrSegmentReplaced, got %v", err)
+	}
+	if err := lc.DropFor("seg-1"); err != ErrSegmentReplaced {
+		t.Errorf("DropFor: expected ErrSegmentReplaced, got %v", err)
+	}
+	if lc.State() != StateOpen {
+		t.Errorf("expected seg-2 to stay OPEN, got %v", lc.State())
+	}
+
+	if err := lc.EmitFinalFor("seg-2"); err != nil {
+		t.Errorf("EmitFinalFor current segment: %v", err)
+	}
+}
@@ -23,6 +23,26 @@ type Callback interface {
 	OnError(err error)
 }
 
+// Alternative is one recognition hypothesis of a final transcript.
+type Alternative struct {
+	Text       string
+	Confidence float64
+}
+
+// AlternativesCallback is optionally implemented by callbacks that accept
+// N-best finals. Adapters that produce alternatives call OnFinalAlternatives
+// instead of OnFinal when the callback supports it, best hypothesis first.
+type AlternativesCallback interface {
+	OnFinalAlternatives(alternatives []Alternative)
+}
+
+// UtteranceDetector is optionally implemented by adapters to report whether
+// their provider signals utterance ends (OnEndOfUtterance) itself. The audio
+// handler's voice activity detection only runs for adapters that don't.
+type UtteranceDetector interface {
+	DetectsUtterances() bool
+}
+
 // Adapter defines the interface for STT providers (Google, Azure, AWS, etc.).
 type Adapter interface {
 	// Start begins a streaming transcription session.
@@ -2,37 +2,134 @@
 // Supports streaming recognition with utterance boundary detection.
 package google
 
+//lint:file-ignore SA1019 RecognitionMetadata is deprecated in the v1 proto but still honored by the API
+
 import (
 	"context"
+	"fmt"
 	"io"
+	"log"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
-	speech "cloud.google.com/go/speech/apiv1"
 	speechpb "cloud.google.com/go/speech/apiv1/speechpb"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"ai-speech-ingress-service/internal/observability/metrics"
 	"ai-speech-ingress-service/internal/service/stt"
 )
 
+// Config holds recognition settings for the Google adapter.
+type Config struct {
+	SampleRateHz int32
+	Encoding     string // Google encoding name, e.g. "LINEAR16", "MULAW"
+	Channels     int32  // Interleaved channels; 0 means mono. Only the first is recognized
+	LanguageCode string
+	Model        string // e.g. "phone_call", "video", "latest_long"; empty lets Google choose
+	UseEnhanced  bool   // Use the enhanced variant of Model where available
+
+	// MaxAlternatives is the number of hypotheses requested for finals (N-best).
+	// Values below 2 request only the best hypothesis.
+	MaxAlternatives int32
+
+	// Recognition metadata hints; zero values are omitted.
+	InteractionType    string // e.g. "PHONE_CALL", "DISCUSSION"
+	IndustryNaicsCode  uint32 // 6-digit NAICS code of the audio's industry vertical
+	MicrophoneDistance string // e.g. "NEARFIELD", "MIDFIELD", "FARFIELD"
+
+	// InterimStability skips interim results whose stability is below this
+	// (0-1), trading partial latency for fewer revisions. Zero keeps all.
+	InterimStability float32
+
+	// AllowUnknownEncoding accepts an unrecognized Encoding and recognizes
+	// it as LINEAR16 instead of failing. Only meant as an escape hatch.
+	AllowUnknownEncoding bool
+
+	// StartRetries is how many times Start retries opening the recognition
+	// stream after a transient error, waiting a jittered, exponentially
+	// growing delay from StartBackoff (capped at maxStartBackoff) in between.
+	StartRetries int
+	StartBackoff time.Duration
+}
+
+// maxStartBackoff caps the delay between attempts to open a stream.
+const maxStartBackoff = 5 * time.Second
+
+// DefaultConfig returns the telephony defaults (8kHz LINEAR16, en-US,
+// enhanced phone_call model).
+func DefaultConfig() Config {
+	return Config{
+		SampleRateHz: 8000,
+		Encoding:     "LINEAR16",
+		LanguageCode: "en-US",
+		Model:        "phone_call",
+		UseEnhanced:  true,
+	}
+}
+
 // Adapter implements stt.Adapter using Google Cloud Speech-to-Text.
 type Adapter struct {
-	client *speech.Client
+	pool   *clientPool
+	lease  *pooledClient
 	stream speechpb.Speech_StreamingRecognizeClient
 	cb     stt.Callback
+	config Config
+
+	// Counts audio sent while no recognition stream is open
+	audioDropped prometheus.Counter
+	// Counts failed attempts to open a recognition stream
+	startFailures prometheus.Counter
+	// Counts final results that came back without any alternative
+	emptyFinals prometheus.Counter
+
+	// Guards the handoff of the lease between Close and Listen: the client
+	// must outlive the stream's last response, so a running Listen releases
+	// it when the stream ends.
+	mu        sync.Mutex
+	listening bool
+	closed    bool
 }
 
-// New creates a new Google STT adapter.
-// Requires GOOGLE_APPLICATION_CREDENTIALS environment variable to be set.
+// New creates a new Google STT adapter with the default config.
+// Requires GOOGLE_CREDENTIALS_JSON or GOOGLE_APPLICATION_CREDENTIALS to be set.
 func New(ctx context.Context) (*Adapter, error) {
-	c, err := speech.NewClient(ctx)
+	return NewWithConfig(ctx, DefaultConfig())
+}
+
+// NewWithConfig creates a new Google STT adapter with the given recognition config.
+// Adapters share a pooled speech client; see clientPool.
+// Requires GOOGLE_CREDENTIALS_JSON or GOOGLE_APPLICATION_CREDENTIALS to be set.
+func NewWithConfig(ctx context.Context, cfg Config) (*Adapter, error) {
+	return newWithPool(ctx, defaultPool, cfg)
+}
+
+func newWithPool(ctx context.Context, pool *clientPool, cfg Config) (*Adapter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	lease, err := pool.acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &Adapter{client: c}, nil
+	return &Adapter{
+		pool:          pool,
+		lease:         lease,
+		config:        cfg,
+		audioDropped:  metrics.Default.STTAudioDroppedDuringRestart,
+		startFailures: metrics.Default.STTRestartFailures,
+		emptyFinals:   metrics.Default.STTFinalsWithoutAlternatives,
+	}, nil
 }
 
 // Start begins a streaming recognition session and sends the initial config.
 // Configures single utterance mode to detect end-of-utterance boundaries.
 func (a *Adapter) Start(ctx context.Context, cb stt.Callback) error {
-	stream, err := a.client.StreamingRecognize(ctx)
+	stream, err := a.openStream(ctx)
 	if err != nil {
 		return err
 	}
@@ -40,24 +137,121 @@ func (a *Adapter) Start(ctx context.Context, cb stt.Callback) error {
 	a.cb = cb
 
 	// Send streaming config as the first message
-	// SingleUtterance mode tells Google to detect when the speaker stops talking
 	return stream.Send(&speechpb.StreamingRecognizeRequest{
 		StreamingRequest: &speechpb.StreamingRecognizeRequest_StreamingConfig{
-			StreamingConfig: &speechpb.StreamingRecognitionConfig{
-				Config: &speechpb.RecognitionConfig{
-					Encoding:        speechpb.RecognitionConfig_LINEAR16,
-					SampleRateHertz: 8000,
-					LanguageCode:    "en-US",
-				},
-				InterimResults:  true,
-				SingleUtterance: true, // Enable utterance boundary detection
-			},
+			StreamingConfig: a.streamingConfig(),
 		},
 	})
 }
 
+// openStream opens a recognition stream, retrying transient errors up to
+// StartRetries times. A client whose connection broke is swapped for a fresh
+// one from the pool before the next attempt.
+func (a *Adapter) openStream(ctx context.Context) (speechpb.Speech_StreamingRecognizeClient, error) {
+	for attempt := 0; ; attempt++ {
+		stream, err := a.lease.client.StreamingRecognize(ctx)
+		if err == nil {
+			return stream, nil
+		}
+		a.pool.markUnhealthy(a.lease, err)
+		a.startFailures.Inc()
+		if attempt >= a.config.StartRetries || !retryable(err) {
+			return nil, err
+		}
+
+		delay := startBackoff(a.config.StartBackoff, attempt)
+		log.Printf("Opening recognition stream failed, retrying in %s: attempt=%d err=%v", delay, attempt+1, err)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+
+		lease, rerr := a.pool.renew(ctx, a.lease)
+		if rerr != nil {
+			return nil, rerr
+		}
+		a.lease = lease
+	}
+}
+
+// retryable reports whether opening a stream may succeed if tried again.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// startBackoff returns a random delay up to base doubled per attempt, capped
+// at maxStartBackoff. The jitter keeps streams that failed together from
+// retrying together.
+func startBackoff(base time.Duration, attempt int) time.Duration {
+	d := base
+	for i := 0; i < attempt && d < maxStartBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, maxStartBackoff)
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d) + 1
+}
+
+// streamingConfig builds the streaming recognition config sent at the start of
+// every stream. SingleUtterance mode tells Google to detect when the speaker
+// stops talking.
+func (a *Adapter) streamingConfig() *speechpb.StreamingRecognitionConfig {
+	return &speechpb.StreamingRecognitionConfig{
+		Config:          a.config.recognitionConfig(),
+		InterimResults:  true,
+		SingleUtterance: true, // Enable utterance boundary detection
+	}
+}
+
+// recognitionConfig builds the recognition settings shared by streaming and
+// batch requests.
+func (cfg Config) recognitionConfig() *speechpb.RecognitionConfig {
+	return &speechpb.RecognitionConfig{
+		Encoding:          parseAudioEncoding(cfg.Encoding),
+		SampleRateHertz:   cfg.SampleRateHz,
+		AudioChannelCount: cfg.Channels,
+		LanguageCode:      cfg.LanguageCode,
+		Model:             cfg.Model,
+		UseEnhanced:       cfg.UseEnhanced,
+		MaxAlternatives:   cfg.MaxAlternatives,
+		Metadata:          cfg.recognitionMetadata(),
+	}
+}
+
+// recognitionMetadata returns the configured metadata hints, or nil if none are set.
+func (cfg Config) recognitionMetadata() *speechpb.RecognitionMetadata {
+	if cfg.InteractionType == "" && cfg.IndustryNaicsCode == 0 && cfg.MicrophoneDistance == "" {
+		return nil
+	}
+
+	md := &speechpb.RecognitionMetadata{
+		IndustryNaicsCodeOfAudio: cfg.IndustryNaicsCode,
+	}
+	if v, ok := speechpb.RecognitionMetadata_InteractionType_value[strings.ToUpper(cfg.InteractionType)]; ok {
+		md.InteractionType = speechpb.RecognitionMetadata_InteractionType(v)
+	}
+	if v, ok := speechpb.RecognitionMetadata_MicrophoneDistance_value[strings.ToUpper(cfg.MicrophoneDistance)]; ok {
+		md.MicrophoneDistance = speechpb.RecognitionMetadata_MicrophoneDistance(v)
+	}
+	return md
+}
+
 // SendAudio sends audio bytes to Google Speech-to-Text.
+// Audio sent while no stream is open (before Start or while the stream is
+// being replaced) is dropped and counted rather than failing the caller.
 func (a *Adapter) SendAudio(ctx context.Context, audio []byte) error {
+	if a.stream == nil {
+		a.audioDropped.Inc()
+		return nil
+	}
 	return a.stream.Send(&speechpb.StreamingRecognizeRequest{
 		StreamingRequest: &speechpb.StreamingRecognizeRequest_AudioContent{
 			AudioContent: audio,
@@ -65,18 +259,72 @@ func (a *Adapter) SendAudio(ctx context.Context, audio []byte) error {
 	})
 }
 
-// Close ends the streaming session.
+// DetectsUtterances reports that Google signals utterance ends
+// (single_utterance mode).
+func (a *Adapter) DetectsUtterances() bool { return true }
+
+// Close half-closes the streaming session. Google still sends the final for
+// the trailing audio, so while Listen is running the pooled client is only
+// released once Listen has received the end of the stream.
+// Safe to call more than once.
 func (a *Adapter) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	release := !a.listening
+	a.mu.Unlock()
+
+	var err error
 	if a.stream != nil {
-		return a.stream.CloseSend()
+		err = a.stream.CloseSend()
+	}
+	if release {
+		if rerr := a.releaseLease(); err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+// releaseLease returns the pooled client. Only the first call releases it.
+func (a *Adapter) releaseLease() error {
+	a.mu.Lock()
+	lease := a.lease
+	a.lease = nil
+	a.mu.Unlock()
+	if lease == nil {
+		return nil
+	}
+	return a.pool.release(lease)
+}
+
+// stopListening marks Listen as finished, releasing the pooled client if
+// Close has already run.
+func (a *Adapter) stopListening() {
+	a.mu.Lock()
+	a.listening = false
+	closed := a.closed
+	a.mu.Unlock()
+	if !closed {
+		return
+	}
+	if err := a.releaseLease(); err != nil {
+		log.Printf("Failed to release speech client: %v", err)
 	}
-	return nil
 }
 
 // Listen receives transcript responses from Google and invokes callbacks.
 // Should be called in a separate goroutine after Start().
 // Detects END_OF_SINGLE_UTTERANCE events to signal utterance boundaries.
 func (a *Adapter) Listen() {
+	a.mu.Lock()
+	a.listening = true
+	a.mu.Unlock()
+	defer a.stopListening()
+
 	for {
 		resp, err := a.stream.Recv()
 		if err == io.EOF {
@@ -84,6 +332,13 @@ func (a *Adapter) Listen() {
 			return
 		}
 		if err != nil {
+			a.mu.Lock()
+			closed := a.closed
+			a.mu.Unlock()
+			if closed && status.Code(err) == codes.Canceled {
+				// Canceled after Close is the stream ending, not a failure
+				return
+			}
 			a.cb.OnError(err)
 			return
 		}
@@ -99,14 +354,82 @@ func (a *Adapter) Listen() {
 		// Process transcript results
 		for _, r := range resp.Results {
 			if len(r.Alternatives) == 0 {
+				if r.IsFinal {
+					// Still ends the utterance, as an empty final, so the
+					// segment isn't left open waiting for one
+					log.Printf("Final result without alternatives, emitting an empty final")
+					a.emptyFinals.Inc()
+					a.cb.OnFinal("", 0)
+				}
 				continue
 			}
 			alt := r.Alternatives[0]
 			if r.IsFinal {
-				a.cb.OnFinal(alt.Transcript, float64(alt.Confidence))
-			} else {
+				a.emitFinal(r.Alternatives)
+			} else if r.Stability >= a.config.InterimStability {
 				a.cb.OnPartial(alt.Transcript)
 			}
 		}
 	}
 }
+
+// emitFinal delivers a final result, passing all alternatives to callbacks
+// that accept them.
+func (a *Adapter) emitFinal(alternatives []*speechpb.SpeechRecognitionAlternative) {
+	ac, ok := a.cb.(stt.AlternativesCallback)
+	if !ok {
+		a.cb.OnFinal(alternatives[0].Transcript, float64(alternatives[0].Confidence))
+		return
+	}
+
+	alts := make([]stt.Alternative, len(alternatives))
+	for i, alt := range alternatives {
+		alts[i] = stt.Alternative{Text: alt.Transcript, Confidence: float64(alt.Confidence)}
+	}
+	ac.OnFinalAlternatives(alts)
+}
+
+// SupportedEncodings returns the encoding names accepted by the adapter,
+// sorted alphabetically.
+func SupportedEncodings() []string {
+	names := make([]string, 0, len(speechpb.RecognitionConfig_AudioEncoding_value))
+	for name, v := range speechpb.RecognitionConfig_AudioEncoding_value {
+		if v != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validate checks the config before a stream is opened, so a mistyped
+// encoding fails loudly instead of producing garbage transcripts.
+func (c Config) validate() error {
+	err := validateEncoding(c.Encoding)
+	if err != nil && c.AllowUnknownEncoding {
+		log.Printf("WARNING: %v, recognizing as LINEAR16", err)
+		return nil
+	}
+	return err
+}
+
+// validateEncoding returns an error if name is not a Google encoding. An
+// empty name selects the LINEAR16 default.
+func validateEncoding(name string) error {
+	if name == "" {
+		return nil
+	}
+	if v, ok := speechpb.RecognitionConfig_AudioEncoding_value[strings.ToUpper(name)]; ok && v != 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown audio encoding %q (supported: %s)", name, strings.Join(SupportedEncodings(), ", "))
+}
+
+// parseAudioEncoding maps an encoding name to the Google enum.
+// Unknown names fall back to LINEAR16; see validateEncoding.
+func parseAudioEncoding(name string) speechpb.RecognitionConfig_AudioEncoding {
+	if v, ok := speechpb.RecognitionConfig_AudioEncoding_value[strings.ToUpper(name)]; ok && v != 0 {
+		return speechpb.RecognitionConfig_AudioEncoding(v)
+	}
+	return speechpb.RecognitionConfig_LINEAR16
+}
@@ -0,0 +1,232 @@
+package google
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	speechpb "cloud.google.com/go/speech/apiv1/speechpb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"ai-speech-ingress-service/internal/observability/metrics"
+	"ai-speech-ingress-service/internal/service/stt"
+)
+
+func TestStreamingConfig_Defaults(t *testing.T) {
+	a := &Adapter{config: DefaultConfig()}
+	sc := a.streamingConfig()
+
+	rc := sc.GetConfig()
+	if rc.GetEncoding() != speechpb.RecognitionConfig_LINEAR16 || rc.GetSampleRateHertz() != 8000 || rc.GetLanguageCode() != "en-US" {
+		t.Errorf("unexpected recognition config: %v", rc)
+	}
+	if rc.GetModel() != "phone_call" || !rc.GetUseEnhanced() {
+		t.Errorf("model=%q useEnhanced=%v, want enhanced phone_call", rc.GetModel(), rc.GetUseEnhanced())
+	}
+	if rc.GetMetadata() != nil {
+		t.Errorf("expected no metadata by default, got %v", rc.GetMetadata())
+	}
+	if !sc.GetInterimResults() || !sc.GetSingleUtterance() {
+		t.Errorf("expected interim results and single utterance: %v", sc)
+	}
+}
+
+func TestStreamingConfig_Model(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Model = "latest_long"
+	cfg.UseEnhanced = false
+	a := &Adapter{config: cfg}
+
+	rc := a.streamingConfig().GetConfig()
+	if rc.GetModel() != "latest_long" || rc.GetUseEnhanced() {
+		t.Errorf("model=%q useEnhanced=%v, want latest_long without enhanced", rc.GetModel(), rc.GetUseEnhanced())
+	}
+}
+
+func TestStreamingConfig_MaxAlternatives(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxAlternatives = 3
+	a := &Adapter{config: cfg}
+
+	if got := a.streamingConfig().GetConfig().GetMaxAlternatives(); got != 3 {
+		t.Errorf("MaxAlternatives = %d, want 3", got)
+	}
+}
+
+// altsCallback records partials, and finals delivered through either callback
+// method.
+type altsCallback struct {
+	partials []string
+	finals   [][]stt.Alternative
+	errs     []error
+}
+
+func (c *altsCallback) OnPartial(text string) { c.partials = append(c.partials, text) }
+func (c *altsCallback) OnFinal(text string, confidence float64) {
+	c.finals = append(c.finals, []stt.Alternative{{Text: text, Confidence: confidence}})
+}
+func (c *altsCallback) OnEndOfUtterance() {}
+func (c *altsCallback) OnError(err error) { c.errs = append(c.errs, err) }
+func (c *altsCallback) OnFinalAlternatives(alts []stt.Alternative) {
+	c.finals = append(c.finals, alts)
+}
+
+func TestEmitFinal_PassesAllAlternatives(t *testing.T) {
+	cb := &altsCallback{}
+	a := &Adapter{cb: cb}
+
+	a.emitFinal([]*speechpb.SpeechRecognitionAlternative{
+		{Transcript: "recognize speech", Confidence: 0.75},
+		{Transcript: "wreck a nice beach", Confidence: 0.25},
+	})
+
+	if len(cb.finals) != 1 || len(cb.finals[0]) != 2 {
+		t.Fatalf("finals = %v, want one final with two alternatives", cb.finals)
+	}
+	if got := cb.finals[0][1]; got.Text != "wreck a nice beach" || got.Confidence != 0.25 {
+		t.Errorf("second alternative = %v", got)
+	}
+}
+
+// fakeRecognizeStream replays responses from Recv, then io.EOF.
+type fakeRecognizeStream struct {
+	speechpb.Speech_StreamingRecognizeClient
+	responses []*speechpb.StreamingRecognizeResponse
+}
+
+func (s *fakeRecognizeStream) Send(*speechpb.StreamingRecognizeRequest) error { return nil }
+func (s *fakeRecognizeStream) CloseSend() error                               { return nil }
+
+func (s *fakeRecognizeStream) Recv() (*speechpb.StreamingRecognizeResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+func interim(text string, stability float32) *speechpb.StreamingRecognizeResponse {
+	return &speechpb.StreamingRecognizeResponse{Results: []*speechpb.StreamingRecognitionResult{{
+		Alternatives: []*speechpb.SpeechRecognitionAlternative{{Transcript: text}},
+		Stability:    stability,
+	}}}
+}
+
+func TestListen_FiltersUnstablePartials(t *testing.T) {
+	cb := &altsCallback{}
+	cfg := DefaultConfig()
+	cfg.InterimStability = 0.8
+	a := &Adapter{cb: cb, config: cfg, stream: &fakeRecognizeStream{responses: []*speechpb.StreamingRecognizeResponse{
+		interim("book", 0.1),
+		interim("book a flight", 0.9),
+		interim("book a fight", 0.5),
+	}}}
+
+	a.Listen()
+
+	if len(cb.partials) != 1 || cb.partials[0] != "book a flight" {
+		t.Errorf("partials = %q, want only the stable one", cb.partials)
+	}
+}
+
+func TestListen_FinalWithoutAlternatives(t *testing.T) {
+	cb := &altsCallback{}
+	m := metrics.New(prometheus.NewRegistry())
+	a := &Adapter{cb: cb, config: DefaultConfig(), emptyFinals: m.STTFinalsWithoutAlternatives,
+		stream: &fakeRecognizeStream{responses: []*speechpb.StreamingRecognizeResponse{
+			interim("book a flight", 0.9),
+			{Results: []*speechpb.StreamingRecognitionResult{{IsFinal: true}}},
+		}}}
+
+	a.Listen()
+
+	if len(cb.finals) != 1 || cb.finals[0][0].Text != "" {
+		t.Errorf("finals = %v, want one empty final", cb.finals)
+	}
+	if got := testutil.ToFloat64(m.STTFinalsWithoutAlternatives); got != 1 {
+		t.Errorf("stt_final_without_alternatives_total = %v, want 1", got)
+	}
+}
+
+func TestStreamingConfig_IncludesRecognitionMetadata(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.InteractionType = "phone_call"
+	cfg.IndustryNaicsCode = 517311
+	cfg.MicrophoneDistance = "NEARFIELD"
+	a := &Adapter{config: cfg}
+
+	md := a.streamingConfig().GetConfig().GetMetadata()
+	if md == nil {
+		t.Fatal("expected metadata to be set")
+	}
+	if md.GetInteractionType() != speechpb.RecognitionMetadata_PHONE_CALL {
+		t.Errorf("interaction type = %v", md.GetInteractionType())
+	}
+	if md.GetIndustryNaicsCodeOfAudio() != 517311 {
+		t.Errorf("naics = %d", md.GetIndustryNaicsCodeOfAudio())
+	}
+	if md.GetMicrophoneDistance() != speechpb.RecognitionMetadata_NEARFIELD {
+		t.Errorf("microphone distance = %v", md.GetMicrophoneDistance())
+	}
+}
+
+func TestParseAudioEncoding(t *testing.T) {
+	tests := map[string]speechpb.RecognitionConfig_AudioEncoding{
+		"LINEAR16": speechpb.RecognitionConfig_LINEAR16,
+		"mulaw":    speechpb.RecognitionConfig_MULAW,
+		"OGG_OPUS": speechpb.RecognitionConfig_OGG_OPUS,
+		"bogus":    speechpb.RecognitionConfig_LINEAR16,
+		"":         speechpb.RecognitionConfig_LINEAR16,
+	}
+	for in, want := range tests {
+		if got := parseAudioEncoding(in); got != want {
+			t.Errorf("parseAudioEncoding(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestValidateEncoding(t *testing.T) {
+	for _, name := range []string{"LINEAR16", "mulaw", "OGG_OPUS", ""} {
+		if err := validateEncoding(name); err != nil {
+			t.Errorf("validateEncoding(%q): %v", name, err)
+		}
+	}
+	for _, name := range []string{"LINEAR_16", "ENCODING_UNSPECIFIED", "wav"} {
+		if err := validateEncoding(name); err == nil {
+			t.Errorf("validateEncoding(%q): expected error", name)
+		}
+	}
+}
+
+func TestNewWithPool_RejectsUnknownEncoding(t *testing.T) {
+	p, dialed, _ := newTestPool(t)
+	cfg := DefaultConfig()
+	cfg.Encoding = "LINEAR_16"
+
+	if _, err := newWithPool(context.Background(), p, cfg); err == nil || !strings.Contains(err.Error(), "LINEAR_16") {
+		t.Fatalf("expected unknown encoding error, got %v", err)
+	}
+	if len(*dialed) != 0 {
+		t.Errorf("dialed %d clients for a rejected config", len(*dialed))
+	}
+
+	cfg.AllowUnknownEncoding = true
+	if _, err := newWithPool(context.Background(), p, cfg); err != nil {
+		t.Errorf("AllowUnknownEncoding: %v", err)
+	}
+}
+
+func TestSendAudio_NilStreamCountsDrop(t *testing.T) {
+	m := metrics.New(prometheus.NewRegistry())
+	a := &Adapter{config: DefaultConfig(), audioDropped: m.STTAudioDroppedDuringRestart}
+
+	if err := a.SendAudio(context.Background(), make([]byte, 320)); err != nil {
+		t.Fatalf("SendAudio with no stream: %v", err)
+	}
+	if got := testutil.ToFloat64(m.STTAudioDroppedDuringRestart); got != 1 {
+		t.Errorf("stt_audio_dropped_during_restart_total = %v, want 1", got)
+	}
+}
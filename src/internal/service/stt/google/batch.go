@@ -0,0 +1,59 @@
+package google
+
+import (
+	"context"
+
+	speechpb "cloud.google.com/go/speech/apiv1/speechpb"
+
+	"ai-speech-ingress-service/internal/service/stt"
+)
+
+// Result is one recognized segment of a batch transcription. Alternatives
+// are ordered best first.
+type Result struct {
+	Alternatives []stt.Alternative
+	EndOffsetMs  int64 // End of the segment relative to the start of the audio
+}
+
+// Transcribe recognizes a complete audio clip with Google's synchronous
+// Recognize API. Google limits synchronous requests to about one minute of
+// audio. Results with no alternatives are skipped.
+func Transcribe(ctx context.Context, cfg Config, audio []byte) ([]Result, error) {
+	return transcribeWithPool(ctx, defaultPool, cfg, audio)
+}
+
+func transcribeWithPool(ctx context.Context, pool *clientPool, cfg Config, audio []byte) ([]Result, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	lease, err := pool.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.release(lease)
+
+	resp, err := lease.client.Recognize(ctx, &speechpb.RecognizeRequest{
+		Config: cfg.recognitionConfig(),
+		Audio:  &speechpb.RecognitionAudio{AudioSource: &speechpb.RecognitionAudio_Content{Content: audio}},
+	})
+	if err != nil {
+		pool.markUnhealthy(lease, err)
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		if len(r.Alternatives) == 0 {
+			continue
+		}
+		res := Result{
+			Alternatives: make([]stt.Alternative, len(r.Alternatives)),
+			EndOffsetMs:  r.ResultEndTime.AsDuration().Milliseconds(),
+		}
+		for i, alt := range r.Alternatives {
+			res.Alternatives[i] = stt.Alternative{Text: alt.Transcript, Confidence: float64(alt.Confidence)}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
@@ -0,0 +1,46 @@
+package google
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	speechpb "cloud.google.com/go/speech/apiv1/speechpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestTranscribe(t *testing.T) {
+	p, dialed, _ := newTestPool(t)
+	var got *speechpb.RecognizeRequest
+	if _, err := p.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	(*dialed)[0].recognize = func(req *speechpb.RecognizeRequest) (*speechpb.RecognizeResponse, error) {
+		got = req
+		return &speechpb.RecognizeResponse{Results: []*speechpb.SpeechRecognitionResult{
+			{
+				Alternatives: []*speechpb.SpeechRecognitionAlternative{
+					{Transcript: "hello there", Confidence: 0.5},
+					{Transcript: "hello their", Confidence: 0.25},
+				},
+				ResultEndTime: durationpb.New(1500 * time.Millisecond),
+			},
+			{}, // No alternatives
+		}}, nil
+	}
+
+	results, err := transcribeWithPool(context.Background(), p, DefaultConfig(), []byte{1, 2})
+	if err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+
+	if got.Config.SampleRateHertz != 8000 || got.Config.Model != "phone_call" || len(got.Audio.GetContent()) != 2 {
+		t.Errorf("unexpected request: %v", got)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if r := results[0]; len(r.Alternatives) != 2 || r.Alternatives[0].Text != "hello there" || r.EndOffsetMs != 1500 {
+		t.Errorf("unexpected result: %+v", r)
+	}
+}
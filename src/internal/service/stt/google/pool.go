@@ -0,0 +1,175 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sync"
+
+	speech "cloud.google.com/go/speech/apiv1"
+	speechpb "cloud.google.com/go/speech/apiv1/speechpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ai-speech-ingress-service/internal/observability/metrics"
+)
+
+// speechClient is the subset of *speech.Client used by the adapter.
+type speechClient interface {
+	StreamingRecognize(ctx context.Context, opts ...gax.CallOption) (speechpb.Speech_StreamingRecognizeClient, error)
+	Recognize(ctx context.Context, req *speechpb.RecognizeRequest, opts ...gax.CallOption) (*speechpb.RecognizeResponse, error)
+	Close() error
+}
+
+// pooledClient is a reference-counted handle on a shared speech client.
+type pooledClient struct {
+	client    speechClient
+	refs      int
+	unhealthy bool
+}
+
+// clientPool shares a single speech client (and its gRPC connections) across
+// adapters. The client is closed when the last adapter releases it. A client
+// marked unhealthy stops being handed out; adapters still holding it keep it
+// until they release it.
+type clientPool struct {
+	mu      sync.Mutex
+	current *pooledClient
+	live    int
+	dialing chan struct{} // Closed when the dial in progress finishes
+	dial    func(ctx context.Context) (speechClient, error)
+	size    prometheus.Gauge
+}
+
+// defaultPool is the process-wide pool used by New and NewWithConfig.
+var defaultPool = newClientPool(dialSpeech, metrics.Default.STTClientPoolSize)
+
+// credentialsJSONEnv holds inline service account JSON, for secret managers
+// that provide the credentials as a value rather than a file.
+const credentialsJSONEnv = "GOOGLE_CREDENTIALS_JSON"
+
+func dialSpeech(ctx context.Context) (speechClient, error) {
+	opts, err := clientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return speech.NewClient(ctx, opts...)
+}
+
+// clientOptions returns the credential options for new clients. Inline JSON
+// from GOOGLE_CREDENTIALS_JSON takes precedence; otherwise no options are
+// set and the client uses Application Default Credentials
+// (GOOGLE_APPLICATION_CREDENTIALS).
+func clientOptions() ([]option.ClientOption, error) {
+	creds := os.Getenv(credentialsJSONEnv)
+	if creds == "" {
+		return nil, nil
+	}
+	if !json.Valid([]byte(creds)) {
+		return nil, errors.New(credentialsJSONEnv + " is not valid JSON")
+	}
+	log.Printf("Using Google credentials from %s", credentialsJSONEnv)
+	return []option.ClientOption{option.WithCredentialsJSON([]byte(creds))}, nil
+}
+
+func newClientPool(dial func(ctx context.Context) (speechClient, error), size prometheus.Gauge) *clientPool {
+	return &clientPool{dial: dial, size: size}
+}
+
+// acquire returns a handle on the shared client, dialing a new one if there is
+// no healthy client available. The dial happens outside the lock so a slow
+// one doesn't hold up streams releasing their clients; concurrent acquires
+// wait for it instead of dialing their own.
+func (p *clientPool) acquire(ctx context.Context) (*pooledClient, error) {
+	for {
+		p.mu.Lock()
+		if pc := p.current; pc != nil && !pc.unhealthy {
+			pc.refs++
+			p.mu.Unlock()
+			return pc, nil
+		}
+		if dialing := p.dialing; dialing != nil {
+			p.mu.Unlock()
+			select {
+			case <-dialing:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		dialing := make(chan struct{})
+		p.dialing = dialing
+		p.mu.Unlock()
+
+		c, err := p.dial(ctx)
+
+		p.mu.Lock()
+		p.dialing = nil
+		close(dialing)
+		if err != nil {
+			p.mu.Unlock()
+			return nil, err
+		}
+		pc := &pooledClient{client: c, refs: 1}
+		p.current = pc
+		p.live++
+		p.size.Set(float64(p.live))
+		p.mu.Unlock()
+		return pc, nil
+	}
+}
+
+// release drops a reference, closing the client when no adapters hold it.
+func (p *clientPool) release(pc *pooledClient) error {
+	p.mu.Lock()
+	pc.refs--
+	if pc.refs > 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	if p.current == pc {
+		p.current = nil
+	}
+	p.live--
+	p.size.Set(float64(p.live))
+	p.mu.Unlock()
+	return pc.client.Close()
+}
+
+// renew returns pc, or a handle on a fresh client in its place if pc was
+// marked unhealthy.
+func (p *clientPool) renew(ctx context.Context, pc *pooledClient) (*pooledClient, error) {
+	p.mu.Lock()
+	unhealthy := pc.unhealthy
+	p.mu.Unlock()
+	if !unhealthy {
+		return pc, nil
+	}
+
+	next, err := p.acquire(ctx)
+	if err != nil {
+		return pc, err
+	}
+	if err := p.release(pc); err != nil {
+		log.Printf("Failed to close unhealthy speech client: %v", err)
+	}
+	return next, nil
+}
+
+// markUnhealthy stops handing out pc if err indicates a broken connection.
+func (p *clientPool) markUnhealthy(pc *pooledClient, err error) {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Unauthenticated:
+	default:
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pc.unhealthy = true
+}
@@ -0,0 +1,356 @@
+package google
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	speechpb "cloud.google.com/go/speech/apiv1/speechpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ai-speech-ingress-service/internal/observability/metrics"
+)
+
+type fakeClient struct {
+	closed    int
+	streamErr error
+	recognize func(req *speechpb.RecognizeRequest) (*speechpb.RecognizeResponse, error)
+}
+
+func (c *fakeClient) StreamingRecognize(ctx context.Context, opts ...gax.CallOption) (speechpb.Speech_StreamingRecognizeClient, error) {
+	return nil, c.streamErr
+}
+
+func (c *fakeClient) Recognize(ctx context.Context, req *speechpb.RecognizeRequest, opts ...gax.CallOption) (*speechpb.RecognizeResponse, error) {
+	return c.recognize(req)
+}
+
+func (c *fakeClient) Close() error {
+	c.closed++
+	return nil
+}
+
+func newTestPool(t *testing.T) (*clientPool, *[]*fakeClient, *metrics.Metrics) {
+	t.Helper()
+	m := metrics.New(prometheus.NewRegistry())
+	var dialed []*fakeClient
+	p := newClientPool(func(ctx context.Context) (speechClient, error) {
+		c := &fakeClient{}
+		dialed = append(dialed, c)
+		return c, nil
+	}, m.STTClientPoolSize)
+	return p, &dialed, m
+}
+
+func TestClientPool_SharesClientUntilLastRelease(t *testing.T) {
+	p, dialed, m := newTestPool(t)
+	ctx := context.Background()
+
+	a, _ := p.acquire(ctx)
+	b, _ := p.acquire(ctx)
+	if a != b || len(*dialed) != 1 {
+		t.Fatalf("expected one shared client, dialed %d", len(*dialed))
+	}
+	if got := testutil.ToFloat64(m.STTClientPoolSize); got != 1 {
+		t.Errorf("pool size = %v, want 1", got)
+	}
+
+	p.release(a)
+	if (*dialed)[0].closed != 0 {
+		t.Fatal("client closed while still referenced")
+	}
+	p.release(b)
+	if (*dialed)[0].closed != 1 {
+		t.Fatalf("client closed %d times after last release, want 1", (*dialed)[0].closed)
+	}
+	if got := testutil.ToFloat64(m.STTClientPoolSize); got != 0 {
+		t.Errorf("pool size = %v, want 0", got)
+	}
+
+	// A fresh acquire after the pool drained dials a new client.
+	p.acquire(ctx)
+	if len(*dialed) != 2 {
+		t.Errorf("expected redial after drain, dialed %d", len(*dialed))
+	}
+}
+
+func TestClientPool_UnhealthyClientReplaced(t *testing.T) {
+	p, dialed, m := newTestPool(t)
+	ctx := context.Background()
+
+	old, _ := p.acquire(ctx)
+	p.markUnhealthy(old, status.Error(codes.Unavailable, "connection reset"))
+
+	fresh, _ := p.acquire(ctx)
+	if fresh == old || len(*dialed) != 2 {
+		t.Fatal("expected unhealthy client to be replaced")
+	}
+	if got := testutil.ToFloat64(m.STTClientPoolSize); got != 2 {
+		t.Errorf("pool size = %v, want 2", got)
+	}
+
+	p.release(old)
+	if (*dialed)[0].closed != 1 || (*dialed)[1].closed != 0 {
+		t.Error("expected only the unhealthy client to close")
+	}
+	if again, _ := p.acquire(ctx); again != fresh {
+		t.Error("expected healthy client to keep being shared")
+	}
+}
+
+func TestClientPool_IgnoresNonConnectionErrors(t *testing.T) {
+	p, dialed, _ := newTestPool(t)
+	ctx := context.Background()
+
+	pc, _ := p.acquire(ctx)
+	p.markUnhealthy(pc, status.Error(codes.InvalidArgument, "bad config"))
+	p.acquire(ctx)
+	if len(*dialed) != 1 {
+		t.Errorf("expected client to stay in use, dialed %d", len(*dialed))
+	}
+}
+
+func TestAdapter_CloseReleasesOnce(t *testing.T) {
+	p, dialed, _ := newTestPool(t)
+	ctx := context.Background()
+
+	a1, _ := newWithPool(ctx, p, DefaultConfig())
+	a2, _ := newWithPool(ctx, p, DefaultConfig())
+
+	a1.Close()
+	a1.Close()
+	if (*dialed)[0].closed != 0 {
+		t.Fatal("double Close released another adapter's reference")
+	}
+	a2.Close()
+	if (*dialed)[0].closed != 1 {
+		t.Errorf("client closed %d times, want 1", (*dialed)[0].closed)
+	}
+}
+
+func TestClientPool_DialOutsideLock(t *testing.T) {
+	m := metrics.New(prometheus.NewRegistry())
+	unblock := make(chan struct{})
+	var dials atomic.Int32
+	p := newClientPool(func(ctx context.Context) (speechClient, error) {
+		if dials.Add(1) > 1 {
+			<-unblock
+		}
+		return &fakeClient{}, nil
+	}, m.STTClientPoolSize)
+	ctx := context.Background()
+
+	old, _ := p.acquire(ctx)
+	p.markUnhealthy(old, status.Error(codes.Unavailable, "connection reset"))
+
+	// Both wait on the one slow dial
+	acquired := make(chan *pooledClient, 2)
+	for range 2 {
+		go func() {
+			pc, _ := p.acquire(ctx)
+			acquired <- pc
+		}()
+	}
+
+	released := make(chan struct{})
+	go func() {
+		p.release(old)
+		close(released)
+	}()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("release blocked behind a dial")
+	}
+
+	close(unblock)
+	a, b := <-acquired, <-acquired
+	if a != b || dials.Load() != 2 {
+		t.Errorf("expected concurrent acquires to share one dial, dials=%d", dials.Load())
+	}
+}
+
+// chanRecognizeStream delivers responses from recv until it is closed, then
+// returns err.
+type chanRecognizeStream struct {
+	fakeRecognizeStream
+	recv chan *speechpb.StreamingRecognizeResponse
+	err  error
+}
+
+func (s *chanRecognizeStream) Recv() (*speechpb.StreamingRecognizeResponse, error) {
+	if resp, ok := <-s.recv; ok {
+		return resp, nil
+	}
+	return nil, s.err
+}
+
+func TestAdapter_CloseKeepsClientUntilListenEnds(t *testing.T) {
+	tests := []struct {
+		name string
+		end  error
+	}{
+		{"stream ends", io.EOF},
+		{"stream canceled", status.Error(codes.Canceled, "context canceled")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, dialed, _ := newTestPool(t)
+			a, _ := newWithPool(context.Background(), p, DefaultConfig())
+			stream := &chanRecognizeStream{recv: make(chan *speechpb.StreamingRecognizeResponse), err: tt.end}
+			cb := &altsCallback{}
+			a.stream, a.cb = stream, cb
+
+			done := make(chan struct{})
+			go func() {
+				a.Listen()
+				close(done)
+			}()
+			// The final for the trailing audio arrives after the half-close
+			stream.recv <- interim("book a flight", 1)
+			a.Close()
+			if (*dialed)[0].closed != 0 {
+				t.Fatal("client closed while the stream was still receiving")
+			}
+			stream.recv <- &speechpb.StreamingRecognizeResponse{Results: []*speechpb.StreamingRecognitionResult{{
+				IsFinal:      true,
+				Alternatives: []*speechpb.SpeechRecognitionAlternative{{Transcript: "book a flight"}},
+			}}}
+			close(stream.recv)
+			<-done
+
+			if len(cb.finals) != 1 {
+				t.Errorf("finals = %v, want the trailing final", cb.finals)
+			}
+			if len(cb.errs) != 0 {
+				t.Errorf("errors = %v, want none for a normal end", cb.errs)
+			}
+			if (*dialed)[0].closed != 1 {
+				t.Errorf("client closed %d times after Listen ended, want 1", (*dialed)[0].closed)
+			}
+		})
+	}
+}
+
+func TestAdapter_StartFailureMarksUnhealthy(t *testing.T) {
+	m := metrics.New(prometheus.NewRegistry())
+	broken := &fakeClient{streamErr: status.Error(codes.Unavailable, "down")}
+	dials := 0
+	p := newClientPool(func(ctx context.Context) (speechClient, error) {
+		dials++
+		if dials == 1 {
+			return broken, nil
+		}
+		return &fakeClient{}, nil
+	}, m.STTClientPoolSize)
+
+	a, _ := newWithPool(context.Background(), p, DefaultConfig())
+	if err := a.Start(context.Background(), nil); err == nil {
+		t.Fatalf("expected start error, got %v", err)
+	}
+	p.acquire(context.Background())
+	if dials != 2 {
+		t.Errorf("expected redial after unavailable error, dials=%d", dials)
+	}
+}
+
+// flakyClient fails StreamingRecognize while *failures is positive.
+type flakyClient struct {
+	fakeClient
+	failures *int
+}
+
+func (c *flakyClient) StreamingRecognize(ctx context.Context, opts ...gax.CallOption) (speechpb.Speech_StreamingRecognizeClient, error) {
+	if *c.failures > 0 {
+		*c.failures--
+		return nil, status.Error(codes.Unavailable, "down")
+	}
+	return &fakeRecognizeStream{}, nil
+}
+
+func TestAdapter_StartRetriesTransientErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		retries  int
+		wantErr  bool
+		wantDial int
+	}{
+		{"recovers within retries", 2, false, 3},
+		{"gives up after retries", 1, true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := metrics.New(prometheus.NewRegistry())
+			failures, dials := 2, 0
+			p := newClientPool(func(ctx context.Context) (speechClient, error) {
+				dials++
+				return &flakyClient{failures: &failures}, nil
+			}, m.STTClientPoolSize)
+
+			cfg := DefaultConfig()
+			cfg.StartRetries = tt.retries
+			cfg.StartBackoff = time.Millisecond
+			a, _ := newWithPool(context.Background(), p, cfg)
+			a.startFailures = m.STTRestartFailures
+
+			err := a.Start(context.Background(), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Start error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// Unavailable marks the client unhealthy, so each retry redials
+			if dials != tt.wantDial {
+				t.Errorf("dials = %d, want %d", dials, tt.wantDial)
+			}
+			if got := testutil.ToFloat64(m.STTRestartFailures); got != 2 {
+				t.Errorf("stt_restart_failures_total = %v, want 2", got)
+			}
+			a.Close()
+			if got := testutil.ToFloat64(m.STTClientPoolSize); got != 0 {
+				t.Errorf("pool size after close = %v, want 0", got)
+			}
+		})
+	}
+}
+
+func TestStartBackoff_Capped(t *testing.T) {
+	for attempt := 0; attempt < 20; attempt++ {
+		if d := startBackoff(time.Second, attempt); d <= 0 || d > maxStartBackoff {
+			t.Fatalf("attempt %d: backoff %s outside (0, %s]", attempt, d, maxStartBackoff)
+		}
+	}
+}
+
+func TestClientOptions_InlineCredentials(t *testing.T) {
+	t.Setenv(credentialsJSONEnv, `{"type":"service_account"}`)
+
+	opts, err := clientOptions()
+	if err != nil {
+		t.Fatalf("clientOptions: %v", err)
+	}
+	if len(opts) != 1 {
+		t.Errorf("got %d options, want the inline credentials", len(opts))
+	}
+}
+
+func TestClientOptions_DefaultCredentials(t *testing.T) {
+	t.Setenv(credentialsJSONEnv, "")
+
+	opts, err := clientOptions()
+	if err != nil || len(opts) != 0 {
+		t.Errorf("clientOptions = %v, %v; want no options so ADC is used", opts, err)
+	}
+}
+
+func TestClientOptions_InvalidJSON(t *testing.T) {
+	t.Setenv(credentialsJSONEnv, "not json")
+
+	if _, err := clientOptions(); err == nil {
+		t.Error("expected an error for invalid credentials JSON")
+	}
+}
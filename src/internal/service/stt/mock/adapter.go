@@ -55,12 +55,18 @@ var DefaultUtterances = []SimulatedUtterance{
 type Adapter struct {
 	cb                 stt.Callback
 	mu                 sync.Mutex
-	audioReceived      int                // Count of audio frames received
-	utterance          SimulatedUtterance // Current utterance being simulated
-	partialIndex       int                // Next partial to send
-	finalSent          bool               // Ensures only one final per utterance
-	endOfUtteranceSent bool               // Ensures only one end-of-utterance per utterance
+	audioReceived      int                  // Count of audio frames received
+	script             []SimulatedUtterance // Utterances played in order
+	next               int                  // Index of the next utterance in script
+	utterance          SimulatedUtterance   // Current utterance being simulated
+	partialIndex       int                  // Next partial to send
+	finalSent          bool                 // Ensures only one final per utterance
+	endOfUtteranceSent bool                 // Ensures only one end-of-utterance per utterance
+	utteranceDone      bool                 // Final and end-of-utterance were delivered
+	synchronous        bool                 // Deliver callbacks inline, without delay
 	closed             bool
+	done               chan struct{}  // Closed by Close to cancel scheduled callbacks
+	pending            sync.WaitGroup // Scheduled callbacks not yet delivered or cancelled
 }
 
 // utteranceCounter tracks which utterance to use next (cycles through defaults)
@@ -69,16 +75,49 @@ var (
 	counterMu        sync.Mutex
 )
 
-// New creates a new mock STT adapter.
+// New creates a new mock STT adapter that simulates one utterance, cycling
+// through DefaultUtterances across adapters.
 func New() *Adapter {
 	counterMu.Lock()
 	idx := utteranceCounter % len(DefaultUtterances)
 	utteranceCounter++
 	counterMu.Unlock()
 
-	return &Adapter{
-		utterance: DefaultUtterances[idx],
+	return NewWithScript([]SimulatedUtterance{DefaultUtterances[idx]})
+}
+
+// NewWithScript creates a mock STT adapter that plays the given utterances in
+// order, independent of other adapters. Each utterance after the first starts
+// with the first audio frame following the previous end of utterance. Once the
+// script is exhausted, further audio produces no transcripts.
+func NewWithScript(script []SimulatedUtterance) *Adapter {
+	a := &Adapter{script: script, done: make(chan struct{})}
+	a.advance()
+	return a
+}
+
+// SetSynchronous makes the adapter deliver callbacks inline from SendAudio
+// and Close instead of after a simulated delay, so tests can assert on them
+// without sleeping. Adapters are asynchronous by default.
+func (a *Adapter) SetSynchronous(synchronous bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.synchronous = synchronous
+}
+
+// advance loads the next scripted utterance. Returns false if the script is
+// exhausted. Callers must hold a.mu unless a is not yet shared.
+func (a *Adapter) advance() bool {
+	if a.next >= len(a.script) {
+		return false
 	}
+	a.utterance = a.script[a.next]
+	a.next++
+	a.partialIndex = 0
+	a.finalSent = false
+	a.endOfUtteranceSent = false
+	a.utteranceDone = false
+	return true
 }
 
 // Start begins a mock transcription session.
@@ -91,36 +130,43 @@ func (a *Adapter) Start(ctx context.Context, cb stt.Callback) error {
 // When all partials are sent, it simulates end-of-utterance detection (like silence detection).
 func (a *Adapter) SendAudio(ctx context.Context, audio []byte) error {
 	a.mu.Lock()
-	defer a.mu.Unlock()
 
 	if a.closed || a.cb == nil {
+		a.mu.Unlock()
 		return nil
 	}
 
 	a.audioReceived++
 
+	if a.utteranceDone && !a.advance() {
+		a.mu.Unlock()
+		return nil
+	}
+
+	var deliver func()
+	var delay time.Duration
 	// Send next partial if available (one partial per audio frame)
 	if a.partialIndex < len(a.utterance.Partials) {
 		partial := a.utterance.Partials[a.partialIndex]
 		a.partialIndex++
 
 		// Simulate processing delay
-		go func(text string) {
-			time.Sleep(50 * time.Millisecond)
+		delay = 50 * time.Millisecond
+		deliver = func() {
 			a.mu.Lock()
 			if !a.closed && a.cb != nil {
-				a.cb.OnPartial(text)
+				a.cb.OnPartial(partial)
 			}
 			a.mu.Unlock()
-		}(partial)
+		}
 	} else if !a.finalSent {
 		// All partials sent - simulate utterance completion
 		// This mimics silence detection triggering end of utterance
 		a.finalSent = true
 		a.endOfUtteranceSent = true
 
-		go func() {
-			time.Sleep(100 * time.Millisecond)
+		delay = 100 * time.Millisecond
+		deliver = func() {
 			a.mu.Lock()
 			cb := a.cb
 			closed := a.closed
@@ -133,32 +179,77 @@ func (a *Adapter) SendAudio(ctx context.Context, audio []byte) error {
 				// Signal end of utterance (speaker stopped talking)
 				cb.OnEndOfUtterance()
 			}
-		}()
+
+			a.mu.Lock()
+			a.utteranceDone = true
+			a.mu.Unlock()
+		}
 	}
+	a.mu.Unlock()
 
+	if deliver != nil {
+		a.schedule(delay, deliver)
+	}
 	return nil
 }
 
-// Close ends the mock session.
-// If final wasn't sent via SendAudio (stream ended early), send it now.
+// DetectsUtterances reports that the scripted finals end their utterances.
+func (a *Adapter) DetectsUtterances() bool { return true }
+
+// Close ends the mock session, cancelling callbacks still waiting out their
+// simulated delay. If final wasn't sent via SendAudio (stream ended early), it
+// is delivered before Close returns; no callbacks are delivered after.
 func (a *Adapter) Close() error {
 	a.mu.Lock()
-	defer a.mu.Unlock()
 
 	if a.closed {
+		a.mu.Unlock()
 		return nil
 	}
 	a.closed = true
+	close(a.done)
 
 	// If final wasn't sent yet (stream ended before natural utterance end),
 	// send final now based on whatever partials we received
-	if !a.finalSent && a.cb != nil {
-		a.finalSent = true
-		go func() {
-			time.Sleep(100 * time.Millisecond)
-			a.cb.OnFinal(a.utterance.Final, a.utterance.Confidence)
-		}()
-	}
+	cb, utt := a.cb, a.utterance
+	sendFinal := !a.finalSent && cb != nil
+	a.finalSent = a.finalSent || sendFinal
+	a.mu.Unlock()
 
+	if sendFinal {
+		cb.OnFinal(utt.Final, utt.Confidence)
+	}
+	a.pending.Wait()
 	return nil
 }
+
+// schedule runs a callback delivery after the simulated processing delay, or
+// inline in synchronous mode. Deliveries pending when the adapter is closed
+// are dropped.
+func (a *Adapter) schedule(delay time.Duration, deliver func()) {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return
+	}
+	synchronous := a.synchronous
+	if !synchronous {
+		a.pending.Add(1)
+	}
+	a.mu.Unlock()
+
+	if synchronous {
+		deliver()
+		return
+	}
+	go func() {
+		defer a.pending.Done()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			deliver()
+		case <-a.done:
+		}
+	}()
+}
@@ -0,0 +1,160 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder captures callbacks as "partial:<text>", "final:<text>" and "eou".
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(ev string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *recorder) OnPartial(text string)          { r.add("partial:" + text) }
+func (r *recorder) OnFinal(text string, _ float64) { r.add("final:" + text) }
+func (r *recorder) OnEndOfUtterance()              { r.add("eou") }
+func (r *recorder) OnError(err error)              { r.add(fmt.Sprintf("error:%v", err)) }
+
+// waitFor waits until n events were recorded and returns them.
+func (r *recorder) waitFor(t *testing.T, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		r.mu.Lock()
+		events := slices.Clone(r.events)
+		r.mu.Unlock()
+		if len(events) >= n {
+			return events
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d events, got %v", n, events)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// checkNoLeaks fails the test if goroutines started during it are still
+// running when it ends.
+func checkNoLeaks(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				t.Errorf("leaked %d goroutines", runtime.NumGoroutine()-before)
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	})
+}
+
+func TestNewWithScript_PlaysUtterancesInOrder(t *testing.T) {
+	a := NewWithScript([]SimulatedUtterance{
+		{Partials: []string{"one"}, Final: "one two", Confidence: 0.9},
+		{Partials: []string{"three"}, Final: "three four", Confidence: 0.8},
+	})
+	a.SetSynchronous(true)
+	cb := &recorder{}
+	ctx := context.Background()
+	a.Start(ctx, cb)
+
+	// One frame per partial and one that ends the utterance, then one more
+	// after the script is exhausted
+	for range 5 {
+		a.SendAudio(ctx, []byte{0, 0})
+	}
+	a.Close()
+
+	want := []string{"partial:one", "final:one two", "eou", "partial:three", "final:three four", "eou"}
+	if !slices.Equal(cb.events, want) {
+		t.Errorf("events = %v, want %v", cb.events, want)
+	}
+}
+
+func TestAdapter_AsyncDeliversAfterDelay(t *testing.T) {
+	a := NewWithScript([]SimulatedUtterance{{Partials: []string{"one"}, Final: "one two"}})
+	cb := &recorder{}
+	ctx := context.Background()
+	a.Start(ctx, cb)
+
+	a.SendAudio(ctx, []byte{0, 0})
+	if got := cb.waitFor(t, 0); len(got) != 0 {
+		t.Errorf("events delivered inline: %v", got)
+	}
+	a.SendAudio(ctx, []byte{0, 0})
+
+	want := []string{"partial:one", "final:one two", "eou"}
+	if got := cb.waitFor(t, 3); !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestAdapter_CloseSendsPendingFinal(t *testing.T) {
+	a := NewWithScript([]SimulatedUtterance{{Partials: []string{"one", "one two"}, Final: "one two three"}})
+	a.SetSynchronous(true)
+	cb := &recorder{}
+	ctx := context.Background()
+	a.Start(ctx, cb)
+
+	a.SendAudio(ctx, []byte{0, 0})
+	a.Close()
+	a.SendAudio(ctx, []byte{0, 0})
+
+	want := []string{"partial:one", "final:one two three"}
+	if !slices.Equal(cb.events, want) {
+		t.Errorf("events = %v, want %v", cb.events, want)
+	}
+}
+
+func TestAdapter_CloseSendsPendingFinalAsync(t *testing.T) {
+	checkNoLeaks(t)
+	a := NewWithScript([]SimulatedUtterance{{Partials: []string{"one", "one two"}, Final: "one two three"}})
+	cb := &recorder{}
+	ctx := context.Background()
+	a.Start(ctx, cb)
+
+	a.SendAudio(ctx, []byte{0, 0})
+	cb.waitFor(t, 1)
+	a.Close()
+
+	// The final is delivered by the time Close returns, not after a delay
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if want := []string{"partial:one", "final:one two three"}; !slices.Equal(cb.events, want) {
+		t.Errorf("events = %v, want %v", cb.events, want)
+	}
+}
+
+func TestAdapter_CloseCancelsScheduledCallbacks(t *testing.T) {
+	checkNoLeaks(t)
+	a := NewWithScript([]SimulatedUtterance{{Partials: []string{"one"}, Final: "one two"}})
+	cb := &recorder{}
+	ctx := context.Background()
+	a.Start(ctx, cb)
+
+	// A partial, then the final and end of utterance, all still delayed
+	a.SendAudio(ctx, []byte{0, 0})
+	a.SendAudio(ctx, []byte{0, 0})
+	a.Close()
+
+	time.Sleep(150 * time.Millisecond)
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if len(cb.events) != 0 {
+		t.Errorf("events delivered after Close: %v", cb.events)
+	}
+}
@@ -0,0 +1,268 @@
+// Package whisper provides an STT adapter for Whisper transcription servers
+// exposing the OpenAI-compatible /v1/audio/transcriptions endpoint (OpenAI,
+// whisper.cpp server, faster-whisper-server, ...).
+//
+// Whisper is not a streaming recognizer, so the adapter buffers audio and
+// transcribes it in chunks. A chunk ends at an utterance boundary, detected as
+// a run of trailing silence, or when it reaches MaxChunkDuration. Each chunk
+// produces a single OnFinal followed by OnEndOfUtterance; no partials are
+// emitted. Whisper does not report confidence, so finals carry 0.
+package whisper
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"ai-speech-ingress-service/internal/service/recording"
+	"ai-speech-ingress-service/internal/service/stt"
+)
+
+// ErrClosed is returned by SendAudio after Close.
+var ErrClosed = errors.New("whisper adapter closed")
+
+// Config holds settings for the Whisper adapter.
+type Config struct {
+	Endpoint     string // Transcription URL, e.g. "https://api.openai.com/v1/audio/transcriptions"
+	APIKey       string // Sent as a bearer token when set
+	Model        string // e.g. "whisper-1"
+	LanguageCode string // BCP-47 code; only the primary language subtag is sent
+	SampleRateHz int
+	Encoding     string // "LINEAR16" or "MULAW"
+
+	// SilenceDuration of trailing silence ends an utterance. Silence is only
+	// detected for LINEAR16; other encodings are chunked by MaxChunkDuration.
+	SilenceDuration time.Duration
+	// SilenceThreshold is the RMS sample amplitude below which audio counts as silence.
+	SilenceThreshold float64
+	// MaxChunkDuration bounds the audio sent in a single request.
+	MaxChunkDuration time.Duration
+	// RequestTimeout bounds a single transcription request.
+	RequestTimeout time.Duration
+}
+
+// DefaultConfig returns the telephony defaults (8kHz LINEAR16, en-US) against
+// the OpenAI API.
+func DefaultConfig() Config {
+	return Config{
+		Endpoint:         "https://api.openai.com/v1/audio/transcriptions",
+		Model:            "whisper-1",
+		LanguageCode:     "en-US",
+		SampleRateHz:     8000,
+		Encoding:         "LINEAR16",
+		SilenceDuration:  800 * time.Millisecond,
+		SilenceThreshold: 500,
+		MaxChunkDuration: 30 * time.Second,
+		RequestTimeout:   30 * time.Second,
+	}
+}
+
+// Adapter implements stt.Adapter by chunking audio and calling a Whisper server.
+type Adapter struct {
+	config Config
+	client *http.Client
+	cb     stt.Callback
+	ctx    context.Context
+
+	mu          sync.Mutex
+	buf         []byte
+	heardSpeech bool // buf contains non-silent audio
+	silentBytes int  // Length of the trailing silence in buf
+	closed      bool
+
+	chunks chan []byte
+	done   chan struct{}
+}
+
+// New creates a new Whisper STT adapter.
+func New(cfg Config) *Adapter {
+	return &Adapter{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+	}
+}
+
+// Start begins a transcription session. Chunks are transcribed in order on a
+// background goroutine so SendAudio never waits on HTTP round trips.
+func (a *Adapter) Start(ctx context.Context, cb stt.Callback) error {
+	a.cb = cb
+	a.ctx = ctx
+	a.chunks = make(chan []byte, 4)
+	a.done = make(chan struct{})
+	go a.run()
+	return nil
+}
+
+// SendAudio buffers audio, handing off a chunk for transcription when an
+// utterance boundary or the chunk size limit is reached. Leading silence is
+// discarded.
+func (a *Adapter) SendAudio(ctx context.Context, audio []byte) error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return ErrClosed
+	}
+
+	silent := a.isSilent(audio)
+	if silent && !a.heardSpeech {
+		a.mu.Unlock()
+		return nil
+	}
+	a.buf = append(a.buf, audio...)
+	if silent {
+		a.silentBytes += len(audio)
+	} else {
+		a.heardSpeech = true
+		a.silentBytes = 0
+	}
+
+	var chunk []byte
+	if a.silentBytes >= a.bytesFor(a.config.SilenceDuration) || len(a.buf) >= a.bytesFor(a.config.MaxChunkDuration) {
+		chunk = a.takeChunk()
+	}
+	a.mu.Unlock()
+
+	if chunk != nil {
+		a.chunks <- chunk
+	}
+	return nil
+}
+
+// DetectsUtterances reports that each chunk, cut on silence, ends an
+// utterance.
+func (a *Adapter) DetectsUtterances() bool { return true }
+
+// Close transcribes any buffered speech and waits for pending chunks to finish.
+func (a *Adapter) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	var chunk []byte
+	if a.heardSpeech {
+		chunk = a.takeChunk()
+	}
+	a.mu.Unlock()
+
+	if a.chunks == nil {
+		return nil
+	}
+	if chunk != nil {
+		a.chunks <- chunk
+	}
+	close(a.chunks)
+	<-a.done
+	return nil
+}
+
+// takeChunk returns the buffered audio without its trailing silence and
+// resets the buffer. Callers must hold a.mu.
+func (a *Adapter) takeChunk() []byte {
+	chunk := a.buf[:len(a.buf)-a.silentBytes]
+	a.buf = nil
+	a.heardSpeech = false
+	a.silentBytes = 0
+	return chunk
+}
+
+func (a *Adapter) run() {
+	defer close(a.done)
+	for chunk := range a.chunks {
+		text, err := a.transcribe(chunk)
+		if err != nil {
+			a.cb.OnError(err)
+			continue
+		}
+		a.cb.OnFinal(text, 0)
+		a.cb.OnEndOfUtterance()
+	}
+}
+
+// transcribe uploads a chunk as a WAV file and returns the transcript text.
+func (a *Adapter) transcribe(chunk []byte) (string, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	fw, err := mw.CreateFormFile("file", "audio.wav")
+	if err != nil {
+		return "", err
+	}
+	if err := recording.WriteWAVHeader(fw, a.config.SampleRateHz, a.config.Encoding, uint32(len(chunk))); err != nil {
+		return "", err
+	}
+	if _, err := fw.Write(chunk); err != nil {
+		return "", err
+	}
+	_ = mw.WriteField("model", a.config.Model)
+	_ = mw.WriteField("response_format", "json")
+	if lang, _, _ := strings.Cut(a.config.LanguageCode, "-"); lang != "" {
+		_ = mw.WriteField("language", strings.ToLower(lang))
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(a.ctx, http.MethodPost, a.config.Endpoint, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if a.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("whisper request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("whisper request: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var out struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("whisper response: %w", err)
+	}
+	return strings.TrimSpace(out.Text), nil
+}
+
+// bytesFor converts a duration to a byte count for the configured format.
+func (a *Adapter) bytesFor(d time.Duration) int {
+	width := 1
+	if strings.ToUpper(a.config.Encoding) == "LINEAR16" {
+		width = 2
+	}
+	return int(d.Seconds() * float64(a.config.SampleRateHz*width))
+}
+
+// isSilent reports whether a LINEAR16 frame's RMS amplitude is below the
+// silence threshold. Frames of other encodings are never treated as silent.
+func (a *Adapter) isSilent(audio []byte) bool {
+	if strings.ToUpper(a.config.Encoding) != "LINEAR16" || len(audio) < 2 {
+		return false
+	}
+	var sum float64
+	n := len(audio) / 2
+	for i := 0; i < n; i++ {
+		s := float64(int16(binary.LittleEndian.Uint16(audio[2*i:])))
+		sum += s * s
+	}
+	return math.Sqrt(sum/float64(n)) < a.config.SilenceThreshold
+}
@@ -0,0 +1,179 @@
+package whisper
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder captures callbacks.
+type recorder struct {
+	mu     sync.Mutex
+	finals []string
+	eous   int
+	errs   []error
+}
+
+func (r *recorder) OnPartial(string) {}
+func (r *recorder) OnFinal(text string, _ float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finals = append(r.finals, text)
+}
+func (r *recorder) OnEndOfUtterance() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.eous++
+}
+func (r *recorder) OnError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+// request is what the fake Whisper server received.
+type request struct {
+	auth, model, language string
+	audio                 []byte
+}
+
+func newFakeServer(t *testing.T, status int, body string) (*httptest.Server, chan request) {
+	t.Helper()
+	reqs := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("missing file: %v", err)
+			return
+		}
+		audio, _ := io.ReadAll(f)
+		reqs <- request{
+			auth:     r.Header.Get("Authorization"),
+			model:    r.FormValue("model"),
+			language: r.FormValue("language"),
+			audio:    audio,
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, reqs
+}
+
+// frame returns 100ms of 8kHz LINEAR16 audio at a constant amplitude.
+func frame(amplitude int16) []byte {
+	b := make([]byte, 1600)
+	for i := 0; i < len(b); i += 2 {
+		binary.LittleEndian.PutUint16(b[i:], uint16(amplitude))
+	}
+	return b
+}
+
+func newTestAdapter(t *testing.T, endpoint string) (*Adapter, *recorder) {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.Endpoint = endpoint
+	cfg.APIKey = "secret"
+	a := New(cfg)
+	rec := &recorder{}
+	if err := a.Start(context.Background(), rec); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	return a, rec
+}
+
+func TestAdapter_TranscribesOnTrailingSilence(t *testing.T) {
+	srv, reqs := newFakeServer(t, http.StatusOK, `{"text":" hello world "}`)
+	a, rec := newTestAdapter(t, srv.URL)
+	ctx := context.Background()
+
+	a.SendAudio(ctx, frame(0)) // leading silence is discarded
+	for i := 0; i < 3; i++ {
+		a.SendAudio(ctx, frame(2000))
+	}
+	for i := 0; i < 8; i++ { // 800ms of silence ends the utterance
+		a.SendAudio(ctx, frame(0))
+	}
+
+	select {
+	case req := <-reqs:
+		if req.auth != "Bearer secret" || req.model != "whisper-1" || req.language != "en" {
+			t.Errorf("unexpected request: auth=%q model=%q language=%q", req.auth, req.model, req.language)
+		}
+		if string(req.audio[:4]) != "RIFF" || len(req.audio) != 44+3*1600 {
+			t.Errorf("expected WAV of the speech frames only, got %d bytes", len(req.audio))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no transcription request sent")
+	}
+
+	a.Close()
+	if len(rec.finals) != 1 || rec.finals[0] != "hello world" || rec.eous != 1 {
+		t.Errorf("finals=%q eous=%d, want one final then end of utterance", rec.finals, rec.eous)
+	}
+}
+
+func TestAdapter_CloseFlushesBufferedSpeech(t *testing.T) {
+	srv, reqs := newFakeServer(t, http.StatusOK, `{"text":"goodbye"}`)
+	a, rec := newTestAdapter(t, srv.URL)
+
+	a.SendAudio(context.Background(), frame(2000))
+	a.Close()
+
+	if len(reqs) != 1 || len(rec.finals) != 1 || rec.finals[0] != "goodbye" {
+		t.Errorf("requests=%d finals=%q, want buffered speech transcribed on close", len(reqs), rec.finals)
+	}
+	if err := a.SendAudio(context.Background(), frame(2000)); err != ErrClosed {
+		t.Errorf("SendAudio after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestAdapter_SilenceOnlySendsNothing(t *testing.T) {
+	srv, reqs := newFakeServer(t, http.StatusOK, `{"text":""}`)
+	a, rec := newTestAdapter(t, srv.URL)
+
+	for i := 0; i < 20; i++ {
+		a.SendAudio(context.Background(), frame(0))
+	}
+	a.Close()
+
+	if len(reqs) != 0 || len(rec.finals) != 0 {
+		t.Errorf("requests=%d finals=%d, want none for silence", len(reqs), len(rec.finals))
+	}
+}
+
+func TestAdapter_MaxChunkDuration(t *testing.T) {
+	srv, reqs := newFakeServer(t, http.StatusOK, `{"text":"long"}`)
+	cfg := DefaultConfig()
+	cfg.Endpoint = srv.URL
+	cfg.MaxChunkDuration = 300 * time.Millisecond
+	a := New(cfg)
+	rec := &recorder{}
+	a.Start(context.Background(), rec)
+
+	for i := 0; i < 6; i++ {
+		a.SendAudio(context.Background(), frame(2000))
+	}
+	a.Close()
+
+	if len(reqs) != 2 || len(rec.finals) != 2 {
+		t.Errorf("requests=%d finals=%d, want speech split into 2 chunks", len(reqs), len(rec.finals))
+	}
+}
+
+func TestAdapter_ServerErrorReported(t *testing.T) {
+	srv, _ := newFakeServer(t, http.StatusUnauthorized, `{"error":"bad key"}`)
+	a, rec := newTestAdapter(t, srv.URL)
+
+	a.SendAudio(context.Background(), frame(2000))
+	a.Close()
+
+	if len(rec.errs) != 1 || len(rec.finals) != 0 || rec.eous != 0 {
+		t.Errorf("errs=%v finals=%q eous=%d, want a single error", rec.errs, rec.finals, rec.eous)
+	}
+}
@@ -0,0 +1,200 @@
+package transform
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NumberNormalizer is a rule-based inverse text normalizer that rewrites
+// spoken numbers and times in digit form:
+//
+//	"three thirty pm"           → "3:30 PM"
+//	"twenty five dollars"       → "25 dollars"
+//	"four one one one"          → "4111"
+//
+// Standalone numbers below ten ("I want one") are left as words, matching
+// common ITN conventions.
+type NumberNormalizer struct{}
+
+var unitWords = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16,
+	"seventeen": 17, "eighteen": 18, "nineteen": 19,
+}
+
+var tensWords = map[string]int{
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+var meridiemWords = map[string]string{
+	"am": "AM", "a.m.": "AM", "a.m": "AM",
+	"pm": "PM", "p.m.": "PM", "p.m": "PM",
+}
+
+// token is a word split into its lowercase core and trailing punctuation.
+type token struct {
+	core  string
+	punct string
+}
+
+func tokenize(text string) []token {
+	fields := strings.Fields(text)
+	out := make([]token, len(fields))
+	for i, f := range fields {
+		lower := strings.ToLower(f)
+		if _, ok := meridiemWords[lower]; ok {
+			out[i] = token{core: lower}
+			continue
+		}
+		core := strings.TrimRight(lower, ".,?!;:")
+		out[i] = token{core: core, punct: f[len(core):]}
+	}
+	return out
+}
+
+// Transform rewrites spoken numbers and times in text.
+func (NumberNormalizer) Transform(text string) string {
+	fields := strings.Fields(text)
+	tokens := tokenize(text)
+
+	var out []string
+	for i := 0; i < len(tokens); {
+		if s, n := matchTime(tokens[i:]); n > 0 {
+			out = append(out, s+tokens[i+n-1].punct)
+			i += n
+			continue
+		}
+		if s, n := matchDigits(tokens[i:]); n > 0 {
+			out = append(out, s+tokens[i+n-1].punct)
+			i += n
+			continue
+		}
+		if v, n := matchCardinal(tokens[i:]); n > 0 && (v >= 10 || n > 1) {
+			out = append(out, strconv.Itoa(v)+tokens[i+n-1].punct)
+			i += n
+			continue
+		}
+		out = append(out, fields[i])
+		i++
+	}
+	return strings.Join(out, " ")
+}
+
+// matchTime matches "<hour> [<minutes>|o'clock] <am|pm>".
+func matchTime(ts []token) (string, int) {
+	if len(ts) < 2 {
+		return "", 0
+	}
+	hour, ok := unitWords[ts[0].core]
+	if !ok || hour < 1 || hour > 12 || ts[0].punct != "" {
+		return "", 0
+	}
+
+	// "<hour> pm"
+	if m, ok := meridiemWords[ts[1].core]; ok {
+		return strconv.Itoa(hour) + " " + m, 2
+	}
+
+	minutes, n := matchMinutes(ts[1:])
+	if n == 0 || len(ts) < 2+n {
+		return "", 0
+	}
+	m, ok := meridiemWords[ts[1+n].core]
+	if !ok {
+		return "", 0
+	}
+	return strconv.Itoa(hour) + ":" + minutes + " " + m, 2 + n
+}
+
+// matchMinutes matches the minutes part of a spoken time and returns it as two digits.
+func matchMinutes(ts []token) (string, int) {
+	if len(ts) == 0 || ts[0].punct != "" {
+		return "", 0
+	}
+	w := ts[0].core
+	switch {
+	case w == "o'clock":
+		return "00", 1
+	case w == "oh" || w == "o":
+		if len(ts) > 1 && ts[1].punct == "" {
+			if u, ok := unitWords[ts[1].core]; ok && u < 10 {
+				return "0" + strconv.Itoa(u), 2
+			}
+		}
+		return "", 0
+	}
+	if u, ok := unitWords[w]; ok && u >= 10 {
+		return strconv.Itoa(u), 1
+	}
+	if t, ok := tensWords[w]; ok && t < 60 {
+		if len(ts) > 1 && ts[1].punct == "" {
+			if u, ok := unitWords[ts[1].core]; ok && u > 0 && u < 10 {
+				return strconv.Itoa(t + u), 2
+			}
+		}
+		return strconv.Itoa(t), 1
+	}
+	return "", 0
+}
+
+// matchDigits matches two or more consecutive single-digit words ("oh" counts
+// as zero) and returns them concatenated.
+func matchDigits(ts []token) (string, int) {
+	var b strings.Builder
+	n := 0
+	for _, t := range ts {
+		d, ok := unitWords[t.core]
+		if t.core == "oh" {
+			d, ok = 0, true
+		}
+		if !ok || d > 9 {
+			break
+		}
+		b.WriteString(strconv.Itoa(d))
+		n++
+		if t.punct != "" {
+			break
+		}
+	}
+	if n < 2 {
+		return "", 0
+	}
+	return b.String(), n
+}
+
+// matchCardinal matches a spoken cardinal number such as "one hundred twenty
+// three" or "two thousand five hundred".
+func matchCardinal(ts []token) (int, int) {
+	total, current, n := 0, 0, 0
+	for _, t := range ts {
+		w := t.core
+		switch {
+		case unitWords[w] > 0 || w == "zero":
+			u := unitWords[w]
+			// A unit may start a group or follow a bare tens word ("twenty one")
+			if current%100 != 0 && !(current%10 == 0 && current%100 >= 20 && u < 10) {
+				return total + current, n
+			}
+			current += u
+		case tensWords[w] > 0:
+			if current%100 != 0 {
+				return total + current, n
+			}
+			current += tensWords[w]
+		case w == "hundred" && n > 0 && current%100 > 0 && current%100 < 10:
+			current = current/100*100 + current%100*100
+		case w == "thousand" && n > 0 && current > 0:
+			total += current * 1000
+			current = 0
+		default:
+			return total + current, n
+		}
+		n++
+		if t.punct != "" {
+			break
+		}
+	}
+	return total + current, n
+}
@@ -0,0 +1,61 @@
+// Package transform provides text transforms applied to transcripts before
+// they are published (e.g. PII redaction).
+package transform
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// TextTransform rewrites transcript text.
+type TextTransform interface {
+	Transform(text string) string
+}
+
+// Chain applies transforms in order. A nil or empty chain returns text unchanged.
+type Chain []TextTransform
+
+// Transform runs text through every transform in the chain.
+func (c Chain) Transform(text string) string {
+	for _, t := range c {
+		text = t.Transform(text)
+	}
+	return text
+}
+
+// DefaultRedactionPatterns match common PII in spoken-number transcripts:
+// payment card numbers (13-19 digits, optionally space/dash separated) and
+// US social security numbers.
+var DefaultRedactionPatterns = []string{
+	`\b(?:\d[ -]?){12,18}\d\b`,
+	`\b\d{3}[ -]?\d{2}[ -]?\d{4}\b`,
+}
+
+// RedactionMask replaces each redacted match.
+const RedactionMask = "[REDACTED]"
+
+// RegexRedactor replaces every match of its patterns with RedactionMask.
+type RegexRedactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRegexRedactor compiles the given patterns into a redactor.
+func NewRegexRedactor(patterns []string) (*RegexRedactor, error) {
+	r := &RegexRedactor{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Transform masks all pattern matches in text.
+func (r *RegexRedactor) Transform(text string) string {
+	for _, re := range r.patterns {
+		text = re.ReplaceAllString(text, RedactionMask)
+	}
+	return text
+}
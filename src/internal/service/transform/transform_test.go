@@ -0,0 +1,93 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegexRedactor_DefaultPatterns(t *testing.T) {
+	r, err := NewRegexRedactor(DefaultRedactionPatterns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"my card is 4111111111111111 thanks", "my card is [REDACTED] thanks"},
+		{"card 4111 1111 1111 1111", "card [REDACTED]"},
+		{"card 4111-1111-1111-1111 expires soon", "card [REDACTED] expires soon"},
+		{"amex 378282246310005", "amex [REDACTED]"},
+		{"my ssn is 123-45-6789", "my ssn is [REDACTED]"},
+		{"ssn 123 45 6789 ok", "ssn [REDACTED] ok"},
+		{"I want to cancel my plan", "I want to cancel my plan"},
+		{"call me at 3 pm", "call me at 3 pm"},
+		{"order 12345", "order 12345"},
+	}
+
+	for _, tt := range tests {
+		if got := r.Transform(tt.in); got != tt.want {
+			t.Errorf("Transform(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRegexRedactor_CustomPattern(t *testing.T) {
+	r, err := NewRegexRedactor([]string{`(?i)account \d+`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := r.Transform("Account 998877 is closed"); got != "[REDACTED] is closed" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestNewRegexRedactor_InvalidPattern(t *testing.T) {
+	if _, err := NewRegexRedactor([]string{"("}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+type upper struct{}
+
+func (upper) Transform(s string) string { return strings.ToUpper(s) }
+
+func TestChain_AppliesInOrder(t *testing.T) {
+	r, _ := NewRegexRedactor([]string{`secret`})
+	c := Chain{r, upper{}}
+
+	if got := c.Transform("my secret word"); got != "MY [REDACTED] WORD" {
+		t.Errorf("got %q", got)
+	}
+	if got := Chain(nil).Transform("unchanged"); got != "unchanged" {
+		t.Errorf("nil chain changed text: %q", got)
+	}
+}
+
+func TestNumberNormalizer(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"let's meet at three thirty pm", "let's meet at 3:30 PM"},
+		{"five fifteen p.m.", "5:15 PM"},
+		{"call at nine oh five am.", "call at 9:05 AM."},
+		{"ten o'clock am", "10:00 AM"},
+		{"see you at seven pm", "see you at 7 PM"},
+		{"it costs twenty five dollars", "it costs 25 dollars"},
+		{"one hundred twenty three", "123"},
+		{"two thousand five hundred", "2500"},
+		{"my card is four one one one", "my card is 4111"},
+		{"wait forty minutes", "wait 40 minutes"},
+		{"I want one", "I want one"},
+		{"I want to cancel my plan", "I want to cancel my plan"},
+	}
+
+	n := NumberNormalizer{}
+	for _, tt := range tests {
+		if got := n.Transform(tt.in); got != tt.want {
+			t.Errorf("Transform(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package subtitles renders final transcripts as SRT or WebVTT subtitles.
+package subtitles
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"ai-speech-ingress-service/internal/models"
+)
+
+// Format is a subtitle file format.
+type Format string
+
+const (
+	SRT    Format = "srt"
+	WebVTT Format = "vtt"
+)
+
+// ParseFormat returns the Format named by s, case-insensitively.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case SRT, WebVTT:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown subtitle format %q (supported: srt, vtt)", s)
+	}
+}
+
+// Options controls how finals are split into cues.
+type Options struct {
+	MaxLineLength  int   // Lines are wrapped at word boundaries to at most this many characters
+	MaxLines       int   // Text needing more lines is split across cues
+	MinCueDuration int64 // Shortest cue in milliseconds, for finals without a usable offset
+}
+
+// DefaultOptions returns common broadcast limits: two lines of 42 characters.
+func DefaultOptions() Options {
+	return Options{MaxLineLength: 42, MaxLines: 2, MinCueDuration: 1000}
+}
+
+// cue is one subtitle shown from start to end (milliseconds).
+type cue struct {
+	start, end int64
+	lines      []string
+}
+
+// Render returns finals as subtitles in format. Finals must be in order;
+// each one's AudioOffsetMs is taken as the end of its speech, and its cues
+// start where the previous final's ended. Timing never goes backwards, even
+// if the offsets do. Finals with empty text produce no cues.
+func Render(format Format, finals []models.TranscriptFinal, opts Options) (string, error) {
+	var b strings.Builder
+	switch format {
+	case SRT:
+		for i, c := range cues(finals, opts) {
+			fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, timestamp(c.start, ','), timestamp(c.end, ','), strings.Join(c.lines, "\n"))
+		}
+	case WebVTT:
+		b.WriteString("WEBVTT\n\n")
+		for _, c := range cues(finals, opts) {
+			fmt.Fprintf(&b, "%s --> %s\n%s\n\n", timestamp(c.start, '.'), timestamp(c.end, '.'), strings.Join(c.lines, "\n"))
+		}
+	default:
+		return "", fmt.Errorf("unknown subtitle format %q", format)
+	}
+	return b.String(), nil
+}
+
+// cues maps finals to cues. A final whose text needs more than MaxLines lines
+// is split across consecutive cues, dividing its time by text length.
+func cues(finals []models.TranscriptFinal, opts Options) []cue {
+	maxLines := max(opts.MaxLines, 1)
+	var out []cue
+	var prevEnd int64
+	for _, f := range finals {
+		lines := wrap(f.Text, opts.MaxLineLength)
+		if len(lines) == 0 {
+			continue
+		}
+		start := prevEnd
+		end := max(f.AudioOffsetMs, start+opts.MinCueDuration)
+
+		total := 0
+		for _, l := range lines {
+			total += utf8.RuneCountInString(l)
+		}
+		done := 0
+		for i := 0; i < len(lines); i += maxLines {
+			chunk := lines[i:min(i+maxLines, len(lines))]
+			for _, l := range chunk {
+				done += utf8.RuneCountInString(l)
+			}
+			cueEnd := prevEnd + (end-prevEnd)*int64(done)/int64(total)
+			if i+maxLines >= len(lines) {
+				cueEnd = end // Avoid rounding short of the final's end
+			}
+			out = append(out, cue{start: start, end: cueEnd, lines: chunk})
+			start = cueEnd
+		}
+		prevEnd = end
+	}
+	return out
+}
+
+// wrap splits text into lines of at most width characters at word
+// boundaries. Words longer than width get a line of their own. A width of
+// zero or less puts all the text on one line.
+func wrap(text string, width int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if width <= 0 {
+		return []string{strings.Join(words, " ")}
+	}
+
+	var lines []string
+	line := words[0]
+	for _, w := range words[1:] {
+		if utf8.RuneCountInString(line)+1+utf8.RuneCountInString(w) > width {
+			lines = append(lines, line)
+			line = w
+			continue
+		}
+		line += " " + w
+	}
+	return append(lines, line)
+}
+
+// timestamp formats ms as HH:MM:SS followed by sep and milliseconds: ","
+// for SRT, "." for WebVTT.
+func timestamp(ms int64, sep byte) string {
+	ms = max(ms, 0)
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
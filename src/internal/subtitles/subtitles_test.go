@@ -0,0 +1,88 @@
+package subtitles
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"ai-speech-ingress-service/internal/models"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// testFinals covers wrapping, splitting a long final across cues, an empty
+// final, and an offset that goes backwards.
+var testFinals = []models.TranscriptFinal{
+	{SegmentID: "int-1-seg-1", Text: "Thanks for calling, how can I help?", AudioOffsetMs: 2400},
+	{SegmentID: "int-1-seg-2", Text: "", AudioOffsetMs: 3000},
+	{SegmentID: "int-1-seg-3", Text: "I'd like to check the status of an order I placed last week, it still hasn't arrived and the tracking page hasn't changed in days.", AudioOffsetMs: 9800},
+	{SegmentID: "int-1-seg-4", Text: "Sure.", AudioOffsetMs: 9500},
+	{SegmentID: "int-1-seg-5", Text: "Could you read me the order number?", AudioOffsetMs: 3723456},
+}
+
+func TestRender_Golden(t *testing.T) {
+	for _, format := range []Format{SRT, WebVTT} {
+		t.Run(string(format), func(t *testing.T) {
+			got, err := Render(format, testFinals, DefaultOptions())
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+
+			path := filepath.Join("testdata", "call."+string(format))
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("Render mismatch with %s (run with -update to rewrite):\n%s", path, got)
+			}
+		})
+	}
+}
+
+func TestCues_Monotonic(t *testing.T) {
+	cs := cues(testFinals, DefaultOptions())
+	var prev int64
+	for i, c := range cs {
+		if c.start < prev || c.end <= c.start {
+			t.Errorf("cue %d runs %d-%d after previous end %d", i, c.start, c.end, prev)
+		}
+		prev = c.end
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"", 10, nil},
+		{"  hello   world ", 0, []string{"hello world"}},
+		{"hello world", 11, []string{"hello world"}},
+		{"hello world", 10, []string{"hello", "world"}},
+		{"a supercalifragilistic word", 10, []string{"a", "supercalifragilistic", "word"}},
+		{"héllo wörld", 11, []string{"héllo wörld"}},
+	}
+	for _, tt := range tests {
+		if got := wrap(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("VTT"); err != nil || f != WebVTT {
+		t.Errorf("ParseFormat(VTT) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("ass"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
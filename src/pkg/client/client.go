@@ -0,0 +1,154 @@
+// Package client streams audio to the speech ingress service from Go
+// programs. It turns a reader of raw PCM into paced, sequenced frames and
+// returns the server's ack or its transcripts.
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "ai-speech-ingress-service/proto"
+)
+
+// Options identify a stream and describe its audio.
+type Options struct {
+	InteractionID string
+	TenantID      string
+	LanguageCode  string // BCP-47; empty uses the server's default
+
+	// Format of the audio read from the reader. Zero uses DefaultFormat.
+	Format Format
+
+	// FrameDuration is the audio per frame. Zero uses 100ms.
+	FrameDuration time.Duration
+
+	// Unpaced sends frames as fast as the reader allows instead of in real
+	// time, e.g. for recorded audio when latency doesn't matter.
+	Unpaced bool
+}
+
+// defaultFrameDuration is the audio per frame when Options leave it unset.
+const defaultFrameDuration = 100 * time.Millisecond
+
+func (o Options) withDefaults() Options {
+	if o.Format == (Format{}) {
+		o.Format = DefaultFormat
+	}
+	if o.FrameDuration <= 0 {
+		o.FrameDuration = defaultFrameDuration
+	}
+	return o
+}
+
+// Client streams audio over a gRPC connection to the service.
+type Client struct {
+	rpc pb.AudioStreamServiceClient
+}
+
+// New creates a client on conn. The caller owns conn and closes it.
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{rpc: pb.NewAudioStreamServiceClient(conn)}
+}
+
+// StreamAudio streams the PCM audio read from r until it is exhausted, then
+// closes the stream and returns the server's ack. Errors the server ends the
+// stream with are returned as gRPC status errors.
+func (c *Client) StreamAudio(ctx context.Context, r io.Reader, opts Options) (*pb.StreamAck, error) {
+	opts = opts.withDefaults()
+	if err := opts.Format.validate(); err != nil {
+		return nil, err
+	}
+
+	stream, err := c.rpc.StreamAudio(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sendPCM(stream.Send, r, opts); err != nil {
+		return nil, err
+	}
+	return stream.CloseAndRecv()
+}
+
+// Transcripts are the transcripts of a StreamTranscribe call. C delivers
+// partials and finals as the server produces them and is closed when the
+// stream ends.
+type Transcripts struct {
+	C <-chan *pb.Transcript
+
+	done chan struct{}
+	err  error
+}
+
+// Err waits for the stream to end and returns why it ended abnormally, or
+// nil once the server has sent every transcript. Receive from C until it is
+// closed before calling Err, or cancel the call's context.
+func (t *Transcripts) Err() error {
+	<-t.done
+	return t.err
+}
+
+// StreamTranscribe streams the PCM audio read from r like StreamAudio, and
+// delivers the transcripts the server streams back while the audio is
+// still being sent.
+func (c *Client) StreamTranscribe(ctx context.Context, r io.Reader, opts Options) (*Transcripts, error) {
+	opts = opts.withDefaults()
+	if err := opts.Format.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.rpc.StreamTranscribe(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	ch := make(chan *pb.Transcript)
+	t := &Transcripts{C: ch, done: make(chan struct{})}
+	sendErr := make(chan error, 1)
+	go func() {
+		if _, err := sendPCM(stream.Send, r, opts); err != nil {
+			sendErr <- err
+			// Ends the receive loop below
+			cancel()
+			return
+		}
+		sendErr <- stream.CloseSend()
+	}()
+
+	go func() {
+		defer close(t.done)
+		defer cancel()
+		defer close(ch)
+		for {
+			tr, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				t.err = <-sendErr
+				return
+			}
+			if err != nil {
+				// A failed send cancels the stream; report the cause
+				select {
+				case serr := <-sendErr:
+					if serr != nil {
+						err = serr
+					}
+				default:
+				}
+				t.err = err
+				return
+			}
+			select {
+			case ch <- tr:
+			case <-ctx.Done():
+				t.err = ctx.Err()
+				return
+			}
+		}
+	}()
+	return t, nil
+}
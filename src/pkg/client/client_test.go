@@ -0,0 +1,221 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "ai-speech-ingress-service/proto"
+)
+
+// mockServer records received frames. StreamTranscribe answers each frame
+// with a partial of its seq and ends with a final.
+type mockServer struct {
+	pb.UnimplementedAudioStreamServiceServer
+
+	mu     sync.Mutex
+	frames []*pb.AudioFrame
+}
+
+func (s *mockServer) receive(recv func() (*pb.AudioFrame, error), onFrame func(*pb.AudioFrame) error) error {
+	for {
+		frame, err := recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if frame.GetTenantId() == "" {
+			return status.Error(codes.InvalidArgument, "tenantId is required")
+		}
+		s.mu.Lock()
+		s.frames = append(s.frames, frame)
+		s.mu.Unlock()
+		if err := onFrame(frame); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *mockServer) StreamAudio(stream pb.AudioStreamService_StreamAudioServer) error {
+	var interactionId string
+	err := s.receive(stream.Recv, func(f *pb.AudioFrame) error {
+		interactionId = f.GetInteractionId()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&pb.StreamAck{InteractionId: interactionId, SegmentState: "CLOSED"})
+}
+
+func (s *mockServer) StreamTranscribe(stream pb.AudioStreamService_StreamTranscribeServer) error {
+	err := s.receive(stream.Recv, func(f *pb.AudioFrame) error {
+		return stream.Send(&pb.Transcript{Text: strconv.FormatUint(f.GetSeq(), 10)})
+	})
+	if err != nil {
+		return err
+	}
+	return stream.Send(&pb.Transcript{Text: "done", IsFinal: true})
+}
+
+func newTestClient(t *testing.T) (*Client, *mockServer) {
+	t.Helper()
+	srv := &mockServer{}
+	g := grpc.NewServer()
+	pb.RegisterAudioStreamServiceServer(g, srv)
+	lis := bufconn.Listen(1 << 20)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return New(conn), srv
+}
+
+func TestStreamAudio(t *testing.T) {
+	c, srv := newTestClient(t)
+
+	// 250ms of 8kHz LINEAR16 plus a stray byte that isn't a whole sample
+	audio := make([]byte, 4001)
+	for i := range audio {
+		audio[i] = byte(i)
+	}
+	start := time.Now()
+	ack, err := c.StreamAudio(context.Background(), bytes.NewReader(audio), Options{
+		InteractionID: "int-1",
+		TenantID:      "tenant-1",
+		LanguageCode:  "es-ES",
+	})
+	if err != nil {
+		t.Fatalf("StreamAudio: %v", err)
+	}
+	if ack.GetInteractionId() != "int-1" {
+		t.Errorf("ack = %v", ack)
+	}
+	// Paced to real time: the last frame starts 200ms in
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("streamed 250ms of audio in %s, want real time", elapsed)
+	}
+
+	if len(srv.frames) != 3 {
+		t.Fatalf("server received %d frames, want 3", len(srv.frames))
+	}
+	first, last := srv.frames[0], srv.frames[2]
+	if first.GetEncoding() != "LINEAR16" || first.GetSampleRateHz() != 8000 || first.GetChannels() != 1 || first.GetLanguageCode() != "es-ES" {
+		t.Errorf("first frame doesn't declare the format: %v", first)
+	}
+	if last.GetEncoding() != "" || last.GetLanguageCode() != "" {
+		t.Error("format declared again after the first frame")
+	}
+	if last.GetSeq() != 3 || last.GetAudioOffsetMs() != 200 || len(last.GetAudio()) != 800 {
+		t.Errorf("last frame: seq=%d offset=%d bytes=%d, want 3/200/800", last.GetSeq(), last.GetAudioOffsetMs(), len(last.GetAudio()))
+	}
+	if !bytes.Equal(last.GetAudio(), audio[3200:4000]) {
+		t.Error("audio not split in order")
+	}
+}
+
+func TestStreamAudio_ServerError(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	_, err := c.StreamAudio(context.Background(), bytes.NewReader(make([]byte, 1600)), Options{InteractionID: "int-1", Unpaced: true})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestStreamAudio_InvalidFormat(t *testing.T) {
+	c, srv := newTestClient(t)
+
+	_, err := c.StreamAudio(context.Background(), bytes.NewReader(make([]byte, 1600)), Options{
+		TenantID: "tenant-1",
+		Format:   Format{SampleRateHz: 16000, Channels: 1, Encoding: "FLAC"},
+	})
+	if err == nil || len(srv.frames) != 0 {
+		t.Errorf("err = %v with %d frames sent, want the format rejected before streaming", err, len(srv.frames))
+	}
+}
+
+func TestStreamTranscribe(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	// 20ms frames of 8kHz MULAW
+	transcripts, err := c.StreamTranscribe(context.Background(), bytes.NewReader(make([]byte, 480)), Options{
+		InteractionID: "int-1",
+		TenantID:      "tenant-1",
+		Format:        Format{SampleRateHz: 8000, Channels: 1, Encoding: "MULAW"},
+		FrameDuration: 20 * time.Millisecond,
+		Unpaced:       true,
+	})
+	if err != nil {
+		t.Fatalf("StreamTranscribe: %v", err)
+	}
+
+	var got []string
+	for tr := range transcripts.C {
+		got = append(got, tr.GetText())
+	}
+	if err := transcripts.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+	want := []string{"1", "2", "3", "done"}
+	if len(got) != len(want) {
+		t.Fatalf("transcripts = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("transcripts = %q, want %q", got, want)
+			break
+		}
+	}
+}
+
+// failingReader returns some audio, then an error.
+type failingReader struct{ n int }
+
+var errRead = errors.New("capture device lost")
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.n > 0 {
+		n := min(len(p), r.n)
+		r.n -= n
+		return n, nil
+	}
+	return 0, errRead
+}
+
+func TestStreamTranscribe_ReadError(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	transcripts, err := c.StreamTranscribe(context.Background(), &failingReader{n: 1600}, Options{TenantID: "tenant-1", Unpaced: true})
+	if err != nil {
+		t.Fatalf("StreamTranscribe: %v", err)
+	}
+	for range transcripts.C {
+	}
+	if err := transcripts.Err(); !errors.Is(err, errRead) {
+		t.Errorf("Err = %v, want the read error", err)
+	}
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	pb "ai-speech-ingress-service/proto"
+)
+
+// Format describes headerless PCM audio.
+type Format struct {
+	SampleRateHz int
+	Channels     int    // Interleaved channels; only the first is recognized
+	Encoding     string // "LINEAR16" or "MULAW"
+}
+
+// DefaultFormat is 8kHz mono LINEAR16, the service's default STT format.
+var DefaultFormat = Format{SampleRateHz: 8000, Channels: 1, Encoding: "LINEAR16"}
+
+func (f Format) validate() error {
+	if f.sampleBytes() == 0 {
+		return fmt.Errorf("unsupported encoding %q (use LINEAR16 or MULAW)", f.Encoding)
+	}
+	if f.SampleRateHz <= 0 || f.Channels <= 0 {
+		return fmt.Errorf("invalid format: %d Hz, %d channels", f.SampleRateHz, f.Channels)
+	}
+	return nil
+}
+
+func (f Format) sampleBytes() int {
+	switch f.Encoding {
+	case "LINEAR16":
+		return 2
+	case "MULAW":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// frameAlign is the size of one sample across all channels; frames must be a
+// multiple of it or the server rejects them as misaligned.
+func (f Format) frameAlign() int {
+	return f.Channels * f.sampleBytes()
+}
+
+// frameBytes is the size of d of audio.
+func (f Format) frameBytes(d time.Duration) int {
+	samples := int(int64(f.SampleRateHz) * d.Milliseconds() / 1000)
+	return max(samples, 1) * f.frameAlign()
+}
+
+// sendPCM reads raw PCM from r and sends it in opts.FrameDuration chunks,
+// paced to real time unless opts.Unpaced is set. A source slower than real
+// time (e.g. a live capture) sets the pace instead. The first frame declares
+// the format and language. Returns the number of frames sent once r is
+// exhausted, or once send returns io.EOF because the server ended the stream.
+func sendPCM(send func(*pb.AudioFrame) error, r io.Reader, opts Options) (int, error) {
+	format := opts.Format
+	buf := make([]byte, format.frameBytes(opts.FrameDuration))
+	align := format.frameAlign()
+	start := time.Now()
+	var sent int
+	var offsetMs int64
+	for {
+		// ReadFull gathers short reads from pipes into whole frames
+		n, err := io.ReadFull(r, buf)
+		eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !eof {
+			return sent, fmt.Errorf("reading audio: %w", err)
+		}
+		if trailing := n % align; trailing != 0 {
+			log.Printf("Discarding %d trailing bytes that don't make up a whole sample", trailing)
+			n -= trailing
+		}
+
+		if n > 0 {
+			frame := &pb.AudioFrame{
+				InteractionId: opts.InteractionID,
+				TenantId:      opts.TenantID,
+				Audio:         append([]byte(nil), buf[:n]...),
+				AudioOffsetMs: offsetMs,
+				Seq:           uint64(sent + 1),
+			}
+			if sent == 0 {
+				frame.SampleRateHz = int32(format.SampleRateHz)
+				frame.Encoding = format.Encoding
+				frame.Channels = int32(format.Channels)
+				frame.LanguageCode = opts.LanguageCode
+			}
+			if err := send(frame); err != nil {
+				if errors.Is(err, io.EOF) {
+					// The server's status is returned by the stream's receive
+					return sent, nil
+				}
+				return sent, fmt.Errorf("sending frame: %w", err)
+			}
+			sent++
+			offsetMs += int64(n/align) * 1000 / int64(format.SampleRateHz)
+
+			// Pace against the stream start so per-frame delays don't accumulate
+			if wait := time.Until(start.Add(time.Duration(offsetMs) * time.Millisecond)); wait > 0 && !opts.Unpaced {
+				time.Sleep(wait)
+			}
+		}
+		if eof {
+			return sent, nil
+		}
+	}
+}
@@ -28,8 +28,32 @@ type AudioFrame struct {
 	Audio          []byte                 `protobuf:"bytes,3,opt,name=audio,proto3" json:"audio,omitempty"`
 	AudioOffsetMs  int64                  `protobuf:"varint,4,opt,name=audioOffsetMs,proto3" json:"audioOffsetMs,omitempty"`
 	EndOfUtterance bool                   `protobuf:"varint,5,opt,name=endOfUtterance,proto3" json:"endOfUtterance,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Client-assigned frame sequence number, incremented by one per frame.
+	// 0 means unset and disables gap detection.
+	Seq uint64 `protobuf:"varint,6,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Abandons the current segment without a final (drop reason "client_cancel")
+	// and starts a new one; the stream stays open. Audio in the same frame
+	// belongs to the new segment.
+	CancelSegment bool `protobuf:"varint,7,opt,name=cancelSegment,proto3" json:"cancelSegment,omitempty"`
+	// Audio format declared by the client; only read from the first frame.
+	// When set and different from the server's STT config, the stream is
+	// recognized in the declared format, or rejected with INVALID_ARGUMENT if
+	// the provider can't honor it. 0 / "" use the server's configured format.
+	SampleRateHz int32  `protobuf:"varint,8,opt,name=sampleRateHz,proto3" json:"sampleRateHz,omitempty"`
+	Encoding     string `protobuf:"bytes,9,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	// Interleaved channel count; only the first channel is recognized.
+	Channels int32 `protobuf:"varint,10,opt,name=channels,proto3" json:"channels,omitempty"`
+	// BCP-47 language of the stream; only read from the first frame. The
+	// server's language profile for it, if any, sets the default format and
+	// model. "" uses the server's configured language.
+	LanguageCode string `protobuf:"bytes,11,opt,name=languageCode,proto3" json:"languageCode,omitempty"`
+	// Publishes the current segment's latest partial as its final and starts a
+	// new segment; the stream stays open. Use when client-side VAD detects a
+	// boundary the provider missed. Audio in the same frame belongs to the new
+	// segment.
+	FinalizeSegment bool `protobuf:"varint,12,opt,name=finalizeSegment,proto3" json:"finalizeSegment,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *AudioFrame) Reset() {
@@ -97,9 +121,66 @@ func (x *AudioFrame) GetEndOfUtterance() bool {
 	return false
 }
 
+func (x *AudioFrame) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AudioFrame) GetCancelSegment() bool {
+	if x != nil {
+		return x.CancelSegment
+	}
+	return false
+}
+
+func (x *AudioFrame) GetSampleRateHz() int32 {
+	if x != nil {
+		return x.SampleRateHz
+	}
+	return 0
+}
+
+func (x *AudioFrame) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *AudioFrame) GetChannels() int32 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *AudioFrame) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *AudioFrame) GetFinalizeSegment() bool {
+	if x != nil {
+		return x.FinalizeSegment
+	}
+	return false
+}
+
 type StreamAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	InteractionId string                 `protobuf:"bytes,1,opt,name=interactionId,proto3" json:"interactionId,omitempty"`
+	// Set when the stream was closed because it exceeded the maximum interaction duration.
+	InteractionCapped bool `protobuf:"varint,2,opt,name=interactionCapped,proto3" json:"interactionCapped,omitempty"`
+	// Lifecycle state of the last segment when the stream ended, e.g. "OPEN", "DROPPED".
+	SegmentState string `protobuf:"bytes,3,opt,name=segmentState,proto3" json:"segmentState,omitempty"`
+	// Set when the last segment was dropped without publishing a final.
+	SegmentDropped bool `protobuf:"varint,4,opt,name=segmentDropped,proto3" json:"segmentDropped,omitempty"`
+	// Why the last segment was dropped, e.g. "empty_final", "invalid_frame".
+	DropReason    string `protobuf:"bytes,5,opt,name=dropReason,proto3" json:"dropReason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -141,22 +222,823 @@ func (x *StreamAck) GetInteractionId() string {
 	return ""
 }
 
-var File_proto_audio_proto protoreflect.FileDescriptor
+func (x *StreamAck) GetInteractionCapped() bool {
+	if x != nil {
+		return x.InteractionCapped
+	}
+	return false
+}
 
-const file_proto_audio_proto_rawDesc = "" +
-	"\n" +
-	"\x11proto/audio.proto\x12\x11ai.speech.ingress\"\xb2\x01\n" +
-	"\n" +
-	"AudioFrame\x12$\n" +
-	"\rinteractionId\x18\x01 \x01(\tR\rinteractionId\x12\x1a\n" +
-	"\btenantId\x18\x02 \x01(\tR\btenantId\x12\x14\n" +
-	"\x05audio\x18\x03 \x01(\fR\x05audio\x12$\n" +
-	"\raudioOffsetMs\x18\x04 \x01(\x03R\raudioOffsetMs\x12&\n" +
-	"\x0eendOfUtterance\x18\x05 \x01(\bR\x0eendOfUtterance\"1\n" +
-	"\tStreamAck\x12$\n" +
-	"\rinteractionId\x18\x01 \x01(\tR\rinteractionId2b\n" +
+func (x *StreamAck) GetSegmentState() string {
+	if x != nil {
+		return x.SegmentState
+	}
+	return ""
+}
+
+func (x *StreamAck) GetSegmentDropped() bool {
+	if x != nil {
+		return x.SegmentDropped
+	}
+	return false
+}
+
+func (x *StreamAck) GetDropReason() string {
+	if x != nil {
+		return x.DropReason
+	}
+	return ""
+}
+
+// Transcript mirrors the partial/final transcript events published to Kafka.
+type Transcript struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=eventType,proto3" json:"eventType,omitempty"`
+	InteractionId string                 `protobuf:"bytes,2,opt,name=interactionId,proto3" json:"interactionId,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenantId,proto3" json:"tenantId,omitempty"`
+	SegmentId     string                 `protobuf:"bytes,4,opt,name=segmentId,proto3" json:"segmentId,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	IsFinal       bool                   `protobuf:"varint,6,opt,name=isFinal,proto3" json:"isFinal,omitempty"`
+	// Final-only fields
+	Confidence    float64        `protobuf:"fixed64,7,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	AudioOffsetMs int64          `protobuf:"varint,8,opt,name=audioOffsetMs,proto3" json:"audioOffsetMs,omitempty"`
+	Alternatives  []*Alternative `protobuf:"bytes,9,rep,name=alternatives,proto3" json:"alternatives,omitempty"`
+	Timestamp     int64          `protobuf:"varint,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transcript) Reset() {
+	*x = Transcript{}
+	mi := &file_proto_audio_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transcript) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transcript) ProtoMessage() {}
+
+func (x *Transcript) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transcript.ProtoReflect.Descriptor instead.
+func (*Transcript) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transcript) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Transcript) GetInteractionId() string {
+	if x != nil {
+		return x.InteractionId
+	}
+	return ""
+}
+
+func (x *Transcript) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Transcript) GetSegmentId() string {
+	if x != nil {
+		return x.SegmentId
+	}
+	return ""
+}
+
+func (x *Transcript) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Transcript) GetIsFinal() bool {
+	if x != nil {
+		return x.IsFinal
+	}
+	return false
+}
+
+func (x *Transcript) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *Transcript) GetAudioOffsetMs() int64 {
+	if x != nil {
+		return x.AudioOffsetMs
+	}
+	return 0
+}
+
+func (x *Transcript) GetAlternatives() []*Alternative {
+	if x != nil {
+		return x.Alternatives
+	}
+	return nil
+}
+
+func (x *Transcript) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type Alternative struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alternative) Reset() {
+	*x = Alternative{}
+	mi := &file_proto_audio_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alternative) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alternative) ProtoMessage() {}
+
+func (x *Alternative) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alternative.ProtoReflect.Descriptor instead.
+func (*Alternative) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Alternative) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Alternative) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+type GetCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
+	mi := &file_proto_audio_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{4}
+}
+
+type Capabilities struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SttProvider string                 `protobuf:"bytes,1,opt,name=sttProvider,proto3" json:"sttProvider,omitempty"`
+	// Encoding names accepted for STT_ENCODING, e.g. "LINEAR16", "MULAW".
+	SupportedEncodings []string `protobuf:"bytes,2,rep,name=supportedEncodings,proto3" json:"supportedEncodings,omitempty"`
+	LanguageCode       string   `protobuf:"bytes,3,opt,name=languageCode,proto3" json:"languageCode,omitempty"`
+	SampleRateHz       int32    `protobuf:"varint,4,opt,name=sampleRateHz,proto3" json:"sampleRateHz,omitempty"`
+	Encoding           string   `protobuf:"bytes,5,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	// True when the provider ends a segment at each detected utterance boundary;
+	// false for continuous recognition.
+	SingleUtterance bool `protobuf:"varint,6,opt,name=singleUtterance,proto3" json:"singleUtterance,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	mi := &file_proto_audio_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Capabilities) GetSttProvider() string {
+	if x != nil {
+		return x.SttProvider
+	}
+	return ""
+}
+
+func (x *Capabilities) GetSupportedEncodings() []string {
+	if x != nil {
+		return x.SupportedEncodings
+	}
+	return nil
+}
+
+func (x *Capabilities) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *Capabilities) GetSampleRateHz() int32 {
+	if x != nil {
+		return x.SampleRateHz
+	}
+	return 0
+}
+
+func (x *Capabilities) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *Capabilities) GetSingleUtterance() bool {
+	if x != nil {
+		return x.SingleUtterance
+	}
+	return false
+}
+
+type TranscribeFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InteractionId string                 `protobuf:"bytes,1,opt,name=interactionId,proto3" json:"interactionId,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenantId,proto3" json:"tenantId,omitempty"`
+	Audio         []byte                 `protobuf:"bytes,3,opt,name=audio,proto3" json:"audio,omitempty"`
+	// Audio format; 0 / "" use the server's configured format.
+	SampleRateHz int32  `protobuf:"varint,4,opt,name=sampleRateHz,proto3" json:"sampleRateHz,omitempty"`
+	Encoding     string `protobuf:"bytes,5,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	Channels     int32  `protobuf:"varint,6,opt,name=channels,proto3" json:"channels,omitempty"`
+	// "srt" or "vtt" to also return the transcript as subtitles; "" for none.
+	SubtitleFormat string `protobuf:"bytes,7,opt,name=subtitleFormat,proto3" json:"subtitleFormat,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TranscribeFileRequest) Reset() {
+	*x = TranscribeFileRequest{}
+	mi := &file_proto_audio_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscribeFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscribeFileRequest) ProtoMessage() {}
+
+func (x *TranscribeFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscribeFileRequest.ProtoReflect.Descriptor instead.
+func (*TranscribeFileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TranscribeFileRequest) GetInteractionId() string {
+	if x != nil {
+		return x.InteractionId
+	}
+	return ""
+}
+
+func (x *TranscribeFileRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *TranscribeFileRequest) GetAudio() []byte {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *TranscribeFileRequest) GetSampleRateHz() int32 {
+	if x != nil {
+		return x.SampleRateHz
+	}
+	return 0
+}
+
+func (x *TranscribeFileRequest) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *TranscribeFileRequest) GetChannels() int32 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *TranscribeFileRequest) GetSubtitleFormat() string {
+	if x != nil {
+		return x.SubtitleFormat
+	}
+	return ""
+}
+
+type TranscribeFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InteractionId string                 `protobuf:"bytes,1,opt,name=interactionId,proto3" json:"interactionId,omitempty"`
+	// Best hypotheses of all segments, joined with spaces.
+	Text     string         `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Segments []*FileSegment `protobuf:"bytes,3,rep,name=segments,proto3" json:"segments,omitempty"`
+	// Segments rendered in the requested subtitleFormat.
+	Subtitles     string `protobuf:"bytes,4,opt,name=subtitles,proto3" json:"subtitles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscribeFileResponse) Reset() {
+	*x = TranscribeFileResponse{}
+	mi := &file_proto_audio_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscribeFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscribeFileResponse) ProtoMessage() {}
+
+func (x *TranscribeFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscribeFileResponse.ProtoReflect.Descriptor instead.
+func (*TranscribeFileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TranscribeFileResponse) GetInteractionId() string {
+	if x != nil {
+		return x.InteractionId
+	}
+	return ""
+}
+
+func (x *TranscribeFileResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *TranscribeFileResponse) GetSegments() []*FileSegment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *TranscribeFileResponse) GetSubtitles() string {
+	if x != nil {
+		return x.Subtitles
+	}
+	return ""
+}
+
+type FileSegment struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SegmentId  string                 `protobuf:"bytes,1,opt,name=segmentId,proto3" json:"segmentId,omitempty"`
+	Text       string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Confidence float64                `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	// End of the segment relative to the start of the audio.
+	EndOffsetMs   int64          `protobuf:"varint,4,opt,name=endOffsetMs,proto3" json:"endOffsetMs,omitempty"`
+	Alternatives  []*Alternative `protobuf:"bytes,5,rep,name=alternatives,proto3" json:"alternatives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSegment) Reset() {
+	*x = FileSegment{}
+	mi := &file_proto_audio_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSegment) ProtoMessage() {}
+
+func (x *FileSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSegment.ProtoReflect.Descriptor instead.
+func (*FileSegment) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FileSegment) GetSegmentId() string {
+	if x != nil {
+		return x.SegmentId
+	}
+	return ""
+}
+
+func (x *FileSegment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *FileSegment) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *FileSegment) GetEndOffsetMs() int64 {
+	if x != nil {
+		return x.EndOffsetMs
+	}
+	return 0
+}
+
+func (x *FileSegment) GetAlternatives() []*Alternative {
+	if x != nil {
+		return x.Alternatives
+	}
+	return nil
+}
+
+type GetInteractionStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InteractionId string                 `protobuf:"bytes,1,opt,name=interactionId,proto3" json:"interactionId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInteractionStatusRequest) Reset() {
+	*x = GetInteractionStatusRequest{}
+	mi := &file_proto_audio_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInteractionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInteractionStatusRequest) ProtoMessage() {}
+
+func (x *GetInteractionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInteractionStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetInteractionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetInteractionStatusRequest) GetInteractionId() string {
+	if x != nil {
+		return x.InteractionId
+	}
+	return ""
+}
+
+type InteractionStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InteractionId string                 `protobuf:"bytes,1,opt,name=interactionId,proto3" json:"interactionId,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenantId,proto3" json:"tenantId,omitempty"`
+	SegmentId     string                 `protobuf:"bytes,3,opt,name=segmentId,proto3" json:"segmentId,omitempty"`
+	// Segment lifecycle state, e.g. "OPEN", "FINAL_EMITTED".
+	State           string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	AudioBytes      int64  `protobuf:"varint,5,opt,name=audioBytes,proto3" json:"audioBytes,omitempty"`
+	AudioDurationMs int64  `protobuf:"varint,6,opt,name=audioDurationMs,proto3" json:"audioDurationMs,omitempty"`
+	PartialCount    int32  `protobuf:"varint,7,opt,name=partialCount,proto3" json:"partialCount,omitempty"`
+	// Time since the stream started.
+	DurationMs int64 `protobuf:"varint,8,opt,name=durationMs,proto3" json:"durationMs,omitempty"`
+	// Streams currently open for the interaction; the status is of the most
+	// recently started one.
+	ActiveStreams int32 `protobuf:"varint,9,opt,name=activeStreams,proto3" json:"activeStreams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InteractionStatus) Reset() {
+	*x = InteractionStatus{}
+	mi := &file_proto_audio_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InteractionStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InteractionStatus) ProtoMessage() {}
+
+func (x *InteractionStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audio_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InteractionStatus.ProtoReflect.Descriptor instead.
+func (*InteractionStatus) Descriptor() ([]byte, []int) {
+	return file_proto_audio_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InteractionStatus) GetInteractionId() string {
+	if x != nil {
+		return x.InteractionId
+	}
+	return ""
+}
+
+func (x *InteractionStatus) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *InteractionStatus) GetSegmentId() string {
+	if x != nil {
+		return x.SegmentId
+	}
+	return ""
+}
+
+func (x *InteractionStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *InteractionStatus) GetAudioBytes() int64 {
+	if x != nil {
+		return x.AudioBytes
+	}
+	return 0
+}
+
+func (x *InteractionStatus) GetAudioDurationMs() int64 {
+	if x != nil {
+		return x.AudioDurationMs
+	}
+	return 0
+}
+
+func (x *InteractionStatus) GetPartialCount() int32 {
+	if x != nil {
+		return x.PartialCount
+	}
+	return 0
+}
+
+func (x *InteractionStatus) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *InteractionStatus) GetActiveStreams() int32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+var File_proto_audio_proto protoreflect.FileDescriptor
+
+const file_proto_audio_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/audio.proto\x12\x11ai.speech.ingress\"\x94\x03\n" +
+	"\n" +
+	"AudioFrame\x12$\n" +
+	"\rinteractionId\x18\x01 \x01(\tR\rinteractionId\x12\x1a\n" +
+	"\btenantId\x18\x02 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05audio\x18\x03 \x01(\fR\x05audio\x12$\n" +
+	"\raudioOffsetMs\x18\x04 \x01(\x03R\raudioOffsetMs\x12&\n" +
+	"\x0eendOfUtterance\x18\x05 \x01(\bR\x0eendOfUtterance\x12\x10\n" +
+	"\x03seq\x18\x06 \x01(\x04R\x03seq\x12$\n" +
+	"\rcancelSegment\x18\a \x01(\bR\rcancelSegment\x12\"\n" +
+	"\fsampleRateHz\x18\b \x01(\x05R\fsampleRateHz\x12\x1a\n" +
+	"\bencoding\x18\t \x01(\tR\bencoding\x12\x1a\n" +
+	"\bchannels\x18\n" +
+	" \x01(\x05R\bchannels\x12\"\n" +
+	"\flanguageCode\x18\v \x01(\tR\flanguageCode\x12(\n" +
+	"\x0ffinalizeSegment\x18\f \x01(\bR\x0ffinalizeSegment\"\xcb\x01\n" +
+	"\tStreamAck\x12$\n" +
+	"\rinteractionId\x18\x01 \x01(\tR\rinteractionId\x12,\n" +
+	"\x11interactionCapped\x18\x02 \x01(\bR\x11interactionCapped\x12\"\n" +
+	"\fsegmentState\x18\x03 \x01(\tR\fsegmentState\x12&\n" +
+	"\x0esegmentDropped\x18\x04 \x01(\bR\x0esegmentDropped\x12\x1e\n" +
+	"\n" +
+	"dropReason\x18\x05 \x01(\tR\n" +
+	"dropReason\"\xe0\x02\n" +
+	"\n" +
+	"Transcript\x12\x1c\n" +
+	"\teventType\x18\x01 \x01(\tR\teventType\x12$\n" +
+	"\rinteractionId\x18\x02 \x01(\tR\rinteractionId\x12\x1a\n" +
+	"\btenantId\x18\x03 \x01(\tR\btenantId\x12\x1c\n" +
+	"\tsegmentId\x18\x04 \x01(\tR\tsegmentId\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x18\n" +
+	"\aisFinal\x18\x06 \x01(\bR\aisFinal\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\a \x01(\x01R\n" +
+	"confidence\x12$\n" +
+	"\raudioOffsetMs\x18\b \x01(\x03R\raudioOffsetMs\x12B\n" +
+	"\falternatives\x18\t \x03(\v2\x1e.ai.speech.ingress.AlternativeR\falternatives\x12\x1c\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\x03R\ttimestamp\"A\n" +
+	"\vAlternative\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\x01R\n" +
+	"confidence\"\x18\n" +
+	"\x16GetCapabilitiesRequest\"\xee\x01\n" +
+	"\fCapabilities\x12 \n" +
+	"\vsttProvider\x18\x01 \x01(\tR\vsttProvider\x12.\n" +
+	"\x12supportedEncodings\x18\x02 \x03(\tR\x12supportedEncodings\x12\"\n" +
+	"\flanguageCode\x18\x03 \x01(\tR\flanguageCode\x12\"\n" +
+	"\fsampleRateHz\x18\x04 \x01(\x05R\fsampleRateHz\x12\x1a\n" +
+	"\bencoding\x18\x05 \x01(\tR\bencoding\x12(\n" +
+	"\x0fsingleUtterance\x18\x06 \x01(\bR\x0fsingleUtterance\"\xf3\x01\n" +
+	"\x15TranscribeFileRequest\x12$\n" +
+	"\rinteractionId\x18\x01 \x01(\tR\rinteractionId\x12\x1a\n" +
+	"\btenantId\x18\x02 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05audio\x18\x03 \x01(\fR\x05audio\x12\"\n" +
+	"\fsampleRateHz\x18\x04 \x01(\x05R\fsampleRateHz\x12\x1a\n" +
+	"\bencoding\x18\x05 \x01(\tR\bencoding\x12\x1a\n" +
+	"\bchannels\x18\x06 \x01(\x05R\bchannels\x12&\n" +
+	"\x0esubtitleFormat\x18\a \x01(\tR\x0esubtitleFormat\"\xac\x01\n" +
+	"\x16TranscribeFileResponse\x12$\n" +
+	"\rinteractionId\x18\x01 \x01(\tR\rinteractionId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12:\n" +
+	"\bsegments\x18\x03 \x03(\v2\x1e.ai.speech.ingress.FileSegmentR\bsegments\x12\x1c\n" +
+	"\tsubtitles\x18\x04 \x01(\tR\tsubtitles\"\xc5\x01\n" +
+	"\vFileSegment\x12\x1c\n" +
+	"\tsegmentId\x18\x01 \x01(\tR\tsegmentId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x03 \x01(\x01R\n" +
+	"confidence\x12 \n" +
+	"\vendOffsetMs\x18\x04 \x01(\x03R\vendOffsetMs\x12B\n" +
+	"\falternatives\x18\x05 \x03(\v2\x1e.ai.speech.ingress.AlternativeR\falternatives\"C\n" +
+	"\x1bGetInteractionStatusRequest\x12$\n" +
+	"\rinteractionId\x18\x01 \x01(\tR\rinteractionId\"\xbd\x02\n" +
+	"\x11InteractionStatus\x12$\n" +
+	"\rinteractionId\x18\x01 \x01(\tR\rinteractionId\x12\x1a\n" +
+	"\btenantId\x18\x02 \x01(\tR\btenantId\x12\x1c\n" +
+	"\tsegmentId\x18\x03 \x01(\tR\tsegmentId\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x1e\n" +
+	"\n" +
+	"audioBytes\x18\x05 \x01(\x03R\n" +
+	"audioBytes\x12(\n" +
+	"\x0faudioDurationMs\x18\x06 \x01(\x03R\x0faudioDurationMs\x12\"\n" +
+	"\fpartialCount\x18\a \x01(\x05R\fpartialCount\x12\x1e\n" +
+	"\n" +
+	"durationMs\x18\b \x01(\x03R\n" +
+	"durationMs\x12$\n" +
+	"\ractiveStreams\x18\t \x01(\x05R\ractiveStreams2\xec\x03\n" +
 	"\x12AudioStreamService\x12L\n" +
-	"\vStreamAudio\x12\x1d.ai.speech.ingress.AudioFrame\x1a\x1c.ai.speech.ingress.StreamAck(\x01B'Z%ai-speech-ingress-service/proto;protob\x06proto3"
+	"\vStreamAudio\x12\x1d.ai.speech.ingress.AudioFrame\x1a\x1c.ai.speech.ingress.StreamAck(\x01\x12]\n" +
+	"\x0fGetCapabilities\x12).ai.speech.ingress.GetCapabilitiesRequest\x1a\x1f.ai.speech.ingress.Capabilities\x12T\n" +
+	"\x10StreamTranscribe\x12\x1d.ai.speech.ingress.AudioFrame\x1a\x1d.ai.speech.ingress.Transcript(\x010\x01\x12e\n" +
+	"\x0eTranscribeFile\x12(.ai.speech.ingress.TranscribeFileRequest\x1a).ai.speech.ingress.TranscribeFileResponse\x12l\n" +
+	"\x14GetInteractionStatus\x12..ai.speech.ingress.GetInteractionStatusRequest\x1a$.ai.speech.ingress.InteractionStatusB'Z%ai-speech-ingress-service/proto;protob\x06proto3"
 
 var (
 	file_proto_audio_proto_rawDescOnce sync.Once
@@ -170,19 +1052,39 @@ func file_proto_audio_proto_rawDescGZIP() []byte {
 	return file_proto_audio_proto_rawDescData
 }
 
-var file_proto_audio_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_audio_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_proto_audio_proto_goTypes = []any{
-	(*AudioFrame)(nil), // 0: ai.speech.ingress.AudioFrame
-	(*StreamAck)(nil),  // 1: ai.speech.ingress.StreamAck
+	(*AudioFrame)(nil),                  // 0: ai.speech.ingress.AudioFrame
+	(*StreamAck)(nil),                   // 1: ai.speech.ingress.StreamAck
+	(*Transcript)(nil),                  // 2: ai.speech.ingress.Transcript
+	(*Alternative)(nil),                 // 3: ai.speech.ingress.Alternative
+	(*GetCapabilitiesRequest)(nil),      // 4: ai.speech.ingress.GetCapabilitiesRequest
+	(*Capabilities)(nil),                // 5: ai.speech.ingress.Capabilities
+	(*TranscribeFileRequest)(nil),       // 6: ai.speech.ingress.TranscribeFileRequest
+	(*TranscribeFileResponse)(nil),      // 7: ai.speech.ingress.TranscribeFileResponse
+	(*FileSegment)(nil),                 // 8: ai.speech.ingress.FileSegment
+	(*GetInteractionStatusRequest)(nil), // 9: ai.speech.ingress.GetInteractionStatusRequest
+	(*InteractionStatus)(nil),           // 10: ai.speech.ingress.InteractionStatus
 }
 var file_proto_audio_proto_depIdxs = []int32{
-	0, // 0: ai.speech.ingress.AudioStreamService.StreamAudio:input_type -> ai.speech.ingress.AudioFrame
-	1, // 1: ai.speech.ingress.AudioStreamService.StreamAudio:output_type -> ai.speech.ingress.StreamAck
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3,  // 0: ai.speech.ingress.Transcript.alternatives:type_name -> ai.speech.ingress.Alternative
+	8,  // 1: ai.speech.ingress.TranscribeFileResponse.segments:type_name -> ai.speech.ingress.FileSegment
+	3,  // 2: ai.speech.ingress.FileSegment.alternatives:type_name -> ai.speech.ingress.Alternative
+	0,  // 3: ai.speech.ingress.AudioStreamService.StreamAudio:input_type -> ai.speech.ingress.AudioFrame
+	4,  // 4: ai.speech.ingress.AudioStreamService.GetCapabilities:input_type -> ai.speech.ingress.GetCapabilitiesRequest
+	0,  // 5: ai.speech.ingress.AudioStreamService.StreamTranscribe:input_type -> ai.speech.ingress.AudioFrame
+	6,  // 6: ai.speech.ingress.AudioStreamService.TranscribeFile:input_type -> ai.speech.ingress.TranscribeFileRequest
+	9,  // 7: ai.speech.ingress.AudioStreamService.GetInteractionStatus:input_type -> ai.speech.ingress.GetInteractionStatusRequest
+	1,  // 8: ai.speech.ingress.AudioStreamService.StreamAudio:output_type -> ai.speech.ingress.StreamAck
+	5,  // 9: ai.speech.ingress.AudioStreamService.GetCapabilities:output_type -> ai.speech.ingress.Capabilities
+	2,  // 10: ai.speech.ingress.AudioStreamService.StreamTranscribe:output_type -> ai.speech.ingress.Transcript
+	7,  // 11: ai.speech.ingress.AudioStreamService.TranscribeFile:output_type -> ai.speech.ingress.TranscribeFileResponse
+	10, // 12: ai.speech.ingress.AudioStreamService.GetInteractionStatus:output_type -> ai.speech.ingress.InteractionStatus
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_audio_proto_init() }
@@ -196,7 +1098,7 @@ func file_proto_audio_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_audio_proto_rawDesc), len(file_proto_audio_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
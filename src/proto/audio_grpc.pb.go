@@ -19,7 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AudioStreamService_StreamAudio_FullMethodName = "/ai.speech.ingress.AudioStreamService/StreamAudio"
+	AudioStreamService_StreamAudio_FullMethodName          = "/ai.speech.ingress.AudioStreamService/StreamAudio"
+	AudioStreamService_GetCapabilities_FullMethodName      = "/ai.speech.ingress.AudioStreamService/GetCapabilities"
+	AudioStreamService_StreamTranscribe_FullMethodName     = "/ai.speech.ingress.AudioStreamService/StreamTranscribe"
+	AudioStreamService_TranscribeFile_FullMethodName       = "/ai.speech.ingress.AudioStreamService/TranscribeFile"
+	AudioStreamService_GetInteractionStatus_FullMethodName = "/ai.speech.ingress.AudioStreamService/GetInteractionStatus"
 )
 
 // AudioStreamServiceClient is the client API for AudioStreamService service.
@@ -27,6 +31,16 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AudioStreamServiceClient interface {
 	StreamAudio(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AudioFrame, StreamAck], error)
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error)
+	// StreamTranscribe behaves like StreamAudio but also streams transcripts
+	// back to the client as they are produced. Events are still published to Kafka.
+	StreamTranscribe(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AudioFrame, Transcript], error)
+	// TranscribeFile recognizes a complete audio clip in one request and returns
+	// the whole transcript. Results are not published to Kafka.
+	TranscribeFile(ctx context.Context, in *TranscribeFileRequest, opts ...grpc.CallOption) (*TranscribeFileResponse, error)
+	// GetInteractionStatus reports the current segment and live metrics of an
+	// active interaction. Requires DEBUG_ENDPOINTS_ENABLED.
+	GetInteractionStatus(ctx context.Context, in *GetInteractionStatusRequest, opts ...grpc.CallOption) (*InteractionStatus, error)
 }
 
 type audioStreamServiceClient struct {
@@ -50,11 +64,64 @@ func (c *audioStreamServiceClient) StreamAudio(ctx context.Context, opts ...grpc
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AudioStreamService_StreamAudioClient = grpc.ClientStreamingClient[AudioFrame, StreamAck]
 
+func (c *audioStreamServiceClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, AudioStreamService_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *audioStreamServiceClient) StreamTranscribe(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AudioFrame, Transcript], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AudioStreamService_ServiceDesc.Streams[1], AudioStreamService_StreamTranscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AudioFrame, Transcript]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AudioStreamService_StreamTranscribeClient = grpc.BidiStreamingClient[AudioFrame, Transcript]
+
+func (c *audioStreamServiceClient) TranscribeFile(ctx context.Context, in *TranscribeFileRequest, opts ...grpc.CallOption) (*TranscribeFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranscribeFileResponse)
+	err := c.cc.Invoke(ctx, AudioStreamService_TranscribeFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *audioStreamServiceClient) GetInteractionStatus(ctx context.Context, in *GetInteractionStatusRequest, opts ...grpc.CallOption) (*InteractionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InteractionStatus)
+	err := c.cc.Invoke(ctx, AudioStreamService_GetInteractionStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AudioStreamServiceServer is the server API for AudioStreamService service.
 // All implementations must embed UnimplementedAudioStreamServiceServer
 // for forward compatibility.
 type AudioStreamServiceServer interface {
 	StreamAudio(grpc.ClientStreamingServer[AudioFrame, StreamAck]) error
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*Capabilities, error)
+	// StreamTranscribe behaves like StreamAudio but also streams transcripts
+	// back to the client as they are produced. Events are still published to Kafka.
+	StreamTranscribe(grpc.BidiStreamingServer[AudioFrame, Transcript]) error
+	// TranscribeFile recognizes a complete audio clip in one request and returns
+	// the whole transcript. Results are not published to Kafka.
+	TranscribeFile(context.Context, *TranscribeFileRequest) (*TranscribeFileResponse, error)
+	// GetInteractionStatus reports the current segment and live metrics of an
+	// active interaction. Requires DEBUG_ENDPOINTS_ENABLED.
+	GetInteractionStatus(context.Context, *GetInteractionStatusRequest) (*InteractionStatus, error)
 	mustEmbedUnimplementedAudioStreamServiceServer()
 }
 
@@ -68,6 +135,18 @@ type UnimplementedAudioStreamServiceServer struct{}
 func (UnimplementedAudioStreamServiceServer) StreamAudio(grpc.ClientStreamingServer[AudioFrame, StreamAck]) error {
 	return status.Error(codes.Unimplemented, "method StreamAudio not implemented")
 }
+func (UnimplementedAudioStreamServiceServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*Capabilities, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedAudioStreamServiceServer) StreamTranscribe(grpc.BidiStreamingServer[AudioFrame, Transcript]) error {
+	return status.Error(codes.Unimplemented, "method StreamTranscribe not implemented")
+}
+func (UnimplementedAudioStreamServiceServer) TranscribeFile(context.Context, *TranscribeFileRequest) (*TranscribeFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TranscribeFile not implemented")
+}
+func (UnimplementedAudioStreamServiceServer) GetInteractionStatus(context.Context, *GetInteractionStatusRequest) (*InteractionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInteractionStatus not implemented")
+}
 func (UnimplementedAudioStreamServiceServer) mustEmbedUnimplementedAudioStreamServiceServer() {}
 func (UnimplementedAudioStreamServiceServer) testEmbeddedByValue()                            {}
 
@@ -96,19 +175,99 @@ func _AudioStreamService_StreamAudio_Handler(srv interface{}, stream grpc.Server
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AudioStreamService_StreamAudioServer = grpc.ClientStreamingServer[AudioFrame, StreamAck]
 
+func _AudioStreamService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AudioStreamServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AudioStreamService_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AudioStreamServiceServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AudioStreamService_StreamTranscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AudioStreamServiceServer).StreamTranscribe(&grpc.GenericServerStream[AudioFrame, Transcript]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AudioStreamService_StreamTranscribeServer = grpc.BidiStreamingServer[AudioFrame, Transcript]
+
+func _AudioStreamService_TranscribeFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranscribeFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AudioStreamServiceServer).TranscribeFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AudioStreamService_TranscribeFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AudioStreamServiceServer).TranscribeFile(ctx, req.(*TranscribeFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AudioStreamService_GetInteractionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInteractionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AudioStreamServiceServer).GetInteractionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AudioStreamService_GetInteractionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AudioStreamServiceServer).GetInteractionStatus(ctx, req.(*GetInteractionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AudioStreamService_ServiceDesc is the grpc.ServiceDesc for AudioStreamService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var AudioStreamService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "ai.speech.ingress.AudioStreamService",
 	HandlerType: (*AudioStreamServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _AudioStreamService_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "TranscribeFile",
+			Handler:    _AudioStreamService_TranscribeFile_Handler,
+		},
+		{
+			MethodName: "GetInteractionStatus",
+			Handler:    _AudioStreamService_GetInteractionStatus_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "StreamAudio",
 			Handler:       _AudioStreamService_StreamAudio_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamTranscribe",
+			Handler:       _AudioStreamService_StreamTranscribe_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "proto/audio.proto",
 }